@@ -0,0 +1,105 @@
+package ibclient
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"sync"
+)
+
+// ReadCoalescer coalesces the identical GET requests in flight of the
+// Connectors sharing it: a request sent while the same request, for the
+// same object type, filters, return fields and user, is in flight waits
+// for the answer of the request in flight instead of being sent again.
+type ReadCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is a request in flight and the requests waiting for it
+type coalescedCall struct {
+	done chan struct{}
+	res  []byte
+	err  error
+	dups int
+}
+
+// NewReadCoalescer returns a ReadCoalescer to set on the Connectors whose
+// identical reads are coalesced
+func NewReadCoalescer() *ReadCoalescer {
+	return &ReadCoalescer{calls: make(map[string]*coalescedCall)}
+}
+
+// do calls fn, or waits for the call of fn in flight with the same key,
+// and returns its result and whether it was shared with other callers. A
+// caller stops waiting when ctx, if not nil, is done.
+func (rc *ReadCoalescer) do(ctx context.Context, key string, fn func() ([]byte, error)) ([]byte, bool, error) {
+	rc.mu.Lock()
+	if call, ok := rc.calls[key]; ok {
+		call.dups++
+		rc.mu.Unlock()
+
+		var ctxDone <-chan struct{}
+		if ctx != nil {
+			ctxDone = ctx.Done()
+		}
+		select {
+		case <-call.done:
+			return call.res, true, call.err
+		case <-ctxDone:
+			rc.mu.Lock()
+			call.dups--
+			rc.mu.Unlock()
+			return nil, false, ctx.Err()
+		}
+	}
+	call := &coalescedCall{done: make(chan struct{})}
+	rc.calls[key] = call
+	rc.mu.Unlock()
+
+	call.res, call.err = fn()
+
+	rc.mu.Lock()
+	delete(rc.calls, key)
+	shared := call.dups > 0
+	rc.mu.Unlock()
+	close(call.done)
+
+	return call.res, shared, call.err
+}
+
+// readKey identifies a GET request by its URL, body and user
+func (c *Connector) readKey(obj IBObject, ref string, queryParams QueryParams) (string, error) {
+	req, err := c.newRequest(GET, obj, ref, queryParams)
+	if err != nil {
+		return "", err
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	user, _, _ := req.BasicAuth()
+
+	return user + "\n" + req.URL.String() + "\n" + string(body), nil
+}
+
+// makeCoalescedRequest sends the GET request through the ReadCoalescer of
+// the Connector. The request is sent again, with the context of the
+// Connector, when the request it waited for was cancelled by the context
+// of another Connector.
+func (c *Connector) makeCoalescedRequest(obj IBObject, ref string, queryParams QueryParams) ([]byte, error) {
+	key, err := c.readKey(obj, ref, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	res, shared, err := c.ReadCoalescer.do(c.ctx, key, func() ([]byte, error) {
+		return c.makeUncoalescedRequest(GET, obj, ref, queryParams)
+	})
+	if shared && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) &&
+		(c.ctx == nil || c.ctx.Err() == nil) {
+		return c.makeUncoalescedRequest(GET, obj, ref, queryParams)
+	}
+
+	return res, err
+}
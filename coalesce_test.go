@@ -0,0 +1,137 @@
+package ibclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// blockingRequestor answers the requests once released and counts them
+type blockingRequestor struct {
+	mu      sync.Mutex
+	sent    int
+	release chan struct{}
+}
+
+func (r *blockingRequestor) Init(cfg TransportConfig) {}
+
+func (r *blockingRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	r.mu.Lock()
+	r.sent++
+	r.mu.Unlock()
+
+	select {
+	case <-r.release:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	return []byte(`[{"_ref": "networkview/ZG5zLm5ldHdvcmtfdmlldyQw:default/true", "name": "default"}]`), nil
+}
+
+func (r *blockingRequestor) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sent
+}
+
+// waiting returns the number of requests waiting for the request in flight
+func (rc *ReadCoalescer) waiting() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	n := 0
+	for _, call := range rc.calls {
+		n += call.dups
+	}
+	return n
+}
+
+var _ = Describe("ReadCoalescer", func() {
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443", Username: "admin"}
+	var requestor *blockingRequestor
+	var conn *Connector
+
+	BeforeEach(func() {
+		requestor = &blockingRequestor{release: make(chan struct{})}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn = &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor,
+			ReadCoalescer: NewReadCoalescer()}
+	})
+
+	It("should send identical reads in flight once", func() {
+		var wg sync.WaitGroup
+		results := make([][]NetworkView, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				err := conn.GetObject(NewNetworkView(NetworkView{Name: "default"}), "", &results[i])
+				Expect(err).To(BeNil())
+			}(i)
+		}
+
+		Eventually(conn.ReadCoalescer.waiting).Should(Equal(4))
+		close(requestor.release)
+		wg.Wait()
+
+		Expect(requestor.count()).To(Equal(1))
+		for _, res := range results {
+			Expect(res[0].Name).To(Equal("default"))
+		}
+	})
+
+	It("should not coalesce different reads", func() {
+		close(requestor.release)
+		var res []NetworkView
+		Expect(conn.GetObject(NewNetworkView(NetworkView{Name: "default"}), "", &res)).To(Succeed())
+		Expect(conn.GetObject(NewNetworkView(NetworkView{Name: "prod"}), "", &res)).To(Succeed())
+		Expect(requestor.count()).To(Equal(2))
+	})
+
+	It("should send the read again when the read it waited for was cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			var res []NetworkView
+			errCh <- conn.WithContext(ctx).GetObject(NewNetworkView(NetworkView{Name: "default"}), "", &res)
+		}()
+		Eventually(requestor.count).Should(Equal(1))
+
+		done := make(chan []NetworkView, 1)
+		go func() {
+			var res []NetworkView
+			conn.GetObject(NewNetworkView(NetworkView{Name: "default"}), "", &res)
+			done <- res
+		}()
+		Eventually(conn.ReadCoalescer.waiting).Should(Equal(1))
+
+		cancel()
+		Eventually(errCh).Should(Receive(Equal(context.Canceled)))
+		close(requestor.release)
+		var res []NetworkView
+		Eventually(done).Should(Receive(&res))
+		Expect(res[0].Name).To(Equal("default"))
+	})
+
+	It("should stop waiting when the context of the waiting read is done", func() {
+		go func() {
+			var res []NetworkView
+			conn.GetObject(NewNetworkView(NetworkView{Name: "default"}), "", &res)
+		}()
+		Eventually(requestor.count).Should(Equal(1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		var res []NetworkView
+		err := conn.WithContext(ctx).GetObject(NewNetworkView(NetworkView{Name: "default"}), "", &res)
+		Expect(err).To(Equal(context.DeadlineExceeded))
+		Expect(conn.ReadCoalescer.waiting()).To(Equal(0))
+		close(requestor.release)
+	})
+})
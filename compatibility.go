@@ -0,0 +1,143 @@
+package ibclient
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// NiosVersion is a NIOS release, e.g. 8.5.2 build 409296
+type NiosVersion struct {
+	Major int
+	Minor int
+	Patch int
+	Build string
+}
+
+var niosVersionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-(\S+))?`)
+
+// ParseNiosVersion parses a NIOS version such as "8.5.2-409296"
+func ParseNiosVersion(version string) (NiosVersion, error) {
+	m := niosVersionRegexp.FindStringSubmatch(version)
+	if m == nil {
+		return NiosVersion{}, fmt.Errorf("'%s' is not a NIOS version", version)
+	}
+
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch, _ := strconv.Atoi(m[3])
+	return NiosVersion{Major: major, Minor: minor, Patch: patch, Build: m[4]}, nil
+}
+
+func (v NiosVersion) String() string {
+	if v.Build != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Build)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the release major.minor.patch or a later
+// one, the build is ignored
+func (v NiosVersion) AtLeast(major int, minor int, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// Compatibility tells which NIOS release the grid runs, which WAPI
+// versions it supports and under which licenses, to check before using
+// the objects and fields of a version or a license
+type Compatibility struct {
+	GridName    string
+	NiosVersion NiosVersion
+	// WapiVersion is the highest WAPI version supported by the grid
+	WapiVersion           string
+	SupportedWapiVersions []string
+	// LicenseTypes are the types of the grid-wide and member licenses
+	// installed, e.g. "DNS", "DHCP" or "CLOUD_API", sorted and without
+	// duplicates
+	LicenseTypes []string
+}
+
+// SupportsWapi reports whether the grid supports the WAPI version
+func (c *Compatibility) SupportsWapi(version string) bool {
+	return c.WapiVersion != "" && compareWapiVersions(c.WapiVersion, version) >= 0
+}
+
+// HasLicense reports whether a license of licenseType is installed
+func (c *Compatibility) HasLicense(licenseType string) bool {
+	for _, t := range c.LicenseTypes {
+		if t == licenseType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCompatibility returns the NIOS release of the grid, from the upgrade
+// status of the grid, the WAPI versions of its schema and the types of
+// its licenses
+func (objMgr *ObjectManager) GetCompatibility() (*Compatibility, error) {
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return nil, err
+	}
+
+	compat := &Compatibility{}
+
+	grids, err := objMgr.withDefaultReturnFields().GetGridInfo()
+	if err != nil {
+		return nil, err
+	}
+	if len(grids) > 0 {
+		compat.GridName = grids[0].Name
+	}
+
+	var statuses []UpgradeStatus
+	upgradeStatus := NewUpgradeStatus(UpgradeStatus{Type: string(UpgradeStatusGrid)})
+	upgradeStatus.returnFields = append(upgradeStatus.returnFields, "current_version")
+	if err = objMgr.readObject(upgradeStatus, "", &statuses); err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 || statuses[0].CurrentVersion == "" {
+		return nil, fmt.Errorf("NIOS version of the grid not found")
+	}
+	if compat.NiosVersion, err = ParseNiosVersion(statuses[0].CurrentVersion); err != nil {
+		return nil, err
+	}
+
+	schema, err := conn.GetSchema()
+	if err != nil {
+		return nil, err
+	}
+	compat.SupportedWapiVersions = schema.SupportedVersions
+	for _, v := range schema.SupportedVersions {
+		if compat.WapiVersion == "" || compareWapiVersions(v, compat.WapiVersion) > 0 {
+			compat.WapiVersion = v
+		}
+	}
+
+	gridLicenses, err := objMgr.withDefaultReturnFields().GetGridLicense()
+	if err != nil {
+		return nil, err
+	}
+	memberLicenses, err := objMgr.withDefaultReturnFields().GetLicense()
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]bool)
+	for _, l := range append(gridLicenses, memberLicenses...) {
+		if l.Licensetype != "" && !types[l.Licensetype] {
+			types[l.Licensetype] = true
+			compat.LicenseTypes = append(compat.LicenseTypes, l.Licensetype)
+		}
+	}
+	sort.Strings(compat.LicenseTypes)
+
+	return compat, nil
+}
@@ -0,0 +1,67 @@
+package ibclient
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Grid compatibility", func() {
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+
+	It("should parse and compare NIOS versions", func() {
+		v, err := ParseNiosVersion("8.5.2-409296")
+		Expect(err).To(BeNil())
+		Expect(v).To(Equal(NiosVersion{Major: 8, Minor: 5, Patch: 2, Build: "409296"}))
+		Expect(v.String()).To(Equal("8.5.2-409296"))
+		Expect(v.AtLeast(8, 5, 0)).To(BeTrue())
+		Expect(v.AtLeast(8, 4, 9)).To(BeTrue())
+		Expect(v.AtLeast(8, 6, 0)).To(BeFalse())
+		Expect(v.AtLeast(9, 0, 0)).To(BeFalse())
+
+		_, err = ParseNiosVersion("unknown")
+		Expect(err).To(MatchError("'unknown' is not a NIOS version"))
+	})
+
+	It("should return the versions and the licenses of the grid", func() {
+		requestor := &fakeReportingRequestor{
+			res: [][]byte{
+				[]byte(`[{"_ref": "grid/b25lLmNsdXN0ZXIkMA:Infoblox", "name": "Infoblox"}]`),
+				[]byte(`[{"_ref": "upgradestatus/Li51cGdyYWRlc3RhdHVzJGdyaWQ:Infoblox", "type": "GRID",
+					"current_version": "8.5.2-409296"}]`),
+				[]byte(`{"requested_version": "2.11", "supported_objects": ["network"],
+					"supported_versions": ["2.9", "2.12", "2.11"]}`),
+				[]byte(`[{"type": "CLOUD_API"}, {"type": "RPZ"}]`),
+				[]byte(`[{"type": "DNS"}, {"type": "DHCP"}, {"type": "DNS"}]`),
+			},
+		}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+		objMgr := NewObjectManager(conn, "Docker", "0123")
+
+		compat, err := objMgr.GetCompatibility()
+		Expect(err).To(BeNil())
+		Expect(compat.GridName).To(Equal("Infoblox"))
+		Expect(compat.NiosVersion.String()).To(Equal("8.5.2-409296"))
+		Expect(compat.WapiVersion).To(Equal("2.12"))
+		Expect(compat.SupportsWapi("2.11")).To(BeTrue())
+		Expect(compat.SupportsWapi("2.13")).To(BeFalse())
+		Expect(compat.LicenseTypes).To(Equal([]string{"CLOUD_API", "DHCP", "DNS", "RPZ"}))
+		Expect(compat.HasLicense("DNS")).To(BeTrue())
+		Expect(compat.HasLicense("DTC")).To(BeFalse())
+
+		Expect(requestor.body[1]).To(MatchJSON(`{"type": "GRID"}`))
+		Expect(requestor.reqs[1].URL.Query().Get("_return_fields")).To(ContainSubstring("current_version"))
+	})
+
+	It("should fail without panicking when the connector is not a Connector", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+		_, err := objMgr.GetCompatibility()
+		Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+		Expect(conn.getObjs).To(BeEmpty())
+	})
+})
@@ -2,6 +2,7 @@ package ibclient
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -13,18 +14,49 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/net/publicsuffix"
 )
 
+// The user types of an admin profile, REMOTE for the admins authenticated
+// by RADIUS, AD, LDAP or TACACS+
+const (
+	UserTypeLocal  = "LOCAL"
+	UserTypeRemote = "REMOTE"
+)
+
 type HostConfig struct {
 	Host     string
 	Version  string
 	Port     string
 	Username string
 	Password string
+	// ExpectedUserType is the user type, LOCAL or REMOTE, NewConnector
+	// expects in the profile of the admin. Remote admins log in with basic
+	// auth like local ones, the grid applies its authentication policy, so
+	// this only checks the outcome.
+	ExpectedUserType string
+	// ExpectedAdminGroup is the admin group NewConnector expects in the
+	// profile of the admin, e.g. the group the authentication policy maps
+	// a remote admin to
+	ExpectedAdminGroup string
+	// NegotiateVersion makes NewConnector select the highest WAPI version
+	// supported by the grid, up to Version unless it is empty
+	NegotiateVersion bool
+	// Discovery resolves the Grid Master, instead of Host and Port, from a
+	// DNS SRV record such as "_wapi._tcp.example.com" or from a discovery
+	// URL starting with https:// or http://. The Grid Master is resolved
+	// by NewConnector, and again when it cannot be reached.
+	Discovery string
+	// Candidates are the Grid Master candidates the requests fail over
+	// to when the Grid Master cannot be reached, until FailbackAfter,
+	// DefaultFailbackAfter if not set, has passed
+	Candidates    []GMCandidate
+	FailbackAfter time.Duration
 }
 
 type TransportConfig struct {
@@ -32,6 +64,9 @@ type TransportConfig struct {
 	certPool            *x509.CertPool
 	HttpRequestTimeout  time.Duration // in seconds
 	HttpPoolConnections int
+	// TLSConfig is used instead of the configuration built from SslVerify
+	// when set, for instance for client certificates
+	TLSConfig *tls.Config
 }
 
 func NewTransportConfig(sslVerify string, httpRequestTimeout int, httpPoolConnections int) (cfg TransportConfig) {
@@ -60,6 +95,43 @@ func NewTransportConfig(sslVerify string, httpRequestTimeout int, httpPoolConnec
 	return
 }
 
+// NewTLSTransportConfig returns a TransportConfig verifying the grid
+// certificate against the CA bundle of caFile, or the system roots if
+// empty. The client certificate of certFile and keyFile is presented when
+// they are not empty, and serverName overrides the name verified in the
+// grid certificate, for grids behind a VIP.
+func NewTLSTransportConfig(caFile string, certFile string, keyFile string, serverName string,
+	httpRequestTimeout int, httpPoolConnections int) (cfg TransportConfig, err error) {
+	tlsConfig := &tls.Config{ServerName: serverName}
+
+	if caFile != "" {
+		var ca []byte
+		if ca, err = ioutil.ReadFile(caFile); err != nil {
+			return
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			err = fmt.Errorf("Cannot append certificate from file '%s'", caFile)
+			return
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+			return
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	cfg.SslVerify = true
+	cfg.certPool = tlsConfig.RootCAs
+	cfg.TLSConfig = tlsConfig
+	cfg.HttpPoolConnections = httpPoolConnections
+	cfg.HttpRequestTimeout = time.Duration(httpRequestTimeout)
+	return
+}
+
 type HttpRequestBuilder interface {
 	Init(HostConfig)
 	BuildUrl(r RequestType, objType string, ref string, returnFields []string, queryParams QueryParams) (urlStr string)
@@ -77,9 +149,39 @@ type WapiRequestBuilder struct {
 }
 
 type WapiHttpRequestor struct {
-	client http.Client
+	client    http.Client
+	transport *http.Transport
+	jar       *sessionJar
+	// timeout bounds the requests whose context has no deadline, those
+	// with a deadline, e.g. set by ObjectManager.WithTimeout, may take
+	// longer or shorter
+	timeout time.Duration
+
+	mu       sync.Mutex
+	closed   bool
+	inflight map[*http.Request]context.CancelFunc
+}
+
+// ServerTimeRequestor is implemented by requestors which can tell the time
+// of the server from the Date header of its response to req
+type ServerTimeRequestor interface {
+	ServerTime(req *http.Request) (time.Time, error)
+}
+
+// RequestorCloser is implemented by requestors which hold connections that
+// need to be released when the Connector is closed
+type RequestorCloser interface {
+	Close() error
+}
+
+// SessionResetter is implemented by requestors which keep a session
+// between requests
+type SessionResetter interface {
+	ResetSession()
 }
 
+var ErrRequestorClosed = errors.New("requestor is closed")
+
 type IBConnector interface {
 	CreateObject(obj IBObject) (ref string, err error)
 	GetObject(obj IBObject, ref string, res interface{}) error
@@ -87,11 +189,30 @@ type IBConnector interface {
 	UpdateObject(obj IBObject, ref string) (refRes string, err error)
 }
 
+// IBContextConnector is implemented by connectors which can bind a
+// context.Context to the requests they send
+type IBContextConnector interface {
+	IBConnector
+	WithContext(ctx context.Context) IBConnector
+}
+
 type Connector struct {
 	HostConfig      HostConfig
 	TransportConfig TransportConfig
 	RequestBuilder  HttpRequestBuilder
 	Requestor       HttpRequestor
+	RetryPolicy     RetryPolicy
+	RateLimiter     *RateLimiter
+	RequestHook     RequestHook
+	// ReadCoalescer, when set, coalesces the identical GET requests in
+	// flight of the Connectors sharing it
+	ReadCoalescer *ReadCoalescer
+
+	ctx context.Context
+	// grid is shared with the copies made by WithContext
+	grid *gridEndpoint
+	// preference is shared with the copies made by WithContext
+	preference *gmPreference
 }
 
 type RequestType int
@@ -121,30 +242,158 @@ func (r RequestType) toMethod() string {
 func getHTTPResponseError(resp *http.Response) error {
 	defer resp.Body.Close()
 	content, _ := ioutil.ReadAll(resp.Body)
-	msg := fmt.Sprintf("WAPI request error: %d('%s')\nContents:\n%s\n", resp.StatusCode, resp.Status, content)
-	log.Printf(msg)
-	return errors.New(msg)
+	err := newWapiError(resp.StatusCode, resp.Status, content)
+	log.Printf(err.Error())
+	return err
 }
 
 func (whr *WapiHttpRequestor) Init(cfg TransportConfig) {
+	tlsConfig := cfg.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{InsecureSkipVerify: !cfg.SslVerify,
+			RootCAs: cfg.certPool}
+	}
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: !cfg.SslVerify,
-			RootCAs: cfg.certPool},
+		TLSClientConfig:     tlsConfig,
 		MaxIdleConnsPerHost: cfg.HttpPoolConnections,
 	}
 
+	whr.jar = &sessionJar{jar: newCookieJar()}
+	whr.transport = tr
+	whr.client = http.Client{Jar: whr.jar, Transport: tr}
+	whr.timeout = cfg.HttpRequestTimeout * time.Second
+	whr.inflight = make(map[*http.Request]context.CancelFunc)
+}
+
+func newCookieJar() http.CookieJar {
 	// All users of cookiejar should import "golang.org/x/net/publicsuffix"
 	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	whr.client = http.Client{Jar: jar, Transport: tr, Timeout: cfg.HttpRequestTimeout * time.Second}
+	return jar
+}
+
+// sessionJar holds the ibapauth session cookie and allows to drop the
+// session while requests may be in flight
+type sessionJar struct {
+	mu  sync.RWMutex
+	jar http.CookieJar
+}
+
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	j.jar.SetCookies(u, cookies)
+}
+
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.jar.Cookies(u)
+}
+
+// hasSession tells whether the jar holds an ibapauth cookie for u
+func (j *sessionJar) hasSession(u *url.URL) bool {
+	for _, c := range j.Cookies(u) {
+		if c.Name == "ibapauth" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (j *sessionJar) reset() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar = newCookieJar()
+}
+
+// ResetSession drops the session cookies so the next request
+// authenticates again
+func (whr *WapiHttpRequestor) ResetSession() {
+	if whr.jar != nil {
+		whr.jar.reset()
+	}
+}
+
+func (whr *WapiHttpRequestor) track(req *http.Request) (*http.Request, error) {
+	whr.mu.Lock()
+	defer whr.mu.Unlock()
+
+	if whr.closed {
+		return nil, ErrRequestorClosed
+	}
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if _, ok := req.Context().Deadline(); !ok && whr.timeout > 0 {
+		ctx, cancel = context.WithTimeout(req.Context(), whr.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(req.Context())
+	}
+	tracked := req.WithContext(ctx)
+	whr.inflight[tracked] = cancel
+
+	return tracked, nil
+}
+
+func (whr *WapiHttpRequestor) untrack(req *http.Request) {
+	whr.mu.Lock()
+	defer whr.mu.Unlock()
+
+	if cancel, ok := whr.inflight[req]; ok {
+		cancel()
+		delete(whr.inflight, req)
+	}
+}
+
+// Close cancels the requests in flight, releases idle connections and
+// makes any further SendRequest fail with ErrRequestorClosed
+func (whr *WapiHttpRequestor) Close() error {
+	whr.mu.Lock()
+	whr.closed = true
+	for req, cancel := range whr.inflight {
+		cancel()
+		delete(whr.inflight, req)
+	}
+	whr.mu.Unlock()
+
+	if whr.transport != nil {
+		whr.transport.CloseIdleConnections()
+	}
+
+	return nil
 }
 
 func (whr *WapiHttpRequestor) SendRequest(req *http.Request) (res []byte, err error) {
+	req, err = whr.track(req)
+	if err != nil {
+		return
+	}
+	defer whr.untrack(req)
+
+	// The ibapauth cookie is sent instead of the credentials when the
+	// session is open, saving the grid a new authentication
+	auth := req.Header.Get("Authorization")
+	reuse := auth != "" && whr.jar != nil && whr.jar.hasSession(req.URL)
+	if reuse {
+		req.Header = cloneHeader(req.Header)
+		req.Header.Del("Authorization")
+	}
+
 	var resp *http.Response
 	resp, err = whr.client.Do(req)
+	if err == nil && reuse && resp.StatusCode == http.StatusUnauthorized && req.GetBody != nil {
+		// the session expired, authenticate again
+		resp.Body.Close()
+		whr.jar.reset()
+		req.Header.Set("Authorization", auth)
+		if req.Body, err = req.GetBody(); err == nil {
+			resp, err = whr.client.Do(req)
+		}
+	}
 	if err != nil {
 		return
 	} else if !(resp.StatusCode == http.StatusOK ||
@@ -163,6 +412,36 @@ func (whr *WapiHttpRequestor) SendRequest(req *http.Request) (res []byte, err er
 	return
 }
 
+// ServerTime sends req and returns the time of the server from the Date
+// header of the response
+func (whr *WapiHttpRequestor) ServerTime(req *http.Request) (t time.Time, err error) {
+	req, err = whr.track(req)
+	if err != nil {
+		return
+	}
+	defer whr.untrack(req)
+
+	resp, err := whr.client.Do(req)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		return t, getHTTPResponseError(resp)
+	}
+	resp.Body.Close()
+
+	return http.ParseTime(resp.Header.Get("Date"))
+}
+
+func cloneHeader(h http.Header) http.Header {
+	res := make(http.Header, len(h))
+	for k, v := range h {
+		res[k] = append([]string(nil), v...)
+	}
+
+	return res
+}
+
 func (wrb *WapiRequestBuilder) Init(cfg HostConfig) {
 	wrb.HostConfig = cfg
 }
@@ -179,12 +458,31 @@ func (wrb *WapiRequestBuilder) BuildUrl(t RequestType, objType string, ref strin
 	vals := url.Values{}
 	if t == GET {
 		if len(returnFields) > 0 {
-			vals.Set("_return_fields", strings.Join(returnFields, ","))
+			if queryParams.returnFieldsPlus {
+				vals.Set("_return_fields+", strings.Join(returnFields, ","))
+			} else {
+				vals.Set("_return_fields", strings.Join(returnFields, ","))
+			}
 		}
 		// TODO need to get this from individual objects in future
 		if queryParams.forceProxy {
 			vals.Set("_proxy_search", "GM")
 		}
+		if queryParams.paging {
+			vals.Set("_paging", "1")
+			vals.Set("_return_as_object", "1")
+			vals.Set("_max_results", strconv.Itoa(queryParams.maxResults))
+		}
+		if queryParams.pageID != "" {
+			vals.Set("_page_id", queryParams.pageID)
+		}
+		if queryParams.schema {
+			vals.Set("_schema", "1")
+		}
+		qry = vals.Encode()
+	}
+	if t == CREATE && queryParams.function != "" {
+		vals.Set("_function", queryParams.function)
 		qry = vals.Encode()
 	}
 
@@ -215,6 +513,9 @@ func (wrb *WapiRequestBuilder) BuildBody(t RequestType, obj IBObject) []byte {
 			log.Printf("Cannot marshal EA Search attributes. '%s'\n", err)
 			return nil
 		}
+		if string(objJSON) == "{}" {
+			return eaSearchJSON
+		}
 		objJSON = append(append(objJSON[:len(objJSON)-1], byte(',')), eaSearchJSON[1:]...)
 	}
 
@@ -229,6 +530,11 @@ func (wrb *WapiRequestBuilder) BuildRequest(t RequestType, obj IBObject, ref str
 	if obj != nil {
 		objType = obj.ObjectType()
 		returnFields = obj.ReturnFields()
+		if o, ok := obj.(interface {
+			addsReturnFields() bool
+		}); ok {
+			queryParams.returnFieldsPlus = o.addsReturnFields()
+		}
 	}
 	urlStr := wrb.BuildUrl(t, objType, ref, returnFields, queryParams)
 
@@ -249,19 +555,121 @@ func (wrb *WapiRequestBuilder) BuildRequest(t RequestType, obj IBObject, ref str
 }
 
 func (c *Connector) makeRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
+	if t == GET && c.ReadCoalescer != nil {
+		return c.makeCoalescedRequest(obj, ref, queryParams)
+	}
+	return c.makeUncoalescedRequest(t, obj, ref, queryParams)
+}
+
+func (c *Connector) makeUncoalescedRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
+	for attempt := 1; ; attempt++ {
+		res, err = c.sendRequest(t, obj, ref, queryParams)
+		// failed logins are not retried, they would extend the lockout of
+		// the account
+		if err == nil || attempt >= c.RetryPolicy.MaxAttempts || errors.Is(err, ErrAccountLocked) ||
+			!c.RetryPolicy.retries(t, obj, queryParams) || !c.RetryPolicy.retryable(err) {
+			return
+		}
+		if sleepErr := c.sleep(c.RetryPolicy.backoff(attempt)); sleepErr != nil {
+			return nil, sleepErr
+		}
+	}
+}
+
+func (c *Connector) sendRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (res []byte, err error) {
 	var req *http.Request
-	req, err = c.RequestBuilder.BuildRequest(t, obj, ref, queryParams)
-	res, err = c.Requestor.SendRequest(req)
+	req, err = c.buildRequest(t, obj, ref, queryParams)
+	if err != nil {
+		return
+	}
+	res, err = c.send(req)
 	if err != nil {
+		if c.ctx != nil && c.ctx.Err() != nil {
+			return nil, c.ctx.Err()
+		}
+		// an allocation the grid may have applied is not sent again
+		if errors.Is(err, ErrAuth) || errors.Is(err, ErrRequestTooLarge) ||
+			allocates(t, obj, queryParams) && mayBeApplied(err) {
+			return
+		}
+		if c.rediscover(err) {
+			req, err = c.buildRequest(t, obj, ref, queryParams)
+			if err != nil {
+				return
+			}
+			return c.send(req)
+		}
+		if len(c.HostConfig.Candidates) > 0 && unreachable(err) {
+			return c.sendFailover(t, obj, ref, queryParams, err)
+		}
 		/* Forcing the request to redirect to Grid Master by making forcedProxy=true */
 		queryParams.forceProxy = true
-		req, err = c.RequestBuilder.BuildRequest(t, obj, ref, queryParams)
-		res, err = c.Requestor.SendRequest(req)
+		req, err = c.buildRequest(t, obj, ref, queryParams)
+		if err != nil {
+			return
+		}
+		res, err = c.send(req)
+	}
+
+	return
+}
+
+// newRequest builds the request for the current Grid Master
+func (c *Connector) newRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (*http.Request, error) {
+	defer c.rlockGrid()()
+
+	return c.RequestBuilder.BuildRequest(t, obj, ref, queryParams)
+}
+
+func (c *Connector) buildRequest(t RequestType, obj IBObject, ref string, queryParams QueryParams) (req *http.Request, err error) {
+	req, err = c.newRequest(t, obj, ref, queryParams)
+	if err != nil {
+		return
 	}
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+	c.routeRequest(req)
 
 	return
 }
 
+// WithContext returns a shallow copy of the Connector sending its
+// requests with ctx, so they are cancelled when ctx is done.
+func (c *Connector) WithContext(ctx context.Context) IBConnector {
+	if ctx == nil {
+		panic("nil context")
+	}
+	res := *c
+	res.ctx = ctx
+
+	return &res
+}
+
+// Context returns the context bound to the Connector's requests
+func (c *Connector) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+func (c *Connector) CreateObjectWithContext(ctx context.Context, obj IBObject) (string, error) {
+	return c.WithContext(ctx).CreateObject(obj)
+}
+
+func (c *Connector) GetObjectWithContext(ctx context.Context, obj IBObject, ref string, res interface{}) error {
+	return c.WithContext(ctx).GetObject(obj, ref, res)
+}
+
+func (c *Connector) DeleteObjectWithContext(ctx context.Context, ref string) (string, error) {
+	return c.WithContext(ctx).DeleteObject(ref)
+}
+
+func (c *Connector) UpdateObjectWithContext(ctx context.Context, obj IBObject, ref string) (string, error) {
+	return c.WithContext(ctx).UpdateObject(obj, ref)
+}
+
 func (c *Connector) CreateObject(obj IBObject) (ref string, err error) {
 	ref = ""
 	queryParams := QueryParams{forceProxy: false}
@@ -280,6 +688,40 @@ func (c *Connector) CreateObject(obj IBObject) (ref string, err error) {
 	return
 }
 
+// ServerTime returns the time of the Grid Master, with the precision of a
+// second, if the requestor implements ServerTimeRequestor
+func (c *Connector) ServerTime() (time.Time, error) {
+	requestor, ok := c.Requestor.(ServerTimeRequestor)
+	if !ok {
+		return time.Time{}, fmt.Errorf("requestor %T does not report the server time", c.Requestor)
+	}
+
+	grid := NewGrid(Grid{})
+	grid.returnFields = []string{"name"}
+	req, err := c.buildRequest(GET, grid, "", QueryParams{forceProxy: false})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return requestor.ServerTime(req)
+}
+
+// callFunction calls the WAPI function of the object referenced by ref
+// with args and unmarshals the result into res, if not nil
+func (c *Connector) callFunction(ref string, function string, args IBObject, res interface{}) error {
+	queryParams := QueryParams{forceProxy: false, function: function}
+	resp, err := c.makeRequest(CREATE, args, ref, queryParams)
+	if err != nil {
+		log.Printf("Function '%s' request error: '%s'\n", function, err)
+		return err
+	}
+	if res == nil || len(resp) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(resp, res)
+}
+
 func (c *Connector) GetObject(obj IBObject, ref string, res interface{}) (err error) {
 	queryParams := QueryParams{forceProxy: false}
 	resp, err := c.makeRequest(GET, obj, ref, queryParams)
@@ -346,30 +788,96 @@ func (c *Connector) UpdateObject(obj IBObject, ref string) (refRes string, err e
 
 // Logout sends a request to invalidate the ibapauth cookie and should
 // be used in a defer statement after the Connector has been successfully
-// initialized.
+// initialized. The next request authenticates again.
 func (c *Connector) Logout() (err error) {
 	queryParams := QueryParams{forceProxy: false}
 	_, err = c.makeRequest(CREATE, nil, "logout", queryParams)
 	if err != nil {
 		log.Printf("Logout request error: '%s'\n", err)
 	}
+	if resetter, ok := c.Requestor.(SessionResetter); ok {
+		resetter.ResetSession()
+	}
 
 	return
 }
 
+// SetCredentials logs out the session of the current credentials before
+// using the new ones, so rotating credentials does not leave sessions
+// behind on the Grid Master.
+func (c *Connector) SetCredentials(username string, password string) error {
+	err := c.Logout()
+
+	defer c.lockGrid()()
+
+	c.grid.username, c.grid.password = username, password
+	c.RequestBuilder.Init(c.hostConfig())
+
+	return err
+}
+
+// Close invalidates the session, cancels the requests in flight and
+// releases the idle connections of the requestor. The Connector must not
+// be used after it is closed.
+func (c *Connector) Close() error {
+	err := c.Logout()
+
+	if closer, ok := c.Requestor.(RequestorCloser); ok {
+		if cerr := closer.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
 var ValidateConnector = validateConnector
 
 func validateConnector(c *Connector) (err error) {
 	// GET UserProfile request is used here to validate connector's basic auth and reachability.
 	var response []UserProfile
 	userprofile := NewUserProfile(UserProfile{})
+	if c.HostConfig.ExpectedUserType != "" || c.HostConfig.ExpectedAdminGroup != "" {
+		userprofile.returnFields = append(userprofile.returnFields, "admin_group", "user_type")
+	}
 	err = c.GetObject(userprofile, "", &response)
 	if err != nil {
 		log.Printf("Failed to connect to the Grid, err: %s \n", err)
+		return
+	}
+
+	err = checkUserProfile(c.HostConfig, response)
+	if err != nil {
+		log.Printf("Failed to validate the admin account, err: %s \n", err)
 	}
 	return
 }
 
+// checkUserProfile verifies that the profile of the admin has the user
+// type and the admin group the HostConfig expects, remote admins which are
+// not mapped to the expected admin group are otherwise only noticed on the
+// first denied request.
+func checkUserProfile(cfg HostConfig, profiles []UserProfile) error {
+	if cfg.ExpectedUserType == "" && cfg.ExpectedAdminGroup == "" {
+		return nil
+	}
+	if len(profiles) == 0 {
+		return fmt.Errorf("no user profile returned for '%s'", cfg.Username)
+	}
+
+	profile := profiles[0]
+	if cfg.ExpectedUserType != "" && !strings.EqualFold(profile.UserType, cfg.ExpectedUserType) {
+		return fmt.Errorf("admin '%s' is a %s user, expected %s",
+			cfg.Username, profile.UserType, strings.ToUpper(cfg.ExpectedUserType))
+	}
+	if cfg.ExpectedAdminGroup != "" && profile.AdminGroup != cfg.ExpectedAdminGroup {
+		return fmt.Errorf("admin '%s' is in admin group '%s', expected '%s'",
+			cfg.Username, profile.AdminGroup, cfg.ExpectedAdminGroup)
+	}
+
+	return nil
+}
+
 func NewConnector(hostConfig HostConfig, transportConfig TransportConfig,
 	requestBuilder HttpRequestBuilder, requestor HttpRequestor) (res *Connector, err error) {
 	res = nil
@@ -382,10 +890,23 @@ func NewConnector(hostConfig HostConfig, transportConfig TransportConfig,
 	//connector.RequestBuilder = WapiRequestBuilder{WaipHostConfig: connector.HostConfig}
 	connector.RequestBuilder = requestBuilder
 	connector.RequestBuilder.Init(connector.HostConfig)
+	connector.grid = newGridEndpoint(hostConfig)
+	connector.preference = &gmPreference{}
+	if hostConfig.Discovery != "" {
+		if _, err = connector.Discover(); err != nil {
+			return
+		}
+	}
 
 	connector.Requestor = requestor
 	connector.Requestor.Init(connector.TransportConfig)
 
+	if hostConfig.NegotiateVersion {
+		if _, err = connector.NegotiateVersion(hostConfig.Version); err != nil {
+			return
+		}
+	}
+
 	res = connector
 	err = ValidateConnector(connector)
 	return
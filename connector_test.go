@@ -2,11 +2,27 @@ package ibclient
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -65,10 +81,47 @@ func (hr *FakeHttpRequestor) SendRequest(req *http.Request) ([]byte, error) {
 	return hr.res, nil
 }
 
+type ctxRecordingRequestor struct {
+	reqs   []*http.Request
+	ctxs   []context.Context
+	res    []byte
+	err    error
+	cancel context.CancelFunc
+}
+
+func (hr *ctxRecordingRequestor) Init(config TransportConfig) {}
+
+func (hr *ctxRecordingRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	hr.reqs = append(hr.reqs, req)
+	hr.ctxs = append(hr.ctxs, req.Context())
+	if hr.cancel != nil {
+		hr.cancel()
+	}
+
+	return hr.res, hr.err
+}
+
 func MockValidateConnector(c *Connector) (err error) {
 	return
 }
 
+// sessionRequestor records the requests and resets the session of whr
+type sessionRequestor struct {
+	whr   *WapiHttpRequestor
+	paths []string
+}
+
+func (sr *sessionRequestor) Init(cfg TransportConfig) {}
+
+func (sr *sessionRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	sr.paths = append(sr.paths, req.URL.Path)
+	return []byte(`""`), nil
+}
+
+func (sr *sessionRequestor) ResetSession() {
+	sr.whr.ResetSession()
+}
+
 var _ = Describe("Connector", func() {
 
 	Describe("WapiRequestBuilder", func() {
@@ -193,6 +246,32 @@ var _ = Describe("Connector", func() {
 			})
 		})
 
+		Describe("BuildBody", func() {
+			It("should return only the EA search for GET without search fields", func() {
+				obj := NewSearchObject("record:host", nil, EA{"Owner": "teamA"}, nil)
+
+				bodyStr := wrb.BuildBody(GET, obj)
+				Expect(string(bodyStr)).To(Equal(`{"*Owner":"teamA"}`))
+			})
+		})
+
+		Describe("BuildRequest", func() {
+			It("should search with modifiers and request additional return fields", func() {
+				obj := NewSearchObject("record:host",
+					map[string]interface{}{"zone": "example.com", "name~": "^web"},
+					EA{"Owner": "teamA"},
+					[]string{"extattrs", "comment"})
+
+				req, err := wrb.BuildRequest(GET, obj, "", QueryParams{})
+				Expect(err).To(BeNil())
+				Expect(req.URL.Path).To(Equal(fmt.Sprintf("/wapi/v%s/record:host", version)))
+				Expect(req.URL.Query()).To(Equal(url.Values{"_return_fields+": []string{"extattrs,comment"}}))
+
+				body, _ := ioutil.ReadAll(req.Body)
+				Expect(body).To(MatchJSON(`{"zone": "example.com", "name~": "^web", "*Owner": "teamA"}`))
+			})
+		})
+
 		Describe("BuildRequest", func() {
 			Context("for CREATE request", func() {
 				networkView := "private-view"
@@ -257,6 +336,222 @@ var _ = Describe("Connector", func() {
 		})
 	})
 
+	Describe("checkUserProfile", func() {
+		remoteProfile := []UserProfile{{Name: "svc-ipam", UserType: "REMOTE", AdminGroup: "cloud-api-only"}}
+
+		It("should accept any profile when no expectation is configured", func() {
+			Expect(checkUserProfile(HostConfig{Username: "admin"}, nil)).To(BeNil())
+		})
+		It("should accept a remote admin in the expected group", func() {
+			cfg := HostConfig{Username: "svc-ipam", ExpectedUserType: "remote", ExpectedAdminGroup: "cloud-api-only"}
+			Expect(checkUserProfile(cfg, remoteProfile)).To(BeNil())
+		})
+		It("should reject a remote admin when a local one is expected", func() {
+			cfg := HostConfig{Username: "svc-ipam", ExpectedUserType: UserTypeLocal}
+			Expect(checkUserProfile(cfg, remoteProfile)).NotTo(BeNil())
+		})
+		It("should reject a remote admin in another group", func() {
+			cfg := HostConfig{Username: "svc-ipam", ExpectedUserType: UserTypeRemote, ExpectedAdminGroup: "admin-group"}
+			Expect(checkUserProfile(cfg, remoteProfile)).NotTo(BeNil())
+		})
+	})
+
+	Describe("WapiHttpRequestor Close", func() {
+		It("should cancel requests in flight and refuse new ones", func() {
+			reached := make(chan struct{})
+			release := make(chan struct{})
+			defer close(release)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(reached)
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+			}))
+			defer server.Close()
+
+			whr := &WapiHttpRequestor{}
+			whr.Init(NewTransportConfig("false", 20, 10))
+
+			errCh := make(chan error, 1)
+			go func() {
+				req, _ := http.NewRequest("GET", server.URL, nil)
+				_, err := whr.SendRequest(req)
+				errCh <- err
+			}()
+
+			<-reached
+			Expect(whr.Close()).To(BeNil())
+			Eventually(errCh).Should(Receive(HaveOccurred()))
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			_, err := whr.SendRequest(req)
+			Expect(err).To(Equal(ErrRequestorClosed))
+		})
+	})
+
+	Describe("WapiHttpRequestor ServerTime", func() {
+		It("should return the time of the Date header", func() {
+			serverTime := time.Date(2020, time.March, 2, 10, 4, 5, 0, time.UTC)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+				w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			whr := &WapiHttpRequestor{}
+			whr.Init(NewTransportConfig("false", 20, 10))
+
+			req, _ := http.NewRequest("GET", server.URL, nil)
+			t, err := whr.ServerTime(req)
+			Expect(err).To(BeNil())
+			Expect(t.Equal(serverTime)).To(BeTrue())
+		})
+	})
+
+	Describe("Connector WithContext", func() {
+		It("should send requests with the bound context", func() {
+			type ctxKey string
+			ctx := context.WithValue(context.Background(), ctxKey("reconcile"), "net-1")
+			requestor := &ctxRecordingRequestor{res: []byte(`"networkview/ZG5zLm5ldHdvcmtfdmlldyQyMw:global_view/false"`)}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+
+			_, err := conn.CreateObjectWithContext(ctx, NewNetworkView(NetworkView{Name: "global_view"}))
+			Expect(err).To(BeNil())
+			Expect(requestor.ctxs).To(HaveLen(1))
+			Expect(requestor.ctxs[0].Value(ctxKey("reconcile"))).To(Equal("net-1"))
+		})
+
+		It("should not retry once the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			requestor := &ctxRecordingRequestor{err: errors.New("connection reset"), cancel: cancel}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+
+			objMgr := NewObjectManager(conn, "Docker", "0123").WithContext(ctx)
+			_, err := objMgr.CreateNetworkView("global_view")
+			Expect(err).To(Equal(context.Canceled))
+			Expect(requestor.ctxs).To(HaveLen(1))
+		})
+	})
+
+	Describe("ObjectManager WithTimeout", func() {
+		It("should bound the requests by the timeout instead of the transport one", func() {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(1200 * time.Millisecond):
+				case <-r.Context().Done():
+				}
+				w.Write([]byte(`[{"_ref": "networkview/ZG5zLm5ldHdvcmtfdmlldyQw:default/true", "name": "default"}]`))
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			host, port, _ := net.SplitHostPort(u.Host)
+			hostConfig := HostConfig{Host: host, Port: port, Version: "2.11"}
+			wrb := &WapiRequestBuilder{}
+			wrb.Init(hostConfig)
+			whr := &WapiHttpRequestor{}
+			whr.Init(NewTransportConfig("false", 1, 10))
+			conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: whr}
+			objMgr := NewObjectManager(conn, "Docker", "0123")
+
+			quick, cancel := objMgr.WithTimeout(100 * time.Millisecond)
+			defer cancel()
+			_, err := quick.GetNetworkView("default")
+			Expect(err).To(Equal(context.DeadlineExceeded))
+
+			slow, cancel := objMgr.WithTimeout(5 * time.Second)
+			defer cancel()
+			netview, err := slow.GetNetworkView("default")
+			Expect(err).To(BeNil())
+			Expect(netview.Name).To(Equal("default"))
+		})
+	})
+
+	Describe("Connector SetCredentials", func() {
+		It("should log out before switching to the new credentials", func() {
+			requestor := &ctxRecordingRequestor{}
+			builder := &WapiRequestBuilder{}
+			conn := &Connector{
+				HostConfig:     HostConfig{Host: "172.22.18.66", Version: "2.5", Port: "443", Username: "old", Password: "old-pass"},
+				RequestBuilder: builder,
+				Requestor:      requestor,
+			}
+			conn.RequestBuilder.Init(conn.HostConfig)
+
+			Expect(conn.SetCredentials("new", "new-pass")).To(BeNil())
+			Expect(requestor.reqs).To(HaveLen(1))
+			Expect(requestor.reqs[0].URL.Path).To(Equal("/wapi/v2.5/logout"))
+			username, _, _ := requestor.reqs[0].BasicAuth()
+			Expect(username).To(Equal("old"))
+
+			Expect(builder.HostConfig.Username).To(Equal("new"))
+			Expect(builder.HostConfig.Password).To(Equal("new-pass"))
+		})
+
+		It("should drop the session cookies of the requestor", func() {
+			whr := &WapiHttpRequestor{}
+			whr.Init(NewTransportConfig("false", 20, 10))
+			u, _ := url.Parse("https://172.22.18.66/wapi/v2.5/")
+			whr.jar.SetCookies(u, []*http.Cookie{{Name: "ibapauth", Value: "ip=172.22.18.1,client=API"}})
+			Expect(whr.jar.Cookies(u)).To(HaveLen(1))
+
+			whr.ResetSession()
+			Expect(whr.jar.Cookies(u)).To(BeEmpty())
+		})
+	})
+
+	Describe("Session reuse", func() {
+		It("should send the ibapauth cookie instead of the credentials and authenticate again on 401", func() {
+			var auths []bool
+			expired := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _, hasAuth := r.BasicAuth()
+				auths = append(auths, hasAuth)
+				if !hasAuth && expired {
+					expired = false
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				if hasAuth {
+					http.SetCookie(w, &http.Cookie{Name: "ibapauth", Value: "ip=127.0.0.1,client=API", Path: "/"})
+				}
+				w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			whr := &WapiHttpRequestor{}
+			whr.Init(NewTransportConfig("false", 20, 10))
+			send := func() error {
+				req, _ := http.NewRequest("GET", server.URL+"/wapi/v2.5/network", bytes.NewBufferString("{}"))
+				req.SetBasicAuth("admin", "infoblox")
+				_, err := whr.SendRequest(req)
+				return err
+			}
+
+			Expect(send()).To(Succeed())
+			Expect(send()).To(Succeed())
+			Expect(auths).To(Equal([]bool{true, false}))
+
+			expired = true
+			Expect(send()).To(Succeed())
+			Expect(auths).To(Equal([]bool{true, false, false, true}))
+		})
+
+		It("should drop the session on logout", func() {
+			whr := &WapiHttpRequestor{}
+			whr.Init(NewTransportConfig("false", 20, 10))
+			u, _ := url.Parse("https://172.22.18.66/wapi/v2.5/")
+			whr.jar.SetCookies(u, []*http.Cookie{{Name: "ibapauth", Value: "ip=172.22.18.1,client=API"}})
+
+			requestor := &sessionRequestor{whr: whr}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			Expect(conn.Logout()).To(Succeed())
+			Expect(requestor.paths).To(Equal([]string{"/wapi/v/logout"}))
+			Expect(whr.jar.hasSession(u)).To(BeFalse())
+		})
+	})
+
 	Describe("Connector Object Methods", func() {
 
 		host := "172.22.18.66"
@@ -507,3 +802,71 @@ var _ = Describe("Connector", func() {
 
 	})
 })
+
+// writeClientCert writes a self-signed client certificate and its key to
+// dir and returns their paths
+func writeClientCert(dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ipam-controller"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).To(BeNil())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)).To(Succeed())
+
+	return certFile, keyFile, cert
+}
+
+var _ = Describe("TLS transport", func() {
+	It("should present the client certificate and verify the grid against the CA bundle", func() {
+		dir, err := ioutil.TempDir("", "ibclient-tls")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+
+		certFile, keyFile, clientCert := writeClientCert(dir)
+		clientCAs := x509.NewCertPool()
+		clientCAs.AddCert(clientCert)
+
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+		server.StartTLS()
+		defer server.Close()
+
+		// the certificate of httptest is issued to example.com
+		caFile := filepath.Join(dir, "ca.pem")
+		Expect(ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+			Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)).To(Succeed())
+
+		cfg, err := NewTLSTransportConfig(caFile, certFile, keyFile, "example.com", 20, 10)
+		Expect(err).To(BeNil())
+		Expect(cfg.SslVerify).To(BeTrue())
+
+		whr := &WapiHttpRequestor{}
+		whr.Init(cfg)
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		res, err := whr.SendRequest(req)
+		Expect(err).To(BeNil())
+		Expect(string(res)).To(Equal("ipam-controller"))
+	})
+
+	It("should fail on a missing CA bundle", func() {
+		_, err := NewTLSTransportConfig("/nonexistent/ca.pem", "", "", "", 20, 10)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
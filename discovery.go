@@ -0,0 +1,196 @@
+package ibclient
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lookupSRV resolves the SRV records of a name, replaced in tests
+var lookupSRV = net.LookupSRV
+
+// discoveryClient fetches the discovery URLs
+var discoveryClient = &http.Client{Timeout: 10 * time.Second}
+
+// gridEndpoint is the Grid Master endpoint, the WAPI version and the
+// credentials of a Connector, shared with the copies made by WithContext.
+// Discover, NegotiateVersion and SetCredentials change them while the
+// requests of the copies are built, so they are only read and changed
+// under mu, as is the HostConfig of the RequestBuilder. The HostConfig of
+// the Connector keeps the values it was created with.
+type gridEndpoint struct {
+	mu       sync.RWMutex
+	host     string
+	port     string
+	version  string
+	username string
+	password string
+}
+
+func newGridEndpoint(cfg HostConfig) *gridEndpoint {
+	return &gridEndpoint{host: cfg.Host, port: cfg.Port, version: cfg.Version,
+		username: cfg.Username, password: cfg.Password}
+}
+
+// lockGrid locks the endpoint of the Connector for an update and returns
+// the function unlocking it. The Connectors not created by NewConnector
+// get their endpoint from HostConfig on their first update, it is not
+// shared with the copies they made before.
+func (c *Connector) lockGrid() func() {
+	if c.grid == nil {
+		c.grid = newGridEndpoint(c.HostConfig)
+	}
+	c.grid.mu.Lock()
+	return c.grid.mu.Unlock
+}
+
+// rlockGrid locks the endpoint of the Connector for reading and returns
+// the function unlocking it
+func (c *Connector) rlockGrid() func() {
+	if c.grid == nil {
+		return func() {}
+	}
+	c.grid.mu.RLock()
+	return c.grid.mu.RUnlock
+}
+
+// gridMaster returns the host and the port of the Grid Master, the caller
+// holds the lock of the endpoint
+func (c *Connector) gridMaster() (string, string) {
+	if c.grid == nil {
+		return c.HostConfig.Host, c.HostConfig.Port
+	}
+	return c.grid.host, c.grid.port
+}
+
+// wapiVersion returns the WAPI version of the requests, the caller holds
+// the lock of the endpoint
+func (c *Connector) wapiVersion() string {
+	if c.grid == nil {
+		return c.HostConfig.Version
+	}
+	return c.grid.version
+}
+
+// setWapiVersion sends the following requests with the WAPI version
+// version, the caller holds the lock of the endpoint for an update
+func (c *Connector) setWapiVersion(version string) {
+	c.grid.version = version
+	c.RequestBuilder.Init(c.hostConfig())
+}
+
+// hostConfig returns the HostConfig of the Connector pointing to the
+// current Grid Master, with the current WAPI version and credentials, the
+// caller holds the lock of the endpoint
+func (c *Connector) hostConfig() HostConfig {
+	cfg := c.HostConfig
+	cfg.Host, cfg.Port = c.gridMaster()
+	cfg.Version = c.wapiVersion()
+	if c.grid != nil {
+		cfg.Username, cfg.Password = c.grid.username, c.grid.password
+	}
+	return cfg
+}
+
+// setBasicAuth authenticates req with the current credentials
+func (c *Connector) setBasicAuth(req *http.Request) {
+	defer c.rlockGrid()()
+
+	cfg := c.hostConfig()
+	req.SetBasicAuth(cfg.Username, cfg.Password)
+}
+
+// isDiscoveryURL tells if discovery is a URL rather than a SRV record name
+func isDiscoveryURL(discovery string) bool {
+	return strings.HasPrefix(discovery, "https://") || strings.HasPrefix(discovery, "http://")
+}
+
+// discoverGridMaster returns the host and the port, empty if not given,
+// of the Grid Master named by the SRV record or the discovery URL
+// discovery. A discovery URL returns "host" or "host:port" as plain text.
+func discoverGridMaster(discovery string) (string, string, error) {
+	if !isDiscoveryURL(discovery) {
+		_, addrs, err := lookupSRV("", "", discovery)
+		if err != nil {
+			return "", "", err
+		}
+		if len(addrs) == 0 {
+			return "", "", fmt.Errorf("no SRV record found for '%s'", discovery)
+		}
+		// the records are sorted by priority and randomized by weight
+		return strings.TrimSuffix(addrs[0].Target, "."), strconv.Itoa(int(addrs[0].Port)), nil
+	}
+
+	resp, err := discoveryClient.Get(discovery)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("discovery URL '%s' returned %s", discovery, resp.Status)
+	}
+
+	hostPort := strings.TrimSpace(string(body))
+	if hostPort == "" {
+		return "", "", fmt.Errorf("discovery URL '%s' returned no Grid Master", discovery)
+	}
+	if host, port, err := net.SplitHostPort(hostPort); err == nil {
+		return host, port, nil
+	}
+	return hostPort, "", nil
+}
+
+// Discover resolves the Grid Master from HostConfig.Discovery and points
+// the connector, and the copies made by WithContext, to it. The current
+// port is kept when the discovery URL does not return one. It returns true
+// when the Grid Master moved.
+func (c *Connector) Discover() (bool, error) {
+	if c.HostConfig.Discovery == "" {
+		return false, errors.New("no Grid Master discovery is configured")
+	}
+
+	host, port, err := discoverGridMaster(c.HostConfig.Discovery)
+	if err != nil {
+		return false, fmt.Errorf("cannot discover the Grid Master from '%s': %s", c.HostConfig.Discovery, err)
+	}
+
+	defer c.lockGrid()()
+
+	curHost, curPort := c.gridMaster()
+	if port == "" {
+		port = curPort
+	}
+	moved := host != curHost || port != curPort
+	if moved {
+		c.grid.host, c.grid.port = host, port
+		c.RequestBuilder.Init(c.hostConfig())
+	}
+
+	return moved, nil
+}
+
+// rediscover discovers the Grid Master again after err, when err is a
+// failure to reach the grid rather than a WAPI error. It returns true when
+// the Grid Master moved and the request should be sent again.
+func (c *Connector) rediscover(err error) bool {
+	var wapiErr *WapiError
+	if c.HostConfig.Discovery == "" || errors.As(err, &wapiErr) {
+		return false
+	}
+
+	moved, discoverErr := c.Discover()
+	if discoverErr != nil {
+		return false
+	}
+	return moved
+}
@@ -0,0 +1,217 @@
+package ibclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// movedGMRequestor fails the requests sent to a Grid Master which is down
+type movedGMRequestor struct {
+	down  string
+	hosts []string
+}
+
+func (r *movedGMRequestor) Init(cfg TransportConfig) {}
+
+func (r *movedGMRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	r.hosts = append(r.hosts, req.URL.Host)
+	if req.URL.Host == r.down {
+		return nil, errors.New("dial tcp: connection refused")
+	}
+	return []byte(`[{"name": "default"}]`), nil
+}
+
+// hostsRequestor records the hosts of the requests sent concurrently
+type hostsRequestor struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (r *hostsRequestor) Init(cfg TransportConfig) {}
+
+func (r *hostsRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hosts = append(r.hosts, req.URL.Host)
+	return []byte(`[{"name": "default"}]`), nil
+}
+
+func (r *hostsRequestor) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.hosts[len(r.hosts)-1]
+}
+
+var _ = Describe("Grid Master discovery", func() {
+	var srvTargets []string
+	var origLookupSRV func(string, string, string) (string, []*net.SRV, error)
+
+	BeforeEach(func() {
+		srvTargets = []string{"gm1.example.com."}
+		origLookupSRV = lookupSRV
+		lookupSRV = func(service string, proto string, name string) (string, []*net.SRV, error) {
+			if name != "_wapi._tcp.example.com" {
+				return "", nil, errors.New("no such host")
+			}
+			return "", []*net.SRV{{Target: srvTargets[0], Port: 8443}}, nil
+		}
+	})
+
+	AfterEach(func() {
+		lookupSRV = origLookupSRV
+	})
+
+	It("should resolve the Grid Master from a SRV record", func() {
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+
+		hostConfig := HostConfig{Version: "2.11", Discovery: "_wapi._tcp.example.com"}
+		conn, err := NewConnector(hostConfig, TransportConfig{}, &WapiRequestBuilder{}, &movedGMRequestor{})
+		Expect(err).To(BeNil())
+		host, port := conn.gridMaster()
+		Expect(host).To(Equal("gm1.example.com"))
+		Expect(port).To(Equal("8443"))
+
+		_, err = NewConnector(HostConfig{Discovery: "_wapi._tcp.example.org"}, TransportConfig{},
+			&WapiRequestBuilder{}, &movedGMRequestor{})
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should resolve the Grid Master from a discovery URL", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("gm2.example.com\n"))
+		}))
+		defer server.Close()
+
+		wrb := &WapiRequestBuilder{}
+		hostConfig := HostConfig{Port: "443", Discovery: server.URL}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb}
+
+		moved, err := conn.Discover()
+		Expect(err).To(BeNil())
+		Expect(moved).To(BeTrue())
+		host, port := conn.gridMaster()
+		Expect(host).To(Equal("gm2.example.com"))
+		Expect(port).To(Equal("443"))
+		Expect(wrb.HostConfig.Host).To(Equal("gm2.example.com"))
+		Expect(conn.HostConfig.Host).To(BeEmpty())
+	})
+
+	It("should point the copies of the connector to the Grid Master it discovered", func() {
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+
+		var gm atomic.Value
+		gm.Store("gm1.example.com")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(gm.Load().(string)))
+		}))
+		defer server.Close()
+
+		requestor := &hostsRequestor{}
+		hostConfig := HostConfig{Port: "443", Version: "2.11", Discovery: server.URL}
+		conn, err := NewConnector(hostConfig, TransportConfig{}, &WapiRequestBuilder{}, requestor)
+		Expect(err).To(BeNil())
+		copied := conn.WithContext(context.Background())
+
+		gm.Store("gm2.example.com")
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var res []NetworkView
+				copied.GetObject(NewNetworkView(NetworkView{}), "", &res)
+			}()
+		}
+		moved, err := conn.Discover()
+		wg.Wait()
+		Expect(err).To(BeNil())
+		Expect(moved).To(BeTrue())
+
+		var res []NetworkView
+		Expect(copied.GetObject(NewNetworkView(NetworkView{}), "", &res)).To(BeNil())
+		Expect(requestor.last()).To(Equal("gm2.example.com:443"))
+	})
+
+	It("should copy the connector while it discovers the Grid Master", func() {
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+
+		var gm atomic.Value
+		gm.Store("gm1.example.com")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(gm.Load().(string)))
+		}))
+		defer server.Close()
+
+		requestor := &hostsRequestor{}
+		hostConfig := HostConfig{Port: "443", Version: "2.11", Discovery: server.URL,
+			Candidates: []GMCandidate{{Host: "gm3.example.com"}}}
+		conn, err := NewConnector(hostConfig, TransportConfig{}, &WapiRequestBuilder{}, requestor)
+		Expect(err).To(BeNil())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				copied := conn.WithContext(context.Background()).(*Connector)
+				var res []NetworkView
+				copied.GetObject(NewNetworkView(NetworkView{}), "", &res)
+				copied.SetMaintenanceHint("gm3.example.com", time.Minute)
+			}()
+		}
+		for _, host := range []string{"gm2.example.com", "gm1.example.com"} {
+			gm.Store(host)
+			_, err = conn.Discover()
+			Expect(err).To(BeNil())
+		}
+		wg.Wait()
+
+		Expect(conn.HostConfig.Host).To(BeEmpty())
+		Expect(conn.gridMasterEndpoint()).To(Equal("gm1.example.com:443"))
+	})
+
+	It("should send the request to the new Grid Master when it moved", func() {
+		requestor := &movedGMRequestor{down: "gm1.example.com:8443"}
+		wrb := &WapiRequestBuilder{}
+		hostConfig := HostConfig{Host: "gm1.example.com", Port: "8443", Version: "2.11", Discovery: "_wapi._tcp.example.com"}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+
+		srvTargets[0] = "gm2.example.com."
+		var res []NetworkView
+		err := conn.GetObject(NewNetworkView(NetworkView{}), "", &res)
+		Expect(err).To(BeNil())
+		Expect(requestor.hosts).To(Equal([]string{"gm1.example.com:8443", "gm2.example.com:8443"}))
+	})
+
+	It("should not discover the Grid Master again on WAPI errors", func() {
+		requestor := &bulkRequestor{errs: []error{&WapiError{StatusCode: 400}, &WapiError{StatusCode: 400}}}
+		hostConfig := HostConfig{Host: "gm1.example.com", Port: "8443", Version: "2.11", Discovery: "_wapi._tcp.example.com"}
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+		conn.RequestBuilder.Init(hostConfig)
+
+		srvTargets[0] = "gm2.example.com."
+		_, err := conn.DeleteObject("network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default")
+		Expect(err).NotTo(BeNil())
+		host, _ := conn.gridMaster()
+		Expect(host).To(Equal("gm1.example.com"))
+	})
+})
@@ -0,0 +1,100 @@
+package ibclient
+
+import "strings"
+
+// MatchClient is how the DHCP server matches the client of a fixed address
+type MatchClient string
+
+const (
+	MatchClientMacAddress MatchClient = "MAC_ADDRESS"
+	MatchClientClientID   MatchClient = "CLIENT_ID"
+	MatchClientReserved   MatchClient = "RESERVED"
+	MatchClientCircuitID  MatchClient = "CIRCUIT_ID"
+	MatchClientRemoteID   MatchClient = "REMOTE_ID"
+)
+
+// IsValid reports whether m is one of the match clients known to WAPI
+func (m MatchClient) IsValid() bool {
+	switch m {
+	case MatchClientMacAddress, MatchClientClientID, MatchClientReserved,
+		MatchClientCircuitID, MatchClientRemoteID:
+		return true
+	}
+	return false
+}
+
+// UpgradeStatusType is the level of the grid an upgrade status is reported
+// for
+type UpgradeStatusType string
+
+const (
+	UpgradeStatusGrid   UpgradeStatusType = "GRID"
+	UpgradeStatusGroup  UpgradeStatusType = "GROUP"
+	UpgradeStatusMember UpgradeStatusType = "MEMBER"
+	UpgradeStatusVNode  UpgradeStatusType = "VNODE"
+)
+
+// IsValid reports whether t is one of the upgrade status types known to
+// WAPI
+func (t UpgradeStatusType) IsValid() bool {
+	switch t {
+	case UpgradeStatusGrid, UpgradeStatusGroup, UpgradeStatusMember, UpgradeStatusVNode:
+		return true
+	}
+	return false
+}
+
+// RecordType is the type of a DNS record, e.g. of the records served by a
+// DTC LBDN
+type RecordType string
+
+const (
+	RecordTypeA     RecordType = "A"
+	RecordTypeAAAA  RecordType = "AAAA"
+	RecordTypeCNAME RecordType = "CNAME"
+	RecordTypeMX    RecordType = "MX"
+	RecordTypeNAPTR RecordType = "NAPTR"
+	RecordTypeNS    RecordType = "NS"
+	RecordTypePTR   RecordType = "PTR"
+	RecordTypeSRV   RecordType = "SRV"
+	RecordTypeTXT   RecordType = "TXT"
+	RecordTypeHost  RecordType = "HOST"
+)
+
+// IsValid reports whether t is one of the record types known to WAPI
+func (t RecordType) IsValid() bool {
+	switch t {
+	case RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeMX, RecordTypeNAPTR,
+		RecordTypeNS, RecordTypePTR, RecordTypeSRV, RecordTypeTXT, RecordTypeHost:
+		return true
+	}
+	return false
+}
+
+// ObjectType returns the WAPI object type of the records of type t, e.g.
+// "record:aaaa", or an empty string if t is not valid
+func (t RecordType) ObjectType() string {
+	if !t.IsValid() {
+		return ""
+	}
+	return "record:" + strings.ToLower(string(t))
+}
+
+// AddressType is what an IPv6 range serves, addresses, delegated prefixes
+// or both
+type AddressType string
+
+const (
+	AddressTypeAddress AddressType = "ADDRESS"
+	AddressTypePrefix  AddressType = "PREFIX"
+	AddressTypeBoth    AddressType = "BOTH"
+)
+
+// IsValid reports whether t is one of the address types known to WAPI
+func (t AddressType) IsValid() bool {
+	switch t {
+	case AddressTypeAddress, AddressTypePrefix, AddressTypeBoth:
+		return true
+	}
+	return false
+}
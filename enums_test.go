@@ -0,0 +1,66 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAPI enums", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	It("should validate the match clients", func() {
+		Expect(MatchClientRemoteID.IsValid()).To(BeTrue())
+		Expect(MatchClient("mac_address").IsValid()).To(BeFalse())
+	})
+
+	It("should validate the upgrade status types", func() {
+		Expect(UpgradeStatusMember.IsValid()).To(BeTrue())
+		Expect(UpgradeStatusType("NODE").IsValid()).To(BeFalse())
+	})
+
+	It("should return the object type of the record types", func() {
+		Expect(RecordTypeAAAA.ObjectType()).To(Equal("record:aaaa"))
+		Expect(RecordTypeHost.ObjectType()).To(Equal("record:host"))
+		Expect(RecordType("SPF").ObjectType()).To(Equal(""))
+	})
+
+	It("should validate the address types", func() {
+		Expect(AddressTypeBoth.IsValid()).To(BeTrue())
+		Expect(AddressType("PREFIXES").IsValid()).To(BeFalse())
+	})
+
+	Describe("Validation of the arguments", func() {
+		var conn *fakeMultiConnector
+		var objMgr *ObjectManager
+
+		BeforeEach(func() {
+			conn = &fakeMultiConnector{}
+			objMgr = NewObjectManager(conn, cmpType, tenantID)
+		})
+
+		It("should reject an unknown match client", func() {
+			_, err := objMgr.UpdateFixedAddress("fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.1/default", "MAC", "", "", "")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.updateObjs).To(BeEmpty())
+		})
+
+		It("should reject an unknown upgrade status type", func() {
+			_, err := objMgr.GetUpgradeStatus("NODE")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.getObjs).To(BeEmpty())
+		})
+
+		It("should reject an unknown IPv6 range address type", func() {
+			_, err := objMgr.CreateIPv6Range(IPv6Range{NetviewName: "default", Network: "2001:db8::/48", AddressType: "PREFIXES"})
+			Expect(err).NotTo(BeNil())
+			Expect(conn.createObjs).To(BeEmpty())
+		})
+
+		It("should reject a record type not served by a DTC LBDN", func() {
+			_, err := objMgr.CreateDtcLbdn(DtcLbdn{Name: "app.example.com", Types: []RecordType{RecordTypeA, RecordTypeMX}})
+			Expect(err).NotTo(BeNil())
+			Expect(conn.createObjs).To(BeEmpty())
+		})
+	})
+})
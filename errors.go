@@ -0,0 +1,365 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrNotEmpty is matched by NotEmptyError
+var ErrNotEmpty = errors.New("object is not empty")
+
+// ErrOverlap is matched by OverlapError
+var ErrOverlap = errors.New("network overlaps existing networks")
+
+// ErrRecordConflict is matched by RecordConflictError
+var ErrRecordConflict = errors.New("record conflicts with existing records")
+
+// NotEmptyError is returned when deleting an object that still contains
+// child objects
+type NotEmptyError struct {
+	Ref      string
+	Children []string
+}
+
+func (e *NotEmptyError) Error() string {
+	return fmt.Sprintf("'%s' still contains %d child object(s): %s",
+		e.Ref, len(e.Children), strings.Join(e.Children, ", "))
+}
+
+func (e *NotEmptyError) Is(target error) bool {
+	return target == ErrNotEmpty
+}
+
+// BulkFailure holds the refs of a chunk rejected by the grid
+type BulkFailure struct {
+	Refs []string
+	Err  error
+}
+
+// BulkUpdateError is returned when some chunks of a bulk update failed.
+// The chunks not listed in Failures were applied.
+type BulkUpdateError struct {
+	Failures []BulkFailure
+}
+
+func (e *BulkUpdateError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	failed := 0
+	for _, f := range e.Failures {
+		failed += len(f.Refs)
+		msgs = append(msgs, f.Err.Error())
+	}
+
+	return fmt.Sprintf("bulk update failed for %d object(s): %s",
+		failed, strings.Join(msgs, "; "))
+}
+
+// OverlapError is returned when a network to create overlaps existing
+// networks or network containers of the network view
+type OverlapError struct {
+	Cidr        string
+	NetviewName string
+	Conflicts   []string
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("network '%s' overlaps %s in network view '%s'",
+		e.Cidr, strings.Join(e.Conflicts, ", "), e.NetviewName)
+}
+
+func (e *OverlapError) Is(target error) bool {
+	return target == ErrOverlap
+}
+
+// RecordConflictError is returned when a CNAME record would share its name
+// with other records, or a record would share its name with a CNAME record
+type RecordConflictError struct {
+	ObjectType string
+	Name       string
+	View       string
+	Conflicts  []string
+}
+
+func (e *RecordConflictError) Error() string {
+	return fmt.Sprintf("%s '%s' conflicts with %s in view '%s'",
+		e.ObjectType, e.Name, strings.Join(e.Conflicts, ", "), e.View)
+}
+
+func (e *RecordConflictError) Is(target error) bool {
+	return target == ErrRecordConflict
+}
+
+// UnresolvedError is returned by Resolve when no object has the name
+type UnresolvedError struct {
+	ObjectType string
+	Name       string
+	View       string
+}
+
+func (e *UnresolvedError) Error() string {
+	if e.View != "" {
+		return fmt.Sprintf("%s '%s' not found in view '%s'", e.ObjectType, e.Name, e.View)
+	}
+	return fmt.Sprintf("%s '%s' not found", e.ObjectType, e.Name)
+}
+
+func (e *UnresolvedError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ErrInvalidEA is matched by InvalidEAError
+var ErrInvalidEA = errors.New("invalid extensible attribute")
+
+// InvalidEAError is returned when an EA is not defined on the grid or its
+// value does not match its definition
+type InvalidEAError struct {
+	Name   string
+	Value  interface{}
+	Reason string
+}
+
+func (e *InvalidEAError) Error() string {
+	return fmt.Sprintf("extensible attribute '%s' %s", e.Name, e.Reason)
+}
+
+func (e *InvalidEAError) Is(target error) bool {
+	return target == ErrInvalidEA
+}
+
+// ClockSkewError is returned when the local time differs from the time of
+// the Grid Master by more than the allowed skew
+type ClockSkewError struct {
+	Skew    time.Duration
+	MaxSkew time.Duration
+}
+
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("local clock is %s off the Grid Master clock, more than %s", e.Skew, e.MaxSkew)
+}
+
+var (
+	// ErrNotFound is matched by NotFoundError
+	ErrNotFound = errors.New("object not found")
+	// ErrConflict is matched by ConflictError
+	ErrConflict = errors.New("object conflicts with an existing object")
+	// ErrAuth is matched by AuthError
+	ErrAuth = errors.New("authentication or authorization failed")
+	// ErrAccountLocked is matched by AccountLockedError
+	ErrAccountLocked = errors.New("account is locked")
+	// ErrRequestTooLarge is matched by RequestTooLargeError
+	ErrRequestTooLarge = errors.New("request body is too large")
+	// ErrAddressSpaceExhausted is matched by AddressSpaceExhaustedError
+	ErrAddressSpaceExhausted = errors.New("no address is available")
+	// ErrNoAvailableNetwork is matched by NoAvailableNetworkError
+	ErrNoAvailableNetwork = errors.New("no network is available")
+	// ErrUnsupportedConnector is returned by the operations sending
+	// requests the connector of the ObjectManager cannot send, e.g. the
+	// request object or function calls through a mock IBConnector
+	ErrUnsupportedConnector = errors.New("operation is not supported by the connector")
+)
+
+// WapiError is an error response of WAPI. Error, code and text are parsed
+// from the JSON body when present.
+type WapiError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Body       []byte `json:"-"`
+	ErrorType  string `json:"Error"`
+	Code       string `json:"code"`
+	Text       string `json:"text"`
+}
+
+func (e *WapiError) Error() string {
+	return fmt.Sprintf("WAPI request error: %d('%s')\nContents:\n%s\n", e.StatusCode, e.Status, e.Body)
+}
+
+// NotFoundError is returned when the referenced object does not exist
+type NotFoundError struct {
+	WapiError
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return &e.WapiError
+}
+
+// ConflictError is returned when the object conflicts with an existing one,
+// for instance when it already exists
+type ConflictError struct {
+	WapiError
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+func (e *ConflictError) Unwrap() error {
+	return &e.WapiError
+}
+
+// RequestTooLargeError is returned when the grid rejects a request whose
+// body exceeds its size limit
+type RequestTooLargeError struct {
+	WapiError
+}
+
+func (e *RequestTooLargeError) Is(target error) bool {
+	return target == ErrRequestTooLarge
+}
+
+func (e *RequestTooLargeError) Unwrap() error {
+	return &e.WapiError
+}
+
+// AddressSpaceExhaustedError is returned when nextavailableip finds no
+// free address left in the network or range, e.g. to add a network
+type AddressSpaceExhaustedError struct {
+	WapiError
+}
+
+func (e *AddressSpaceExhaustedError) Is(target error) bool {
+	return target == ErrAddressSpaceExhausted
+}
+
+func (e *AddressSpaceExhaustedError) Unwrap() error {
+	return &e.WapiError
+}
+
+// NoAvailableNetworkError is returned when nextavailablenetwork finds no
+// free network of the requested prefix length left in the network
+// container, e.g. to expand the container
+type NoAvailableNetworkError struct {
+	WapiError
+}
+
+func (e *NoAvailableNetworkError) Is(target error) bool {
+	return target == ErrNoAvailableNetwork
+}
+
+func (e *NoAvailableNetworkError) Unwrap() error {
+	return &e.WapiError
+}
+
+// AuthError is returned when the credentials are rejected or the user
+// lacks the permission for the request
+type AuthError struct {
+	WapiError
+}
+
+func (e *AuthError) Is(target error) bool {
+	return target == ErrAuth
+}
+
+func (e *AuthError) Unwrap() error {
+	return &e.WapiError
+}
+
+// AccountLockedError is returned when the grid rejects the credentials as
+// the account is locked out after too many failed logins. UnlockTime is
+// zero when the response does not tell when the account is unlocked.
+type AccountLockedError struct {
+	AuthError
+	UnlockTime time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	if e.UnlockTime.IsZero() {
+		return "account is locked: " + e.AuthError.Error()
+	}
+	return fmt.Sprintf("account is locked until %s: %s", e.UnlockTime.Format(time.RFC3339), e.AuthError.Error())
+}
+
+func (e *AccountLockedError) Is(target error) bool {
+	return target == ErrAccountLocked || target == ErrAuth
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return &e.AuthError
+}
+
+// exhaustedRegexp matches the error text of the next available functions
+// when they find nothing left, e.g. "Cannot find 1 available IP
+// address(es) in this network"
+var exhaustedRegexp = regexp.MustCompile(`(?i)cannot find \d+ available (ip|network)`)
+
+// newExhaustedError returns an *AddressSpaceExhaustedError or a
+// *NoAvailableNetworkError if the response reports that a next available
+// function found nothing left, otherwise nil
+func newExhaustedError(wapiErr WapiError) error {
+	m := exhaustedRegexp.FindStringSubmatch(wapiErr.Text)
+	if m == nil {
+		m = exhaustedRegexp.FindStringSubmatch(wapiErr.ErrorType)
+	}
+	if m == nil {
+		return nil
+	}
+
+	if strings.EqualFold(m[1], "ip") {
+		return &AddressSpaceExhaustedError{wapiErr}
+	}
+	return &NoAvailableNetworkError{wapiErr}
+}
+
+var unlockTimeRegexp = regexp.MustCompile(`(?i)until\s+(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:Z|[+-]\d{2}:?\d{2})?)`)
+
+// newAuthError returns an *AccountLockedError if the response reports a
+// locked account, with the unlock time when it is given, otherwise an
+// *AuthError
+func newAuthError(wapiErr WapiError) error {
+	body := strings.ToLower(string(wapiErr.Body))
+	if !strings.Contains(body, "locked") && !strings.Contains(strings.ToLower(wapiErr.Text), "locked") {
+		return &AuthError{wapiErr}
+	}
+
+	lockedErr := &AccountLockedError{AuthError: AuthError{wapiErr}}
+	if m := unlockTimeRegexp.FindStringSubmatch(string(wapiErr.Body)); m != nil {
+		value := strings.Replace(m[1], " ", "T", 1)
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05-0700", "2006-01-02T15:04:05"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				lockedErr.UnlockTime = t
+				break
+			}
+		}
+	}
+
+	return lockedErr
+}
+
+// newWapiError builds the typed error matching the status code and the
+// WAPI error code of the response
+func newWapiError(statusCode int, status string, body []byte) error {
+	wapiErr := WapiError{StatusCode: statusCode, Status: status, Body: body}
+	json.Unmarshal(body, &wapiErr)
+
+	// checked first as the grid may report the exhaustion as a conflict,
+	// which would be retried in vain
+	if exhaustedErr := newExhaustedError(wapiErr); exhaustedErr != nil {
+		return exhaustedErr
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden ||
+		strings.HasPrefix(wapiErr.Code, "Client.Ibap.Auth"):
+		return newAuthError(wapiErr)
+	case statusCode == http.StatusNotFound ||
+		strings.HasSuffix(wapiErr.Code, ".NotFound") ||
+		strings.HasPrefix(wapiErr.ErrorType, "AdmConDataNotFoundError"):
+		return &NotFoundError{wapiErr}
+	case statusCode == http.StatusConflict ||
+		strings.HasSuffix(wapiErr.Code, ".Conflict") ||
+		strings.Contains(wapiErr.ErrorType, "IBDataConflictError"):
+		return &ConflictError{wapiErr}
+	case statusCode == http.StatusRequestEntityTooLarge:
+		return &RequestTooLargeError{wapiErr}
+	}
+
+	return &wapiErr
+}
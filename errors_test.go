@@ -0,0 +1,105 @@
+package ibclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WAPI errors", func() {
+	It("should return a NotFoundError for a missing object", func() {
+		err := newWapiError(http.StatusBadRequest, "400 Bad Request", []byte(`{"Error": "AdmConDataNotFoundError: Reference network/abc not found", "code": "Client.Ibap.Data.NotFound", "text": "Reference network/abc not found"}`))
+
+		nfErr, ok := err.(*NotFoundError)
+		Expect(ok).To(BeTrue())
+		Expect(nfErr.Is(ErrNotFound)).To(BeTrue())
+		Expect(nfErr.Code).To(Equal("Client.Ibap.Data.NotFound"))
+		Expect(nfErr.Text).To(Equal("Reference network/abc not found"))
+		Expect(nfErr.Unwrap().(*WapiError).StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return a ConflictError for an existing object", func() {
+		err := newWapiError(http.StatusBadRequest, "400 Bad Request", []byte(`{"Error": "AdmConDataError: None (IBDataConflictError: IB.Data.Conflict:The network 10.0.0.0/24 already exists.)", "code": "Client.Ibap.Data.Conflict", "text": "The network 10.0.0.0/24 already exists."}`))
+
+		cErr, ok := err.(*ConflictError)
+		Expect(ok).To(BeTrue())
+		Expect(cErr.Is(ErrConflict)).To(BeTrue())
+		Expect(cErr.Is(ErrNotFound)).To(BeFalse())
+	})
+
+	It("should return an AuthError for rejected credentials", func() {
+		err := newWapiError(http.StatusUnauthorized, "401 Authorization Required", []byte("<html>401</html>"))
+
+		aErr, ok := err.(*AuthError)
+		Expect(ok).To(BeTrue())
+		Expect(aErr.Is(ErrAuth)).To(BeTrue())
+		Expect(aErr.Error()).To(Equal("WAPI request error: 401('401 Authorization Required')\nContents:\n<html>401</html>\n"))
+	})
+
+	It("should return an AccountLockedError for a locked account", func() {
+		err := newWapiError(http.StatusUnauthorized, "401 Authorization Required",
+			[]byte(`{"Error": "AdmConProtoError: Account is locked until 2026-10-17T10:30:00Z", "code": "Client.Ibap.Auth", "text": "Account is locked"}`))
+
+		lErr, ok := err.(*AccountLockedError)
+		Expect(ok).To(BeTrue())
+		Expect(errors.Is(err, ErrAccountLocked)).To(BeTrue())
+		Expect(errors.Is(err, ErrAuth)).To(BeTrue())
+		Expect(lErr.UnlockTime).To(Equal(time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)))
+
+		err = newWapiError(http.StatusForbidden, "403 Forbidden", []byte("<html>User account locked</html>"))
+		lErr, ok = err.(*AccountLockedError)
+		Expect(ok).To(BeTrue())
+		Expect(lErr.UnlockTime.IsZero()).To(BeTrue())
+	})
+
+	It("should return a RequestTooLargeError for a request body too large", func() {
+		err := newWapiError(http.StatusRequestEntityTooLarge, "413 Request Entity Too Large", []byte("<html>413</html>"))
+
+		_, ok := err.(*RequestTooLargeError)
+		Expect(ok).To(BeTrue())
+		Expect(errors.Is(err, ErrRequestTooLarge)).To(BeTrue())
+	})
+
+	It("should detect the exhaustion of the next available functions", func() {
+		err := newWapiError(http.StatusBadRequest, "400 Bad Request", []byte(`{"Error": "AdmConProtoError: Cannot find 1 available IP address(es) in this network", "code": "Client.Ibap.Proto", "text": "Cannot find 1 available IP address(es) in this network"}`))
+
+		_, ok := err.(*AddressSpaceExhaustedError)
+		Expect(ok).To(BeTrue())
+		Expect(errors.Is(err, ErrAddressSpaceExhausted)).To(BeTrue())
+		Expect(errors.Is(err, ErrNoAvailableNetwork)).To(BeFalse())
+
+		err = newWapiError(http.StatusBadRequest, "400 Bad Request", []byte(`{"Error": "AdmConDataError: None (IBDataConflictError: IB.Data.Conflict:Cannot find 1 available network for container 10.0.0.0/16)", "code": "Client.Ibap.Data.Conflict", "text": "Cannot find 1 available network for container 10.0.0.0/16"}`))
+
+		_, ok = err.(*NoAvailableNetworkError)
+		Expect(ok).To(BeTrue())
+		Expect(errors.Is(err, ErrNoAvailableNetwork)).To(BeTrue())
+		Expect(errors.Is(err, ErrConflict)).To(BeFalse())
+	})
+
+	It("should return a WapiError for other failures", func() {
+		err := newWapiError(http.StatusBadRequest, "400 Bad Request", []byte(`{"Error": "AdmConProtoError: Unknown argument/field: 'foo'", "code": "Client.Ibap.Proto", "text": "Unknown argument/field: 'foo'"}`))
+
+		wErr, ok := err.(*WapiError)
+		Expect(ok).To(BeTrue())
+		Expect(wErr.ErrorType).To(HavePrefix("AdmConProtoError"))
+	})
+
+	It("should be returned by WapiHttpRequestor", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Error": "AdmConDataNotFoundError", "code": "Client.Ibap.Data.NotFound", "text": "not found"}`))
+		}))
+		defer server.Close()
+
+		whr := &WapiHttpRequestor{}
+		whr.Init(NewTransportConfig("false", 20, 10))
+
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		_, err := whr.SendRequest(req)
+		Expect(err).To(BeAssignableToTypeOf(&NotFoundError{}))
+	})
+})
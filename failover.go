@@ -0,0 +1,192 @@
+package ibclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFailbackAfter is how long the requests keep going to the Grid
+// Master candidate which answered when the Grid Master could not be
+// reached, if HostConfig.FailbackAfter is not set
+const DefaultFailbackAfter = 5 * time.Minute
+
+// GMCandidate is a Grid Master candidate the requests fail over to when
+// the Grid Master cannot be reached. The candidates are tried by
+// decreasing Weight. Port defaults to the port of HostConfig.
+type GMCandidate struct {
+	Host   string
+	Port   string
+	Weight int
+}
+
+// gmPreference is the endpoint, "host:port", the requests of the
+// connectors sharing it are sent to instead of the Grid Master until a
+// time. It is created by NewConnector, the Connectors created otherwise
+// have none and always send their requests to the Grid Master first.
+type gmPreference struct {
+	mu       sync.Mutex
+	endpoint string
+	until    time.Time
+}
+
+// prefer sends the requests to endpoint until until, or to the Grid Master
+// if endpoint is empty
+func (p *gmPreference) prefer(endpoint string, until time.Time) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.endpoint = endpoint
+	p.until = until
+}
+
+// current returns the preferred endpoint, empty once it expired
+func (p *gmPreference) current() string {
+	if p == nil {
+		return ""
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.endpoint != "" && time.Now().Before(p.until) {
+		return p.endpoint
+	}
+	p.endpoint = ""
+	return ""
+}
+
+// gridMasterEndpoint returns the endpoint of the current Grid Master
+func (c *Connector) gridMasterEndpoint() string {
+	defer c.rlockGrid()()
+
+	return net.JoinHostPort(c.gridMaster())
+}
+
+// candidatePort returns the port of the Grid Master candidate cand, the
+// port of the current Grid Master if it has none
+func (c *Connector) candidatePort(cand GMCandidate) string {
+	if cand.Port != "" {
+		return cand.Port
+	}
+
+	defer c.rlockGrid()()
+	_, port := c.gridMaster()
+	return port
+}
+
+// candidateEndpoints returns the endpoints of the Grid Master candidates
+// by decreasing weight
+func (c *Connector) candidateEndpoints() []string {
+	candidates := make([]GMCandidate, len(c.HostConfig.Candidates))
+	copy(candidates, c.HostConfig.Candidates)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight > candidates[j].Weight
+	})
+
+	endpoints := make([]string, 0, len(candidates))
+	for _, cand := range candidates {
+		endpoints = append(endpoints, net.JoinHostPort(cand.Host, c.candidatePort(cand)))
+	}
+	return endpoints
+}
+
+// endpoint returns the endpoint the requests are sent to, empty for the
+// Grid Master of HostConfig
+func (c *Connector) endpoint() string {
+	return c.preference.current()
+}
+
+// routeRequest sends req to the preferred endpoint, if any
+func (c *Connector) routeRequest(req *http.Request) {
+	if endpoint := c.endpoint(); endpoint != "" {
+		req.URL.Host = endpoint
+	}
+}
+
+// SetMaintenanceHint sends the requests to the Grid Master candidate host
+// for d, e.g. while the Grid Master is upgraded, then fails back to the
+// Grid Master. An empty host fails back at once. The hint applies to the
+// copies of the Connector made by WithContext too.
+func (c *Connector) SetMaintenanceHint(host string, d time.Duration) error {
+	if c.preference == nil {
+		return fmt.Errorf("%w: the connector was not created by NewConnector", ErrUnsupportedConnector)
+	}
+	if host == "" {
+		c.preference.prefer("", time.Time{})
+		return nil
+	}
+
+	for _, cand := range c.HostConfig.Candidates {
+		if cand.Host == host {
+			c.preference.prefer(net.JoinHostPort(host, c.candidatePort(cand)), time.Now().Add(d))
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not a Grid Master candidate", host)
+}
+
+// unreachable tells if err is a failure to reach the endpoint rather than
+// an error response of WAPI
+func unreachable(err error) bool {
+	var wapiErr *WapiError
+	return !errors.As(err, &wapiErr) && !errors.Is(err, ErrRequestorClosed)
+}
+
+// sendFailover sends the request to the Grid Master and its candidates
+// other than the endpoint which could not be reached, by decreasing
+// weight, until one of them answers. The requests keep going to the
+// candidate which answered for HostConfig.FailbackAfter. It returns the
+// error of the last endpoint when none answered.
+func (c *Connector) sendFailover(t RequestType, obj IBObject, ref string, queryParams QueryParams, err error) ([]byte, error) {
+	failed := c.endpoint()
+	if failed == "" {
+		failed = c.gridMasterEndpoint()
+	}
+
+	failbackAfter := c.HostConfig.FailbackAfter
+	if failbackAfter <= 0 {
+		failbackAfter = DefaultFailbackAfter
+	}
+
+	endpoints := append([]string{c.gridMasterEndpoint()}, c.candidateEndpoints()...)
+	for _, endpoint := range endpoints {
+		if endpoint == failed {
+			continue
+		}
+		req, buildErr := c.newRequest(t, obj, ref, queryParams)
+		if buildErr != nil {
+			return nil, buildErr
+		}
+		if c.ctx != nil {
+			req = req.WithContext(c.ctx)
+		}
+		req.URL.Host = endpoint
+
+		var res []byte
+		res, err = c.send(req)
+		if err != nil && unreachable(err) {
+			if c.ctx != nil && c.ctx.Err() != nil {
+				return nil, c.ctx.Err()
+			}
+			continue
+		}
+
+		if endpoint == c.gridMasterEndpoint() {
+			c.preference.prefer("", time.Time{})
+		} else {
+			c.preference.prefer(endpoint, time.Now().Add(failbackAfter))
+		}
+		return res, err
+	}
+
+	return nil, err
+}
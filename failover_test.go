@@ -0,0 +1,132 @@
+package ibclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// downGMRequestor fails the requests sent to the endpoints which are down
+type downGMRequestor struct {
+	down  map[string]bool
+	hosts []string
+}
+
+func (r *downGMRequestor) Init(cfg TransportConfig) {}
+
+func (r *downGMRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	r.hosts = append(r.hosts, req.URL.Host)
+	if r.down[req.URL.Host] {
+		return nil, errors.New("dial tcp: connection refused")
+	}
+	return []byte(`"networkview/ZG5zLm5ldHdvcmtfdmlldyQyMw:global_view/false"`), nil
+}
+
+var _ = Describe("Grid Master candidates", func() {
+	var requestor *downGMRequestor
+	var conn *Connector
+
+	BeforeEach(func() {
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+
+		requestor = &downGMRequestor{down: map[string]bool{"gm.example.com:443": true}}
+		hostConfig := HostConfig{Host: "gm.example.com", Port: "443", Version: "2.11",
+			Candidates: []GMCandidate{
+				{Host: "gmc1.example.com", Weight: 1},
+				{Host: "gmc2.example.com", Port: "8443", Weight: 10}}}
+		var err error
+		conn, err = NewConnector(hostConfig, TransportConfig{}, &WapiRequestBuilder{}, requestor)
+		Expect(err).To(BeNil())
+	})
+
+	It("should fail over to the candidates by weight and fail back", func() {
+		requestor.down["gmc2.example.com:8443"] = true
+		conn.HostConfig.FailbackAfter = 50 * time.Millisecond
+
+		_, err := conn.CreateObject(NewNetworkView(NetworkView{Name: "global_view"}))
+		Expect(err).To(BeNil())
+		Expect(requestor.hosts).To(Equal([]string{"gm.example.com:443",
+			"gmc2.example.com:8443", "gmc1.example.com:443"}))
+
+		// the candidate which answered keeps the requests
+		requestor.hosts = nil
+		_, err = conn.WithContext(context.Background()).CreateObject(NewNetworkView(NetworkView{Name: "global_view"}))
+		Expect(err).To(BeNil())
+		Expect(requestor.hosts).To(Equal([]string{"gmc1.example.com:443"}))
+
+		time.Sleep(60 * time.Millisecond)
+		delete(requestor.down, "gm.example.com:443")
+		requestor.hosts = nil
+		_, err = conn.CreateObject(NewNetworkView(NetworkView{Name: "global_view"}))
+		Expect(err).To(BeNil())
+		Expect(requestor.hosts).To(Equal([]string{"gm.example.com:443"}))
+	})
+
+	It("should prefer the candidate of the maintenance hint", func() {
+		delete(requestor.down, "gm.example.com:443")
+		Expect(conn.SetMaintenanceHint("gmc1.example.com", time.Hour)).To(BeNil())
+
+		_, err := conn.CreateObject(NewNetworkView(NetworkView{Name: "global_view"}))
+		Expect(err).To(BeNil())
+		Expect(requestor.hosts).To(Equal([]string{"gmc1.example.com:443"}))
+
+		Expect(conn.SetMaintenanceHint("", 0)).To(BeNil())
+		requestor.hosts = nil
+		_, err = conn.CreateObject(NewNetworkView(NetworkView{Name: "global_view"}))
+		Expect(err).To(BeNil())
+		Expect(requestor.hosts).To(Equal([]string{"gm.example.com:443"}))
+
+		Expect(conn.SetMaintenanceHint("gm2.example.com", time.Hour)).To(
+			MatchError("'gm2.example.com' is not a Grid Master candidate"))
+	})
+
+	It("should apply the maintenance hint to the copies made before it", func() {
+		copied := conn.WithContext(context.Background()).(*Connector)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				copied.endpoint()
+			}()
+		}
+		Expect(conn.SetMaintenanceHint("gmc2.example.com", time.Hour)).To(BeNil())
+		wg.Wait()
+		Expect(copied.endpoint()).To(Equal("gmc2.example.com:8443"))
+
+		literal := &Connector{HostConfig: conn.HostConfig, RequestBuilder: conn.RequestBuilder, Requestor: requestor}
+		Expect(errors.Is(literal.SetMaintenanceHint("gmc1.example.com", time.Hour), ErrUnsupportedConnector)).To(BeTrue())
+	})
+
+	It("should use the port of the discovered Grid Master for the candidates without one", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("gm2.example.com:8080"))
+		}))
+		defer server.Close()
+		conn.HostConfig.Discovery = server.URL
+
+		_, err := conn.Discover()
+		Expect(err).To(BeNil())
+		Expect(conn.candidateEndpoints()).To(Equal([]string{"gmc2.example.com:8443", "gmc1.example.com:8080"}))
+		Expect(conn.SetMaintenanceHint("gmc1.example.com", time.Hour)).To(BeNil())
+		Expect(conn.endpoint()).To(Equal("gmc1.example.com:8080"))
+	})
+
+	It("should not fail over on WAPI errors", func() {
+		bulk := &bulkRequestor{errs: []error{&WapiError{StatusCode: 400}, &WapiError{StatusCode: 400}}}
+		conn.Requestor = bulk
+
+		_, err := conn.CreateObject(NewNetworkView(NetworkView{Name: "global_view"}))
+		Expect(err).NotTo(BeNil())
+		Expect(conn.endpoint()).To(Equal(""))
+	})
+})
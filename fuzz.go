@@ -0,0 +1,53 @@
+//go:build gofuzz
+// +build gofuzz
+
+package ibclient
+
+import (
+	"encoding/json"
+)
+
+// FuzzEA is the go-fuzz target of the EA parser:
+//
+//	go-fuzz-build -func FuzzEA && go-fuzz
+func FuzzEA(data []byte) int {
+	var ea EA
+	if err := json.Unmarshal(data, &ea); err != nil {
+		return 0
+	}
+
+	js, err := json.Marshal(ea)
+	if err != nil {
+		panic(err)
+	}
+	var ea2 EA
+	if err = json.Unmarshal(js, &ea2); err != nil {
+		panic(err)
+	}
+
+	return 1
+}
+
+// FuzzRef is the go-fuzz target of the ref parsers
+func FuzzRef(data []byte) int {
+	ref := string(data)
+	res := 0
+
+	if nv := BuildNetworkViewFromRef(ref); nv != nil {
+		if nv.Ref != ref {
+			panic("network view ref changed")
+		}
+		res = 1
+	}
+	if nw := BuildNetworkFromRef(ref); nw != nil {
+		if nw.Ref != ref {
+			panic("network ref changed")
+		}
+		res = 1
+	}
+	if GetIPAddressFromRef(ref) != "" {
+		res = 1
+	}
+
+	return res
+}
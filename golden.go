@@ -0,0 +1,56 @@
+package ibclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// RenderWapiJSON returns the JSON body sent to WAPI for a request of type t
+// on obj. Keys are sorted and indented so that the result can be compared
+// to golden files.
+func RenderWapiJSON(t RequestType, obj IBObject) ([]byte, error) {
+	wrb := &WapiRequestBuilder{}
+	body := wrb.BuildBody(t, obj)
+	if body == nil {
+		return nil, fmt.Errorf("cannot render the WAPI JSON of '%s'", obj.ObjectType())
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewBuffer(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	js, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(js, '\n'), nil
+}
+
+// CheckGolden compares the WAPI JSON of obj to the golden file at path.
+// The golden file is written instead when update is set.
+func CheckGolden(path string, t RequestType, obj IBObject, update bool) error {
+	js, err := RenderWapiJSON(t, obj)
+	if err != nil {
+		return err
+	}
+
+	if update {
+		return ioutil.WriteFile(path, js, 0644)
+	}
+
+	golden, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(js, golden) {
+		return fmt.Errorf("WAPI JSON of '%s' does not match golden file '%s':\n%s", obj.ObjectType(), path, js)
+	}
+
+	return nil
+}
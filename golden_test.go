@@ -0,0 +1,53 @@
+package ibclient
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "update the golden files of testdata/golden")
+
+var _ = Describe("Golden WAPI JSON", func() {
+	vmEA := EA{"VM ID": "93f9249abc039284", "VM Name": "dummyvm", "Tenant ID": "01234567890abcdef01234567890abcdef"}
+
+	goldens := []struct {
+		file string
+		t    RequestType
+		obj  IBObject
+	}{
+		{"network_create.json", CREATE, NewNetwork(Network{
+			NetviewName: "default", Cidr: "10.0.0.0/24", Ea: EA{"Network Name": "private", "Cloud API Owned": Bool(true)}})},
+		{"record_a_create.json", CREATE, NewRecordA(RecordA{
+			View: "default", Name: "web.example.com", Ipv4Addr: "10.0.0.12", Ttl: 300, Ea: vmEA})},
+		{"host_record_create.json", CREATE, NewHostRecord(HostRecord{
+			Name: "host1.example.com", View: "default", NetworkView: "default",
+			Ipv4Addrs: []HostRecordIpv4Addr{*NewHostRecordIpv4Addr(HostRecordIpv4Addr{Ipv4Addr: "10.0.0.13", Mac: MACADDR_ZERO})},
+			Ea:        vmEA})},
+		{"network_search.json", GET, &Network{
+			IBBase:      IBBase{objectType: "network", eaSearch: EASearch{"Network Name": "private"}},
+			NetviewName: "default"}},
+	}
+
+	for _, g := range goldens {
+		g := g
+		It("should render "+g.file, func() {
+			path := filepath.Join("testdata", "golden", g.file)
+			Expect(CheckGolden(path, g.t, g.obj, *updateGolden)).To(Succeed())
+		})
+	}
+
+	It("should report a mismatch with the golden file", func() {
+		path := filepath.Join("testdata", "golden", "network_create.json")
+		nw := NewNetwork(Network{NetviewName: "default", Cidr: "10.0.1.0/24"})
+		Expect(CheckGolden(path, CREATE, nw, false)).NotTo(Succeed())
+	})
+
+	It("should fail on a missing golden file", func() {
+		err := CheckGolden(filepath.Join("testdata", "golden", "missing.json"), CREATE, NewNetwork(Network{}), false)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
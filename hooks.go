@@ -0,0 +1,85 @@
+package ibclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes an HTTP request sent by a Connector to WAPI
+type RequestInfo struct {
+	Method string
+	// ObjectType is the type of the object requested, e.g. "network", or
+	// "request" for multiple object requests
+	ObjectType string
+	URL        string
+}
+
+// ResponseInfo describes the outcome of an HTTP request sent to WAPI
+type ResponseInfo struct {
+	RequestInfo
+	Duration time.Duration
+	// StatusCode is the status of the response, http.StatusOK for any
+	// successful response, or 0 if no response was received
+	StatusCode int
+	Size       int
+	Err        error
+}
+
+// RequestHook is called by a Connector around each HTTP request it sends to
+// WAPI, including retries and the fallbacks to the Grid Master, to log,
+// measure or trace them
+type RequestHook interface {
+	// OnRequest is called before the request is sent. The returned context
+	// is bound to the request and passed to OnResponse, e.g. to carry a
+	// tracing span.
+	OnRequest(ctx context.Context, info *RequestInfo) context.Context
+	// OnResponse is called once the response is received or the request
+	// failed
+	OnResponse(ctx context.Context, info *ResponseInfo)
+}
+
+// requestObjectType returns the object type of the WAPI URL path, e.g.
+// /wapi/v2.11/network/ZG5zLm5ldHdvcms:10.0.0.0/24/default, or an empty
+// string for other paths such as file transfers
+func requestObjectType(path string) string {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 4)
+	if len(parts) < 3 || parts[0] != "wapi" {
+		return ""
+	}
+
+	return parts[2]
+}
+
+// sendHooked sends req with the requestor of the Connector, calling its
+// RequestHook before and after
+func (c *Connector) sendHooked(req *http.Request) ([]byte, error) {
+	if c.RequestHook == nil {
+		return c.Requestor.SendRequest(req)
+	}
+
+	info := &RequestInfo{
+		Method:     req.Method,
+		ObjectType: requestObjectType(req.URL.Path),
+		URL:        req.URL.String()}
+	ctx := c.RequestHook.OnRequest(req.Context(), info)
+	if ctx != nil && ctx != req.Context() {
+		req = req.WithContext(ctx)
+	}
+
+	start := time.Now()
+	res, err := c.Requestor.SendRequest(req)
+
+	resInfo := &ResponseInfo{RequestInfo: *info, Duration: time.Since(start), Size: len(res), Err: err}
+	var wapiErr *WapiError
+	if err == nil {
+		resInfo.StatusCode = http.StatusOK
+	} else if errors.As(err, &wapiErr) {
+		resInfo.StatusCode = wapiErr.StatusCode
+	}
+	c.RequestHook.OnResponse(req.Context(), resInfo)
+
+	return res, err
+}
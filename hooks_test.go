@@ -0,0 +1,64 @@
+package ibclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type hookKey struct{}
+
+type recordingHook struct {
+	requests  []RequestInfo
+	responses []ResponseInfo
+	contexts  []interface{}
+}
+
+func (h *recordingHook) OnRequest(ctx context.Context, info *RequestInfo) context.Context {
+	h.requests = append(h.requests, *info)
+	return context.WithValue(ctx, hookKey{}, len(h.requests))
+}
+
+func (h *recordingHook) OnResponse(ctx context.Context, info *ResponseInfo) {
+	h.responses = append(h.responses, *info)
+	h.contexts = append(h.contexts, ctx.Value(hookKey{}))
+}
+
+var _ = Describe("Request Hooks", func() {
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+
+	It("should report the requests and their responses", func() {
+		hook := &recordingHook{}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		requestor := &flakyRequestor{errs: []error{
+			newWapiError(http.StatusNotFound, "404 Not Found", []byte(`{"Error": "AdmConDataNotFoundError"}`))}}
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor, RequestHook: hook}
+
+		_, err := conn.UpdateObject(NewNetwork(Network{Comment: "lab"}), "network/ZG5zLm5ldHdvcms:10.0.0.0/24/default")
+		Expect(err).To(BeNil())
+
+		// the failed request falls back to the Grid Master
+		Expect(hook.requests).To(HaveLen(2))
+		Expect(hook.requests[0].Method).To(Equal("PUT"))
+		Expect(hook.requests[0].ObjectType).To(Equal("network"))
+		Expect(hook.requests[0].URL).To(Equal(
+			"https://172.22.18.66:443/wapi/v2.11/network/ZG5zLm5ldHdvcms:10.0.0.0/24/default"))
+
+		Expect(hook.responses).To(HaveLen(2))
+		Expect(hook.responses[0].StatusCode).To(Equal(http.StatusNotFound))
+		Expect(errors.Is(hook.responses[0].Err, ErrNotFound)).To(BeTrue())
+		Expect(hook.responses[1].StatusCode).To(Equal(http.StatusOK))
+		Expect(hook.responses[1].Err).To(BeNil())
+		Expect(hook.responses[1].Size).To(BeNumerically(">", 0))
+		Expect(hook.contexts).To(Equal([]interface{}{1, 2}))
+	})
+
+	It("should return an empty object type outside of WAPI", func() {
+		Expect(requestObjectType("/wapi/v2.11/request")).To(Equal("request"))
+		Expect(requestObjectType("/http_direct_file_io/req_id-UPLOAD-0123/import_file")).To(Equal(""))
+	})
+})
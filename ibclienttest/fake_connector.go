@@ -0,0 +1,502 @@
+// Package ibclienttest provides an in-memory implementation of
+// ibclient.IBConnector to unit test code using an ibclient.ObjectManager
+// without a grid:
+//
+//	conn := ibclienttest.NewFakeConnector()
+//	objMgr := ibclient.NewObjectManager(conn, "Docker", "tenant")
+//	fixedAddr, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm", "")
+package ibclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	ibclient "github.com/infobloxopen/infoblox-go-client"
+)
+
+const nextAvailableIP = "func:nextavailableip:"
+const nextAvailableNetwork = "func:nextavailablenetwork:"
+
+// FakeConnector stores the objects it creates in memory. Objects are got,
+// updated and deleted by the refs it returned, and searches match the
+// non-empty fields of the object searched and its EA search against the
+// stored objects of the same type. Searches return all the fields of the
+// objects, whatever their return fields. The next available IP and network
+// functions allocate from the stored objects. As an ibclient.MultiRequester
+// it applies the request objects of the bulk operations.
+type FakeConnector struct {
+	mu      sync.Mutex
+	seq     int
+	refs    []string
+	objects map[string]map[string]interface{}
+}
+
+// NewFakeConnector returns a FakeConnector holding no objects
+func NewFakeConnector() *FakeConnector {
+	return &FakeConnector{objects: make(map[string]map[string]interface{})}
+}
+
+func objectTypeOf(ref string) string {
+	return strings.SplitN(ref, "/", 2)[0]
+}
+
+func notFound(ref string) error {
+	return &ibclient.NotFoundError{WapiError: ibclient.WapiError{
+		StatusCode: http.StatusNotFound,
+		Status:     http.StatusText(http.StatusNotFound),
+		ErrorType:  "AdmConProtoError: Reference not found",
+		Text:       fmt.Sprintf("Reference %s not found", ref)}}
+}
+
+// fields returns the JSON fields of obj
+func fields(obj ibclient.IBObject) (map[string]interface{}, error) {
+	js, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var res map[string]interface{}
+	err = json.Unmarshal(js, &res)
+	return res, err
+}
+
+// unmarshal converts v, decoded from JSON, into res
+func unmarshal(v interface{}, res interface{}) error {
+	if res == nil {
+		return nil
+	}
+	js, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(js, res)
+}
+
+// newRef returns a ref for an object of objType with the given fields, in
+// the format of the grid, e.g. fixedaddress/ZmFrZTE:10.0.0.1/default
+func (c *FakeConnector) newRef(objType string, obj map[string]interface{}) string {
+	c.seq++
+
+	name := ""
+	for _, field := range []string{"ipv4addr", "ipv6addr", "network", "fqdn", "name", "host_name"} {
+		if s, ok := obj[field].(string); ok && s != "" {
+			name = s
+			break
+		}
+	}
+	if objType == "ipv6fixedaddress" {
+		name = url.PathEscape(name)
+	}
+
+	ref := fmt.Sprintf("%s/ZmFrZQ%d:%s", objType, c.seq, name)
+	for _, field := range []string{"network_view", "view"} {
+		if s, ok := obj[field].(string); ok && s != "" {
+			return ref + "/" + s
+		}
+	}
+
+	return ref
+}
+
+// usedIPs returns the addresses of the stored objects in netview
+func (c *FakeConnector) usedIPs(netview string) map[string]bool {
+	used := make(map[string]bool)
+	for _, obj := range c.objects {
+		if nv, ok := obj["network_view"].(string); ok && nv != netview {
+			continue
+		}
+		for _, field := range []string{"ipv4addr", "ipv6addr"} {
+			if s, ok := obj[field].(string); ok {
+				used[s] = true
+			}
+		}
+		for _, field := range []string{"ipv4addrs", "ipv6addrs"} {
+			addrs, _ := obj[field].([]interface{})
+			for _, a := range addrs {
+				addr, _ := a.(map[string]interface{})
+				for _, f := range []string{"ipv4addr", "ipv6addr"} {
+					if s, ok := addr[f].(string); ok {
+						used[s] = true
+					}
+				}
+			}
+		}
+	}
+
+	return used
+}
+
+// nextIP returns the first address of cidr not used in netview, skipping
+// the network and broadcast addresses of IPv4 networks
+func (c *FakeConnector) nextIP(function string) (string, error) {
+	args := strings.Split(strings.TrimPrefix(function, nextAvailableIP), ",")
+	netview := "default"
+	if len(args) > 1 {
+		netview = args[1]
+	}
+	_, ipNet, err := net.ParseCIDR(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	ip := ipNet.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ones, bits := ipNet.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	first, last := big.NewInt(0), new(big.Int).Sub(size, big.NewInt(1))
+	if len(ip) == net.IPv4len && bits-ones > 1 {
+		first, last = big.NewInt(1), new(big.Int).Sub(last, big.NewInt(1))
+	}
+
+	used := c.usedIPs(netview)
+	base := new(big.Int).SetBytes(ip)
+	for i := first; i.Cmp(last) <= 0; i.Add(i, big.NewInt(1)) {
+		b := new(big.Int).Add(base, i).Bytes()
+		addr := make(net.IP, len(ip))
+		copy(addr[len(addr)-len(b):], b)
+		if !used[addr.String()] {
+			return addr.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no available IP address in '%s'", args[0])
+}
+
+// nextNetwork returns the first network of the prefix length in the
+// container not overlapping the networks of the same type in netview
+func (c *FakeConnector) nextNetwork(objType string, function string) (string, error) {
+	args := strings.Split(strings.TrimPrefix(function, nextAvailableNetwork), ",")
+	if len(args) != 3 {
+		return "", fmt.Errorf("invalid function '%s'", function)
+	}
+	var prefixLen uint
+	if _, err := fmt.Sscanf(args[2], "%d", &prefixLen); err != nil {
+		return "", fmt.Errorf("invalid prefix length in '%s'", function)
+	}
+
+	subnets, err := ibclient.NextSubnets(args[0], prefixLen)
+	if err != nil {
+		return "", err
+	}
+	for _, subnet := range subnets {
+		overlaps := false
+		for ref, obj := range c.objects {
+			network, _ := obj["network"].(string)
+			if objectTypeOf(ref) != objType || obj["network_view"] != args[1] || network == "" {
+				continue
+			}
+			if overlaps, _ = ibclient.Overlaps(subnet, network); overlaps {
+				break
+			}
+		}
+		if !overlaps {
+			return subnet, nil
+		}
+	}
+
+	return "", fmt.Errorf("no available network of prefix length %d in '%s'", prefixLen, args[0])
+}
+
+// allocate replaces the next available IP and network functions of obj by
+// the allocated address or network
+func (c *FakeConnector) allocate(objType string, obj map[string]interface{}) error {
+	for field, v := range obj {
+		s, _ := v.(string)
+		switch {
+		case strings.HasPrefix(s, nextAvailableIP):
+			ip, err := c.nextIP(s)
+			if err != nil {
+				return err
+			}
+			obj[field] = ip
+		case strings.HasPrefix(s, nextAvailableNetwork):
+			network, err := c.nextNetwork(objType, s)
+			if err != nil {
+				return err
+			}
+			obj[field] = network
+		}
+	}
+
+	for _, field := range []string{"ipv4addrs", "ipv6addrs"} {
+		addrs, _ := obj[field].([]interface{})
+		for _, a := range addrs {
+			addr, ok := a.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if err := c.allocate(objType, addr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// create stores the object of objType with the given fields and returns
+// its ref
+func (c *FakeConnector) create(objType string, stored map[string]interface{}) (string, error) {
+	if err := c.allocate(objType, stored); err != nil {
+		return "", err
+	}
+
+	ref := c.newRef(objType, stored)
+	stored["_ref"] = ref
+	c.refs = append(c.refs, ref)
+	c.objects[ref] = stored
+
+	return ref, nil
+}
+
+// CreateObject stores obj and returns its ref
+func (c *FakeConnector) CreateObject(obj ibclient.IBObject) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored, err := fields(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return c.create(obj.ObjectType(), stored)
+}
+
+// matches reports whether stored has the values of filters and matches
+// eaSearch
+func matches(stored map[string]interface{}, filters map[string]interface{}, eaSearch ibclient.EASearch) bool {
+	for field, v := range filters {
+		if field == "extattrs" || v == nil || reflect.ValueOf(v).IsZero() {
+			continue
+		}
+		if !reflect.DeepEqual(stored[field], v) {
+			return false
+		}
+	}
+
+	extattrs, _ := stored["extattrs"].(map[string]interface{})
+	for name, v := range eaSearch {
+		ea, _ := extattrs[name].(map[string]interface{})
+		if ea == nil || fmt.Sprint(ea["value"]) != fmt.Sprint(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetObject unmarshals the object referenced by ref into res or, if ref is
+// empty, the objects matching obj into res, which must then be a pointer to
+// a slice
+func (c *FakeConnector) GetObject(obj ibclient.IBObject, ref string, res interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ref != "" {
+		stored, ok := c.objects[ref]
+		if !ok {
+			return notFound(ref)
+		}
+		return unmarshal(stored, res)
+	}
+
+	filters, err := fields(obj)
+	if err != nil {
+		return err
+	}
+
+	return unmarshal(c.search(obj.ObjectType(), filters, obj.EaSearch()), res)
+}
+
+// search returns the stored objects of objType matching filters and
+// eaSearch
+func (c *FakeConnector) search(objType string, filters map[string]interface{}, eaSearch ibclient.EASearch) []map[string]interface{} {
+	found := []map[string]interface{}{}
+	for _, r := range c.refs {
+		stored := c.objects[r]
+		if objectTypeOf(r) == objType && matches(stored, filters, eaSearch) {
+			found = append(found, stored)
+		}
+	}
+
+	return found
+}
+
+// UpdateObject sets the non-empty fields of obj on the object referenced
+// by ref
+func (c *FakeConnector) UpdateObject(obj ibclient.IBObject, ref string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	update, err := fields(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return c.update(ref, update)
+}
+
+// update sets the fields of update on the object referenced by ref. The
+// extattrs+ and extattrs- fields add and remove EAs as in WAPI.
+func (c *FakeConnector) update(ref string, update map[string]interface{}) (string, error) {
+	stored, ok := c.objects[ref]
+	if !ok {
+		return "", notFound(ref)
+	}
+
+	if err := c.allocate(objectTypeOf(ref), update); err != nil {
+		return "", err
+	}
+	for field, v := range update {
+		switch field {
+		case "extattrs+", "extattrs-":
+			// a new map, the one stored may be shared with a snapshot
+			extattrs := make(map[string]interface{})
+			old, _ := stored["extattrs"].(map[string]interface{})
+			for name, ea := range old {
+				extattrs[name] = ea
+			}
+			changes, _ := v.(map[string]interface{})
+			for name, ea := range changes {
+				if field == "extattrs+" {
+					extattrs[name] = ea
+				} else {
+					delete(extattrs, name)
+				}
+			}
+			stored["extattrs"] = extattrs
+		default:
+			stored[field] = v
+		}
+	}
+
+	return ref, nil
+}
+
+// DeleteObject deletes the object referenced by ref
+func (c *FakeConnector) DeleteObject(ref string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.remove(ref)
+}
+
+// remove deletes the object referenced by ref
+func (c *FakeConnector) remove(ref string) (string, error) {
+	if _, ok := c.objects[ref]; !ok {
+		return "", notFound(ref)
+	}
+	delete(c.objects, ref)
+	for i, r := range c.refs {
+		if r == ref {
+			c.refs = append(c.refs[:i], c.refs[i+1:]...)
+			break
+		}
+	}
+
+	return ref, nil
+}
+
+// apply applies a request of a request object and returns its result
+func (c *FakeConnector) apply(body ibclient.RequestBody) (interface{}, error) {
+	data := body.Data
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+
+	switch body.Method {
+	case "GET":
+		if strings.Contains(body.Object, "/") {
+			stored, ok := c.objects[body.Object]
+			if !ok {
+				return nil, notFound(body.Object)
+			}
+			return stored, nil
+		}
+		filters := make(map[string]interface{})
+		eaSearch := make(ibclient.EASearch)
+		for field, v := range data {
+			if strings.HasPrefix(field, "*") {
+				eaSearch[strings.TrimPrefix(field, "*")] = v
+			} else {
+				filters[field] = v
+			}
+		}
+		return c.search(body.Object, filters, eaSearch), nil
+	case "POST":
+		return c.create(body.Object, data)
+	case "PUT":
+		return c.update(body.Object, data)
+	case "DELETE":
+		return c.remove(body.Object)
+	}
+
+	return nil, fmt.Errorf("unsupported method '%s' in request object", body.Method)
+}
+
+// MultiRequest applies the requests of req in order, all of them or none,
+// and returns the results of those not discarded. Substitutions between
+// the requests are not supported.
+func (c *FakeConnector) MultiRequest(req *ibclient.MultiRequest) ([]byte, error) {
+	js, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var body []ibclient.RequestBody
+	if err = json.Unmarshal(js, &body); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seq, refs := c.seq, append([]string(nil), c.refs...)
+	objects := make(map[string]map[string]interface{}, len(c.objects))
+	for ref, obj := range c.objects {
+		copied := make(map[string]interface{}, len(obj))
+		for field, v := range obj {
+			copied[field] = v
+		}
+		objects[ref] = copied
+	}
+
+	results := []interface{}{}
+	for _, b := range body {
+		res, err := c.apply(b)
+		if err != nil {
+			c.seq, c.refs, c.objects = seq, refs, objects
+			return nil, err
+		}
+		if !b.Discard {
+			results = append(results, res)
+		}
+	}
+
+	return json.Marshal(results)
+}
+
+// Objects returns the stored objects of objType, in the order they were
+// created, for the assertions of tests
+func (c *FakeConnector) Objects(objType string) []map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := []map[string]interface{}{}
+	for _, r := range c.refs {
+		if objectTypeOf(r) == objType {
+			res = append(res, c.objects[r])
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,108 @@
+package ibclienttest
+
+import (
+	"errors"
+
+	ibclient "github.com/infobloxopen/infoblox-go-client"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FakeConnector", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	It("should allocate, get and release IP addresses", func() {
+		conn := NewFakeConnector()
+		objMgr := ibclient.NewObjectManager(conn, cmpType, tenantID)
+
+		fixedAddr, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm1", "", "")
+		Expect(err).To(BeNil())
+		Expect(fixedAddr.IPAddress).To(Equal("10.0.0.1"))
+		fixedAddr, err = objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm2", "", "")
+		Expect(err).To(BeNil())
+		Expect(fixedAddr.IPAddress).To(Equal("10.0.0.2"))
+
+		found, err := objMgr.GetFixedAddress("default", "10.0.0.0/24", "10.0.0.2", "")
+		Expect(err).To(BeNil())
+		Expect(found.Ref).To(Equal(fixedAddr.Ref))
+		Expect(found.Name).To(Equal("vm2"))
+
+		ref, err := objMgr.ReleaseIP("default", "10.0.0.0/24", "10.0.0.1", "")
+		Expect(err).To(BeNil())
+		Expect(ref).ToNot(BeEmpty())
+		Expect(conn.Objects("fixedaddress")).To(HaveLen(1))
+
+		fixedAddr, err = objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "vm3", "", "")
+		Expect(err).To(BeNil())
+		Expect(fixedAddr.IPAddress).To(Equal("10.0.0.1"))
+	})
+
+	It("should allocate networks not overlapping existing ones", func() {
+		conn := NewFakeConnector()
+		objMgr := ibclient.NewObjectManager(conn, cmpType, tenantID)
+
+		_, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "existing")
+		Expect(err).To(BeNil())
+
+		network, err := objMgr.AllocateNetwork("default", "10.0.0.0/16", 24, "allocated")
+		Expect(err).To(BeNil())
+		Expect(network.Cidr).To(Equal("10.0.1.0/24"))
+		Expect(network.NetviewName).To(Equal("default"))
+
+		found, err := objMgr.GetNetwork("default", "10.0.1.0/24", ibclient.EA{"Network Name": "allocated"})
+		Expect(err).To(BeNil())
+		Expect(found.Ref).To(Equal(network.Ref))
+	})
+
+	It("should update objects and return not found errors for unknown refs", func() {
+		conn := NewFakeConnector()
+		objMgr := ibclient.NewObjectManager(conn, cmpType, tenantID)
+
+		fixedAddr, err := objMgr.AllocateIP("default", "10.0.0.0/24", "10.0.0.10", "", "vm", "", "")
+		Expect(err).To(BeNil())
+		_, err = objMgr.UpdateFixedAddress(fixedAddr.Ref, "MAC_ADDRESS", "11:22:33:44:55:66", "", "")
+		Expect(err).To(BeNil())
+		Expect(conn.Objects("fixedaddress")[0]["mac"]).To(Equal("11:22:33:44:55:66"))
+		Expect(conn.Objects("fixedaddress")[0]["ipv4addr"]).To(Equal("10.0.0.10"))
+
+		_, err = conn.DeleteObject("network/ZmFrZQ42:10.1.0.0/24/default")
+		Expect(errors.Is(err, ibclient.ErrNotFound)).To(BeTrue())
+	})
+
+	It("should apply bulk EA updates through the request object", func() {
+		conn := NewFakeConnector()
+		objMgr := ibclient.NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = true
+
+		first, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "first")
+		Expect(err).To(BeNil())
+		second, err := objMgr.CreateNetwork("default", "10.0.1.0/24", "second")
+		Expect(err).To(BeNil())
+
+		updated, err := objMgr.BulkUpdateEA([]string{first.Ref, second.Ref},
+			ibclient.EA{"Site": "east"}, ibclient.EA{"Network Name": ""})
+		Expect(err).To(BeNil())
+		Expect(updated).To(Equal([]string{first.Ref, second.Ref}))
+
+		found, err := objMgr.GetNetwork("default", "10.0.1.0/24", ibclient.EA{"Site": "east"})
+		Expect(err).To(BeNil())
+		Expect(found.Ref).To(Equal(second.Ref))
+		Expect(found.Ea).To(Equal(ibclient.EA{"Site": "east"}))
+	})
+
+	It("should apply none of the requests of a failed request object", func() {
+		conn := NewFakeConnector()
+		objMgr := ibclient.NewObjectManager(conn, cmpType, tenantID)
+
+		network, err := objMgr.CreateNetwork("default", "10.0.0.0/24", "first")
+		Expect(err).To(BeNil())
+
+		_, err = objMgr.BulkUpdateEA([]string{network.Ref, "network/ZmFrZQ42:10.1.0.0/24/default"},
+			ibclient.EA{"Site": "east"}, nil)
+		var bulkErr *ibclient.BulkUpdateError
+		Expect(errors.As(err, &bulkErr)).To(BeTrue())
+		Expect(errors.Is(bulkErr.Failures[0].Err, ibclient.ErrNotFound)).To(BeTrue())
+		Expect(conn.Objects("network")[0]["extattrs"]).NotTo(HaveKey("Site"))
+	})
+})
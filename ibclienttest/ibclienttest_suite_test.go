@@ -0,0 +1,13 @@
+package ibclienttest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestIbclienttest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ibclienttest Suite")
+}
@@ -0,0 +1,253 @@
+package ibclient
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// MaxSubnets bounds the number of subnets enumerated by NextSubnets
+const MaxSubnets = 65536
+
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR '%s': %s", cidr, err)
+	}
+	return ipNet, nil
+}
+
+// parseNetOrIP parses a CIDR, or a single address as a host network
+func parseNetOrIP(s string) (*net.IPNet, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		bits := net.IPv6len * 8
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = net.IPv4len * 8
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	return parseCIDR(s)
+}
+
+func isIPv6CIDR(cidr string) bool {
+	ipNet, err := parseNetOrIP(cidr)
+	return err == nil && len(ipNet.IP) == net.IPv6len
+}
+
+// validatePrefixLen checks that networks of prefixLen fit in cidr
+func validatePrefixLen(cidr string, prefixLen uint) error {
+	ipNet, err := parseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if prefixLen < uint(ones) || prefixLen > uint(bits) {
+		return fmt.Errorf("prefix length %d is out of range for '%s', expected %d to %d",
+			prefixLen, cidr, ones, bits)
+	}
+	return nil
+}
+
+// NextSubnets returns the subnets of parent with the given prefix length,
+// in address order
+func NextSubnets(parent string, prefixLen uint) ([]string, error) {
+	if err := validatePrefixLen(parent, prefixLen); err != nil {
+		return nil, err
+	}
+
+	ipNet, _ := parseCIDR(parent)
+	ones, bits := ipNet.Mask.Size()
+	if prefixLen-uint(ones) > 16 {
+		return nil, fmt.Errorf("'%s' holds more than %d networks of prefix length %d",
+			parent, MaxSubnets, prefixLen)
+	}
+
+	count := 1 << (prefixLen - uint(ones))
+	step := new(big.Int).Lsh(big.NewInt(1), uint(bits)-prefixLen)
+	addr := new(big.Int).SetBytes(ipNet.IP)
+	mask := net.CIDRMask(int(prefixLen), bits)
+
+	subnets := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		b := addr.Bytes()
+		ip := make(net.IP, len(ipNet.IP))
+		copy(ip[len(ip)-len(b):], b)
+		subnets = append(subnets, (&net.IPNet{IP: ip, Mask: mask}).String())
+		addr.Add(addr, step)
+	}
+
+	return subnets, nil
+}
+
+// Contains reports whether child, a CIDR or an address, lies within the
+// parent network
+func Contains(parent string, child string) (bool, error) {
+	p, err := parseCIDR(parent)
+	if err != nil {
+		return false, err
+	}
+	c, err := parseNetOrIP(child)
+	if err != nil {
+		return false, err
+	}
+
+	if len(p.IP) != len(c.IP) {
+		return false, nil
+	}
+	pOnes, _ := p.Mask.Size()
+	cOnes, _ := c.Mask.Size()
+
+	return p.Contains(c.IP) && cOnes >= pOnes, nil
+}
+
+// Overlaps reports whether the networks a and b share any address
+func Overlaps(a string, b string) (bool, error) {
+	na, err := parseNetOrIP(a)
+	if err != nil {
+		return false, err
+	}
+	nb, err := parseNetOrIP(b)
+	if err != nil {
+		return false, err
+	}
+
+	if len(na.IP) != len(nb.IP) {
+		return false, nil
+	}
+	return na.Contains(nb.IP) || nb.Contains(na.IP), nil
+}
+
+// getNetworkCidrs returns the CIDRs of all networks and of all network
+// containers of the network view in the address family of cidr
+func (objMgr *ObjectManager) getNetworkCidrs(netview string, cidr string) (networkCidrs []string, containerCidrs []string, err error) {
+	var networks []Network
+	var containers []NetworkContainer
+
+	network := NewNetwork(Network{NetviewName: netview})
+	container := NewNetworkContainer(NetworkContainer{NetviewName: netview})
+	if isIPv6CIDR(cidr) {
+		network = NewIPv6Network(Network{NetviewName: netview})
+		container = NewIPv6NetworkContainer(NetworkContainer{NetviewName: netview})
+	}
+	network.returnFields = []string{"network"}
+	container.returnFields = []string{"network"}
+
+	if err = objMgr.readObjectPaged(network, DefaultPageSize, &networks); err != nil {
+		return
+	}
+	if err = objMgr.readObjectPaged(container, DefaultPageSize, &containers); err != nil {
+		return
+	}
+
+	for _, n := range networks {
+		networkCidrs = append(networkCidrs, n.Cidr)
+	}
+	for _, c := range containers {
+		containerCidrs = append(containerCidrs, c.Cidr)
+	}
+
+	return
+}
+
+// OverlapCheck returns the CIDRs of the networks and network containers
+// of the network view which overlap cidr
+func (objMgr *ObjectManager) OverlapCheck(netview string, cidr string) ([]string, error) {
+	if _, err := parseCIDR(cidr); err != nil {
+		return nil, err
+	}
+
+	networks, containers, err := objMgr.getNetworkCidrs(netview, cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlaps []string
+	for _, e := range append(networks, containers...) {
+		if ok, _ := Overlaps(cidr, e); ok {
+			overlaps = append(overlaps, e)
+		}
+	}
+
+	return overlaps, nil
+}
+
+// checkNetworkOverlap returns an *OverlapError if a network of cidr would
+// overlap existing networks, or contain network containers, of the view
+func (objMgr *ObjectManager) checkNetworkOverlap(netview string, cidr string) error {
+	if _, err := parseCIDR(cidr); err != nil {
+		return err
+	}
+
+	networks, containers, err := objMgr.getNetworkCidrs(netview, cidr)
+	if err != nil {
+		return err
+	}
+
+	var conflicts []string
+	for _, n := range networks {
+		if ok, _ := Overlaps(cidr, n); ok {
+			conflicts = append(conflicts, n)
+		}
+	}
+	for _, c := range containers {
+		if ok, _ := Contains(cidr, c); ok {
+			conflicts = append(conflicts, c)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &OverlapError{Cidr: cidr, NetviewName: netview, Conflicts: conflicts}
+	}
+	return nil
+}
+
+// freeSubnet returns the first subnet of parent with the given prefix
+// length not overlapping the networks defined in parent, or "" if there
+// is none
+func (objMgr *ObjectManager) freeSubnet(netview string, parent string, prefixLen uint) (string, error) {
+	subnets, err := NextSubnets(parent, prefixLen)
+	if err != nil {
+		return "", err
+	}
+
+	networks, containers, err := objMgr.getNetworkCidrs(netview, parent)
+	if err != nil {
+		return "", err
+	}
+
+	var used []string
+	for _, e := range append(networks, containers...) {
+		if ok, _ := Contains(parent, e); ok && e != parent {
+			used = append(used, e)
+		}
+	}
+
+	for _, s := range subnets {
+		free := true
+		for _, u := range used {
+			if ok, _ := Overlaps(s, u); ok {
+				free = false
+				break
+			}
+		}
+		if free {
+			return s, nil
+		}
+	}
+
+	return "", nil
+}
+
+// allocationError explains a failed network allocation when the parent
+// network has no room left for a network of prefixLen
+func (objMgr *ObjectManager) allocationError(netview string, parent string, prefixLen uint, err error) error {
+	free, ferr := objMgr.freeSubnet(netview, parent, prefixLen)
+	if ferr != nil || free != "" {
+		return err
+	}
+
+	return fmt.Errorf("no network of prefix length %d is available in '%s' of network view '%s': %w",
+		prefixLen, parent, netview, err)
+}
@@ -0,0 +1,179 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type allocFailConnector struct {
+	fakeMultiConnector
+	err error
+}
+
+func (c *allocFailConnector) CreateObject(obj IBObject) (string, error) {
+	c.createObjs = append(c.createObjs, obj)
+	return "", c.err
+}
+
+var _ = Describe("IP math", func() {
+	Describe("NextSubnets", func() {
+		It("should enumerate the IPv4 subnets of the parent", func() {
+			subnets, err := NextSubnets("10.0.0.0/24", 26)
+			Expect(err).To(BeNil())
+			Expect(subnets).To(Equal([]string{
+				"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"}))
+		})
+
+		It("should enumerate the IPv6 subnets of the parent", func() {
+			subnets, err := NextSubnets("2001:db8::/63", 64)
+			Expect(err).To(BeNil())
+			Expect(subnets).To(Equal([]string{"2001:db8::/64", "2001:db8:0:1::/64"}))
+		})
+
+		It("should reject prefix lengths outside of the parent", func() {
+			_, err := NextSubnets("10.0.0.0/24", 16)
+			Expect(err).NotTo(BeNil())
+			_, err = NextSubnets("10.0.0.0/8", 32)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Contains and Overlaps", func() {
+		It("should compare networks and addresses", func() {
+			Expect(Contains("10.0.0.0/16", "10.0.4.0/24")).To(BeTrue())
+			Expect(Contains("10.0.0.0/16", "10.0.4.7")).To(BeTrue())
+			Expect(Contains("10.0.4.0/24", "10.0.0.0/16")).To(BeFalse())
+			Expect(Contains("10.0.0.0/16", "2001:db8::1")).To(BeFalse())
+
+			Expect(Overlaps("10.0.4.0/24", "10.0.0.0/16")).To(BeTrue())
+			Expect(Overlaps("10.0.4.0/24", "10.0.5.0/24")).To(BeFalse())
+
+			_, err := Contains("10.0.0.0/33", "10.0.0.1")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("OverlapCheck", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"network":          []Network{{Cidr: "10.0.4.0/24"}, {Cidr: "10.1.0.0/24"}},
+				"networkcontainer": []NetworkContainer{{Cidr: "10.0.0.0/16"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+		It("should return the overlapping networks and containers of the view", func() {
+			overlaps, err := objMgr.OverlapCheck("default", "10.0.4.128/25")
+			Expect(err).To(BeNil())
+			Expect(overlaps).To(Equal([]string{"10.0.4.0/24", "10.0.0.0/16"}))
+			Expect(conn.getObjs[0].(*Network).NetviewName).To(Equal("default"))
+		})
+	})
+
+	Describe("CreateNetwork with CheckNetworkOverlap", func() {
+		newConnector := func() *fakeMultiConnector {
+			return &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"network":          []Network{{Cidr: "10.0.4.0/24"}},
+					"networkcontainer": []NetworkContainer{{Cidr: "10.0.0.0/16"}, {Cidr: "10.0.8.0/22"}},
+				},
+			}
+		}
+
+		It("should return an OverlapError naming the conflicting networks", func() {
+			conn := newConnector()
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+			objMgr.CheckNetworkOverlap = true
+
+			network, err := objMgr.CreateNetwork("default", "10.0.0.0/20", "")
+			Expect(network).To(BeNil())
+			Expect(conn.createObjs).To(BeEmpty())
+
+			overlapErr, ok := err.(*OverlapError)
+			Expect(ok).To(BeTrue())
+			Expect(overlapErr.Is(ErrOverlap)).To(BeTrue())
+			Expect(overlapErr.Conflicts).To(Equal([]string{"10.0.4.0/24", "10.0.8.0/22"}))
+			Expect(err.Error()).To(Equal("network '10.0.0.0/20' overlaps 10.0.4.0/24, 10.0.8.0/22 in network view 'default'"))
+		})
+
+		It("should create a network inside a container", func() {
+			conn := newConnector()
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+			objMgr.CheckNetworkOverlap = true
+
+			network, err := objMgr.CreateNetwork("default", "10.0.5.0/24", "")
+			Expect(err).To(BeNil())
+			Expect(network.Cidr).To(Equal("10.0.5.0/24"))
+			Expect(len(conn.createObjs)).To(Equal(1))
+		})
+	})
+
+	Describe("AllocateNetwork", func() {
+		It("should reject a prefix length larger than the container", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			_, err := objMgr.AllocateNetwork("default", "10.0.0.0/24", 16, "")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.createObjs).To(BeEmpty())
+		})
+
+		It("should explain that the container is exhausted", func() {
+			conn := &allocFailConnector{err: errors.New("Cannot find 1 available network")}
+			conn.getResults = map[string]interface{}{
+				"network":          []Network{{Cidr: "10.0.0.0/25"}, {Cidr: "10.0.0.128/25"}},
+				"networkcontainer": []NetworkContainer{{Cidr: "10.0.0.0/24"}},
+			}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			network, err := objMgr.AllocateNetwork("default", "10.0.0.0/24", 25, "")
+			Expect(network).To(BeNil())
+			Expect(err.Error()).To(HavePrefix("no network of prefix length 25 is available in '10.0.0.0/24'"))
+		})
+
+		It("should keep the exhaustion reported by the grid", func() {
+			conn := &allocFailConnector{err: newWapiError(http.StatusBadRequest, "400 Bad Request",
+				[]byte(`{"Error": "AdmConProtoError: Cannot find 1 available network", "code": "Client.Ibap.Proto", "text": "Cannot find 1 available network"}`))}
+			conn.getResults = map[string]interface{}{
+				"network":          []Network{{Cidr: "10.0.0.0/25"}, {Cidr: "10.0.0.128/25"}},
+				"networkcontainer": []NetworkContainer{{Cidr: "10.0.0.0/24"}},
+			}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			_, err := objMgr.AllocateNetwork("default", "10.0.0.0/24", 25, "")
+			Expect(errors.Is(err, ErrNoAvailableNetwork)).To(BeTrue())
+			Expect(err.Error()).To(HavePrefix("no network of prefix length 25 is available in '10.0.0.0/24'"))
+		})
+
+		It("should create the network with its attributes in the same request", func() {
+			networkRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+			conn := &fakeMultiConnector{createRefs: []string{networkRef}}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+			objMgr.OmitCloudAttrs = true
+
+			network, err := objMgr.AllocateNetworkWithOptions("default", "10.0.0.0/16", 24, "app", NetworkOptions{
+				Comment:            "app tier",
+				Ea:                 EA{"Site": "east"},
+				Options:            []DhcpOption{{Name: "routers", Num: 3, Value: "10.0.0.1"}},
+				Members:            []DhcpMember{{Name: "dhcp1.example.com", Ipv4Addr: "10.1.0.5"}},
+				HighWaterMark:      90,
+				HighWaterMarkReset: 80})
+			Expect(err).To(BeNil())
+			Expect(network.Cidr).To(Equal("10.0.0.0/24"))
+			Expect(conn.createObjs).To(HaveLen(1))
+
+			js, _ := json.Marshal(conn.createObjs[0])
+			Expect(js).To(MatchJSON(`{"network_view": "default",
+				"network": "func:nextavailablenetwork:10.0.0.0/16,default,24",
+				"comment": "app tier",
+				"extattrs": {"Site": {"value": "east"}, "Network Name": {"value": "app"}},
+				"options": [{"name": "routers", "num": 3, "value": "10.0.0.1"}],
+				"members": [{"_struct": "dhcpmember", "name": "dhcp1.example.com", "ipv4addr": "10.1.0.5"}],
+				"high_water_mark": 90, "high_water_mark_reset": 80}`))
+		})
+	})
+})
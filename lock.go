@@ -156,7 +156,7 @@ func (l *NetworkViewLock) getLock() bool {
 		logrus.Debugf("Failed to create lock on network view %s: %s\n", l.Name, err)
 
 		//Check for Lock Timeout
-		nw, err := l.ObjMgr.GetNetworkView(l.Name)
+		nw, err := l.ObjMgr.withDefaultReturnFields().GetNetworkView(l.Name)
 		if err != nil {
 			logrus.Debugf("Failed to get the network view object for %s : %s\n", l.Name, err)
 			return false
@@ -186,7 +186,7 @@ func (l *NetworkViewLock) getLock() bool {
 func (l *NetworkViewLock) Lock() error {
 
 	// verify if network view exists and has EA for the lock
-	nw, err := l.ObjMgr.GetNetworkView(l.Name)
+	nw, err := l.ObjMgr.withDefaultReturnFields().GetNetworkView(l.Name)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to get the network view object for %s : %s\n", l.Name, err)
 		logrus.Debugf(msg)
@@ -0,0 +1,226 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiRequester is implemented by the connectors sending the request
+// object of WAPI, which applies several requests atomically
+type MultiRequester interface {
+	MultiRequest(req *MultiRequest) ([]byte, error)
+}
+
+// MultiRequest sends req through the request object of WAPI and returns
+// the results of its requests
+func (c *Connector) MultiRequest(req *MultiRequest) ([]byte, error) {
+	return c.makeRequest(CREATE, req, "", QueryParams{forceProxy: false})
+}
+
+// multiRequest sends req through the connector of objMgr, if it is a
+// MultiRequester
+func (objMgr *ObjectManager) multiRequest(req *MultiRequest) ([]byte, error) {
+	requester, ok := objMgr.connector.(MultiRequester)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T cannot send multi requests", ErrUnsupportedConnector, objMgr.connector)
+	}
+
+	return requester.MultiRequest(req)
+}
+
+// wapiConnector returns the connector of objMgr for the operations which
+// need a *Connector, e.g. to call WAPI functions
+func (objMgr *ObjectManager) wapiConnector() (*Connector, error) {
+	conn, ok := objMgr.connector.(*Connector)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a *Connector", ErrUnsupportedConnector, objMgr.connector)
+	}
+
+	return conn, nil
+}
+
+// multiOp is an operation added to a MultiRequestBuilder
+type multiOp struct {
+	method string
+	object string
+	res    interface{}
+}
+
+// MultiRequestBuilder builds a request of several operations which WAPI
+// applies atomically through the request object
+type MultiRequestBuilder struct {
+	body []*RequestBody
+	ops  []multiOp
+	err  error
+}
+
+// MultiResult is the result of an operation of a multi request
+type MultiResult struct {
+	Method string
+	// Ref is the ref of the created, updated or deleted object
+	Ref string
+}
+
+// MultiRequestError attributes the failure of a multi request to one of
+// its operations. Index is -1 when the failed operation cannot be told
+// from the WAPI error.
+type MultiRequestError struct {
+	Index  int
+	Method string
+	Object string
+	Err    error
+}
+
+func (e *MultiRequestError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("multi request failed: %s", e.Err)
+	}
+	return fmt.Sprintf("multi request operation %d (%s %s) failed: %s", e.Index, e.Method, e.Object, e.Err)
+}
+
+func (e *MultiRequestError) Unwrap() error {
+	return e.Err
+}
+
+func NewMultiRequestBuilder() *MultiRequestBuilder {
+	return &MultiRequestBuilder{}
+}
+
+// objectData returns the fields of obj as request data
+func objectData(obj IBObject) (map[string]interface{}, error) {
+	js, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	if err = json.Unmarshal(js, &data); err != nil {
+		return nil, err
+	}
+	delete(data, "_ref")
+
+	return data, nil
+}
+
+func (b *MultiRequestBuilder) add(method string, object string, obj IBObject, res interface{}) *MultiRequestBuilder {
+	body := &RequestBody{Method: method, Object: object}
+
+	if obj != nil {
+		data, err := objectData(obj)
+		if err != nil && b.err == nil {
+			b.err = &MultiRequestError{Index: len(b.ops), Method: method, Object: object, Err: err}
+		}
+		if method == "GET" {
+			for k, v := range obj.EaSearch() {
+				data["*"+k] = v
+			}
+			if len(obj.ReturnFields()) > 0 {
+				body.Args = map[string]string{"_return_fields": strings.Join(obj.ReturnFields(), ",")}
+			}
+		}
+		if len(data) > 0 {
+			body.Data = data
+		}
+	}
+
+	b.body = append(b.body, body)
+	b.ops = append(b.ops, multiOp{method: method, object: object, res: res})
+	return b
+}
+
+// AddCreate adds the creation of obj
+func (b *MultiRequestBuilder) AddCreate(obj IBObject) *MultiRequestBuilder {
+	return b.add("POST", obj.ObjectType(), obj, nil)
+}
+
+// AddUpdate adds the update of the object referenced by ref with the
+// fields of obj
+func (b *MultiRequestBuilder) AddUpdate(ref string, obj IBObject) *MultiRequestBuilder {
+	return b.add("PUT", ref, obj, nil)
+}
+
+// AddDelete adds the deletion of the object referenced by ref
+func (b *MultiRequestBuilder) AddDelete(ref string) *MultiRequestBuilder {
+	return b.add("DELETE", ref, nil, nil)
+}
+
+// AddRead adds a search for objects matching obj, or the read of the object
+// referenced by ref if not empty. The result is unmarshalled into res, a
+// pointer to a slice of the type of obj.
+func (b *MultiRequestBuilder) AddRead(obj IBObject, ref string, res interface{}) *MultiRequestBuilder {
+	object := obj.ObjectType()
+	if ref != "" {
+		object = ref
+	}
+	return b.add("GET", object, obj, res)
+}
+
+// Build returns the request object of the operations added
+func (b *MultiRequestBuilder) Build() (*MultiRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return NewMultiRequest(b.body), nil
+}
+
+// failedOp returns the index of the operation named by the WAPI error, or -1
+func (b *MultiRequestBuilder) failedOp(err error) int {
+	msg := err.Error()
+	for i, op := range b.ops {
+		if strings.Contains(op.object, "/") && strings.Contains(msg, op.object) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ExecuteMultiRequest applies the operations of b atomically. A result is
+// returned per operation, in the order they were added; reads are
+// unmarshalled into the result given to AddRead.
+func (objMgr *ObjectManager) ExecuteMultiRequest(b *MultiRequestBuilder) ([]MultiResult, error) {
+	req, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := objMgr.multiRequest(req)
+	if errors.Is(err, ErrUnsupportedConnector) {
+		return nil, err
+	}
+	if err != nil {
+		idx := b.failedOp(err)
+		mrErr := &MultiRequestError{Index: idx, Err: err}
+		if idx >= 0 {
+			mrErr.Method = b.ops[idx].method
+			mrErr.Object = b.ops[idx].object
+		}
+		return nil, mrErr
+	}
+
+	var raw []json.RawMessage
+	if err = json.Unmarshal(resp, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) != len(b.ops) {
+		return nil, fmt.Errorf("multi request returned %d results for %d operations", len(raw), len(b.ops))
+	}
+
+	results := make([]MultiResult, len(b.ops))
+	for i, op := range b.ops {
+		results[i].Method = op.method
+		if op.method == "GET" {
+			if op.res != nil {
+				err = json.Unmarshal(raw[i], op.res)
+			}
+		} else {
+			err = json.Unmarshal(raw[i], &results[i].Ref)
+		}
+		if err != nil {
+			return results, &MultiRequestError{Index: i, Method: op.method, Object: op.object, Err: err}
+		}
+	}
+
+	return results, nil
+}
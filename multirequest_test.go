@@ -0,0 +1,106 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type multiRequestor struct {
+	body []map[string]interface{}
+	res  []byte
+	err  error
+}
+
+func (hr *multiRequestor) Init(config TransportConfig) {}
+
+func (hr *multiRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	b, _ := ioutil.ReadAll(req.Body)
+	hr.body = nil
+	json.Unmarshal(b, &hr.body)
+
+	if hr.err != nil {
+		return nil, hr.err
+	}
+	return hr.res, nil
+}
+
+var _ = Describe("Multi Request Builder", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	It("should build the operations and return a typed result per operation", func() {
+		requestor := &multiRequestor{res: []byte(`[
+			"network/ZG5zLm5ldHdvcms:10.0.0.0/24/default",
+			"record:host/ZG5zLmhvc3Q:host1.example.com/default",
+			"record:a/ZG5zLmJpbmRfYQ:old.example.com/default",
+			[{"_ref": "networkview/ZG5zLm5ldHdvcmtfdmlldyQw:default/true", "name": "default"}]]`)}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		var netviews []NetworkView
+		b := NewMultiRequestBuilder().
+			AddCreate(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"})).
+			AddUpdate("record:host/ZG5zLmhvc3Q:host1.example.com/default", &HostRecord{
+				IBBase: IBBase{objectType: "record:host"}, Comment: "updated"}).
+			AddDelete("record:a/ZG5zLmJpbmRfYQ:old.example.com/default").
+			AddRead(NewNetworkView(NetworkView{Name: "default"}), "", &netviews)
+
+		results, err := objMgr.ExecuteMultiRequest(b)
+		Expect(err).To(BeNil())
+		Expect(results).To(Equal([]MultiResult{
+			{Method: "POST", Ref: "network/ZG5zLm5ldHdvcms:10.0.0.0/24/default"},
+			{Method: "PUT", Ref: "record:host/ZG5zLmhvc3Q:host1.example.com/default"},
+			{Method: "DELETE", Ref: "record:a/ZG5zLmJpbmRfYQ:old.example.com/default"},
+			{Method: "GET"},
+		}))
+		Expect(netviews).To(Equal([]NetworkView{{
+			Ref:  "networkview/ZG5zLm5ldHdvcmtfdmlldyQw:default/true",
+			Name: "default"}}))
+
+		Expect(requestor.body).To(Equal([]map[string]interface{}{
+			{"method": "POST", "object": "network",
+				"data": map[string]interface{}{"network_view": "default", "network": "10.0.0.0/24"}},
+			{"method": "PUT", "object": "record:host/ZG5zLmhvc3Q:host1.example.com/default",
+				"data": map[string]interface{}{"comment": "updated"}},
+			{"method": "DELETE", "object": "record:a/ZG5zLmJpbmRfYQ:old.example.com/default"},
+			{"method": "GET", "object": "networkview",
+				"data": map[string]interface{}{"name": "default"},
+				"args": map[string]interface{}{"_return_fields": "extattrs,name"}},
+		}))
+	})
+
+	It("should attribute a WAPI error to the operation it names", func() {
+		wapiErr := newWapiError(http.StatusNotFound, "Not Found",
+			[]byte(`{"Error": "AdmConDataNotFoundError: Reference record:a/ZG5zLmJpbmRfYQ:old.example.com/default not found"}`))
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: &multiRequestor{err: wapiErr}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		b := NewMultiRequestBuilder().
+			AddCreate(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"})).
+			AddDelete("record:a/ZG5zLmJpbmRfYQ:old.example.com/default")
+
+		results, err := objMgr.ExecuteMultiRequest(b)
+		Expect(results).To(BeNil())
+		mrErr, ok := err.(*MultiRequestError)
+		Expect(ok).To(BeTrue())
+		Expect(mrErr.Index).To(Equal(1))
+		Expect(mrErr.Method).To(Equal("DELETE"))
+		Expect(mrErr.Unwrap().(*NotFoundError).Is(ErrNotFound)).To(BeTrue())
+	})
+
+	It("should fail without panicking when the connector cannot send multi requests", func() {
+		objMgr := NewObjectManager(&fakeMultiConnector{}, cmpType, tenantID)
+
+		b := NewMultiRequestBuilder().AddDelete("record:a/ZG5zLmJpbmRfYQ:old.example.com/default")
+		_, err := objMgr.ExecuteMultiRequest(b)
+		Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+
+		_, err = objMgr.CreateMultiObject(NewMultiRequest([]*RequestBody{{Method: "GET", Object: "network"}}))
+		Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+	})
+})
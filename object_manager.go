@@ -1,10 +1,16 @@
 package ibclient
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"regexp"
+	"strings"
+	"sync"
+	"time"
 )
 
 type IBObjectManager interface {
@@ -13,34 +19,250 @@ type IBObjectManager interface {
 	CreateNetwork(netview string, cidr string, name string) (*Network, error)
 	CreateNetworkContainer(netview string, cidr string) (*NetworkContainer, error)
 	GetNetworkView(name string) (*NetworkView, error)
+	GetDefaultDNSView(netview string) (string, error)
+	OverlapCheck(netview string, cidr string) ([]string, error)
 	GetNetwork(netview string, cidr string, ea EA) (*Network, error)
 	GetNetworkContainer(netview string, cidr string) (*NetworkContainer, error)
+	GetAllNetworks(netview string, ea EA, pageSize int) ([]Network, error)
+	GetAllHostRecords(dnsview string, ea EA, pageSize int) ([]HostRecord, error)
+	SearchObjects(objType string, filters map[string]string, eaFilters EA, returnFields []string) ([]map[string]interface{}, error)
+	ListModifiedSince(objectType string, t time.Time) ([]map[string]interface{}, error)
+	ListChangedSinceSequence(objectType string, sequenceID string) ([]DBObject, string, error)
 	AllocateIP(netview string, cidr string, ipAddr string, macAddress string, name string, vmID string, vmName string) (*FixedAddress, error)
+	AllocateIPByClientID(netview string, cidr string, ipAddr string, clientID string, name string, vmID string, vmName string) (*FixedAddress, error)
 	AllocateNetwork(netview string, cidr string, prefixLen uint, name string) (network *Network, err error)
 	UpdateFixedAddress(fixedAddrRef string, matchclient string, macAddress string, vmID string, vmName string) (*FixedAddress, error)
+	UpdateFixedAddressClientID(fixedAddrRef string, clientID string, vmID string, vmName string) (*FixedAddress, error)
 	GetFixedAddress(netview string, cidr string, ipAddr string, macAddr string) (*FixedAddress, error)
 	GetFixedAddressByRef(ref string) (*FixedAddress, error)
+	GetFixedAddressByClientID(netview string, clientID string) (*FixedAddress, error)
 	DeleteFixedAddress(ref string) (string, error)
+	GetIPv4Address(netview string, ipAddr string) (*IPv4Address, error)
+	GetDiscoveredData(netview string, ipAddr string) (*DiscoveredData, error)
 	ReleaseIP(netview string, cidr string, ipAddr string, macAddr string) (string, error)
 	DeleteNetwork(ref string, netview string) (string, error)
+	DeleteNetworkWithPolicy(ref string, netview string, policy DeleteNetworkPolicy) (string, error)
 	GetEADefinition(name string) (*EADefinition, error)
 	CreateEADefinition(eadef EADefinition) (*EADefinition, error)
 	UpdateNetworkViewEA(ref string, addEA EA, removeEA EA) error
 	CreateHostRecord(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, vmID string, vmName string) (*HostRecord, error)
+	CreateHostRecordWithOptions(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, opts RecordOptions) (*HostRecord, error)
 	GetHostRecordByRef(ref string) (*HostRecord, error)
 	GetHostRecord(recordName string, netview string, cidr string, ipAddr string) (*HostRecord, error)
+	GetHostRecords(names []string, view string) (map[string]HostRecord, error)
+	GetHostRecordIpv4Addr(ipAddr string) (*HostRecordIpv4Addr, error)
 	GetIpAddressFromHostRecord(host HostRecord) (string, error)
 	UpdateHostRecord(hostRref string, ipAddr string, macAddress string, vmID string, vmName string) (string, error)
+	UpdateHostRecordWithOptions(hostRref string, ipAddr string, macAddress string, opts RecordOptions) (string, error)
+	RenameHostRecord(ref string, newName string) (*HostRecord, error)
+	CreateHostRecordWithAddresses(enabledns bool, recordName string, netview string, dnsview string, ipv4Addrs []HostRecordIpv4Addr, ipv6Addrs []HostRecordIpv6Addr, aliases []string, opts RecordOptions) (*HostRecord, error)
+	AddIPToHostRecord(ref string, ipAddr string, macOrDuid string, enableDhcp bool) (*HostRecord, error)
+	RemoveIPFromHostRecord(ref string, ipAddr string) (*HostRecord, error)
+	UpdateHostRecordAliases(ref string, aliases []string) (*HostRecord, error)
 	DeleteHostRecord(ref string) (string, error)
 	CreateARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordA, error)
+	CreateARecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordA, error)
 	GetARecordByRef(ref string) (*RecordA, error)
+	UpdateARecord(ref string, recordname string, ipAddr string, opts RecordOptions) (*RecordA, error)
 	DeleteARecord(ref string) (string, error)
 	CreateCNAMERecord(canonical string, recordname string, dnsview string) (*RecordCNAME, error)
+	CreateCNAMERecordWithOptions(canonical string, recordname string, dnsview string, opts RecordOptions) (*RecordCNAME, error)
 	GetCNAMERecordByRef(ref string) (*RecordA, error)
+	UpdateCNAMERecord(ref string, canonical string, recordname string, opts RecordOptions) (*RecordCNAME, error)
 	DeleteCNAMERecord(ref string) (string, error)
 	CreatePTRRecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordPTR, error)
+	CreatePTRRecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordPTR, error)
 	GetPTRRecordByRef(ref string) (*RecordPTR, error)
+	UpdatePTRRecord(ref string, ptrdname string, ipAddr string, opts RecordOptions) (*RecordPTR, error)
+	CreateTXTRecord(recordname string, text string, dnsview string, opts RecordOptions) (*RecordTXT, error)
+	GetTXTRecordByRef(ref string) (*RecordTXT, error)
+	UpdateTXTRecord(ref string, recordname string, text string, opts RecordOptions) (*RecordTXT, error)
+	DeleteTXTRecord(ref string) (string, error)
+	CreateMXRecord(recordname string, mailExchanger string, preference uint32, dnsview string, opts RecordOptions) (*RecordMX, error)
+	GetMXRecordByRef(ref string) (*RecordMX, error)
+	UpdateMXRecord(ref string, recordname string, mailExchanger string, preference *uint32, opts RecordOptions) (*RecordMX, error)
+	DeleteMXRecord(ref string) (string, error)
+	CreateSRVRecord(recordname string, target string, priority uint32, weight uint32, port uint32, dnsview string, opts RecordOptions) (*RecordSRV, error)
+	GetSRVRecordByRef(ref string) (*RecordSRV, error)
+	UpdateSRVRecord(ref string, recordname string, target string, priority *uint32, weight *uint32, port *uint32, opts RecordOptions) (*RecordSRV, error)
+	DeleteSRVRecord(ref string) (string, error)
+	CreateNSRecord(zone string, nameserver string, addresses []NsAddress, dnsview string) (*RecordNS, error)
+	GetNSRecordByRef(ref string) (*RecordNS, error)
+	UpdateNSRecord(ref string, nameserver string, addresses []NsAddress) (*RecordNS, error)
+	DeleteNSRecord(ref string) (string, error)
 	DeletePTRRecord(ref string) (string, error)
+	CreateIPv6Network(netview string, cidr string, name string) (*Network, error)
+	CreateIPv6NetworkContainer(netview string, cidr string) (*NetworkContainer, error)
+	GetIPv6Network(netview string, cidr string, ea EA) (*Network, error)
+	GetIPv6NetworkContainer(netview string, cidr string) (*NetworkContainer, error)
+	AllocateIPv6Network(netview string, cidr string, prefixLen uint, name string) (network *Network, err error)
+	AllocateIPv6(netview string, cidr string, ipAddr string, duid string, name string, vmID string, vmName string) (*FixedAddress, error)
+	GetIPv6FixedAddress(netview string, cidr string, ipAddr string, duid string) (*FixedAddress, error)
+	ReleaseIPv6(netview string, cidr string, ipAddr string, duid string) (string, error)
+	CreateAAAARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordAAAA, error)
+	CreateAAAARecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordAAAA, error)
+	GetAAAARecordByRef(ref string) (*RecordAAAA, error)
+	DeleteAAAARecord(ref string) (string, error)
+	CreateIPv6PTRRecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordPTR, error)
+	CreateIPv6PTRRecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordPTR, error)
+	CreateZoneAuth(za ZoneAuth) (*ZoneAuth, error)
+	GetZoneAuthByRef(ref string) (*ZoneAuth, error)
+	GetZoneAuthByFQDN(fqdn string, dnsview string) (*ZoneAuth, error)
+	UpdateZoneAuth(ref string, za ZoneAuth) (*ZoneAuth, error)
+	DeleteZoneAuth(ref string) (string, error)
+	CreateZoneForward(zf ZoneForward) (*ZoneForward, error)
+	GetZoneForwardByRef(ref string) (*ZoneForward, error)
+	GetZoneForwardByFQDN(fqdn string, dnsview string) (*ZoneForward, error)
+	UpdateZoneForward(ref string, zf ZoneForward) (*ZoneForward, error)
+	DeleteZoneForward(ref string) (string, error)
+	CreateZoneDelegated(zd ZoneDelegated) (*ZoneDelegated, error)
+	GetZoneDelegatedByRef(ref string) (*ZoneDelegated, error)
+	GetZoneDelegatedByFQDN(fqdn string, dnsview string) (*ZoneDelegated, error)
+	UpdateZoneDelegated(ref string, zd ZoneDelegated) (*ZoneDelegated, error)
+	DeleteZoneDelegated(ref string) (string, error)
+	BulkUpdateEA(refs []string, addEA EA, removeEA EA) ([]string, error)
+	ExecuteMultiRequest(b *MultiRequestBuilder) ([]MultiResult, error)
+	CreateRange(rng Range) (*Range, error)
+	GetRange(netview string, startAddr string, endAddr string) (*Range, error)
+	GetRangeByRef(ref string) (*Range, error)
+	UpdateRange(ref string, rng Range) (*Range, error)
+	DeleteRange(ref string) (string, error)
+	CreateIPv6Range(rng IPv6Range) (*IPv6Range, error)
+	GetIPv6RangeByRef(ref string) (*IPv6Range, error)
+	GetIPv6PrefixRanges(netview string, cidr string) ([]IPv6Range, error)
+	UpdateIPv6Range(ref string, rng IPv6Range) (*IPv6Range, error)
+	DeleteIPv6Range(ref string) (string, error)
+	UpdateIPv6NetworkOptions(ref string, options []DhcpOption) (*Network, error)
+	GetMemberAnycast(hostName string) (*Member, error)
+	UpdateMemberAnycast(ref string, addresses []MemberInterface, bgpAs []BgpAs, ospfList []Ospf) (*Member, error)
+	GetMemberDns(hostName string) (*MemberDns, error)
+	UpdateMemberDnsAdditionalIPs(ref string, addresses []string) (*MemberDns, error)
+	RestartServices(services []string, members []string, mode string) error
+	GetPendingRestartStatus() (*GridServiceRestartStatus, error)
+	CloneNetwork(srcRef string, newCidr string) (*Network, error)
+	CloneHostRecord(srcRef string, newName string, newIP string) (*HostRecord, error)
+	RenameRecords(dnsview string, matchRegex string, replaceTemplate string, dryRun bool) ([]RenameChange, error)
+	GetIPv4Addresses(netview string, cidr string, status string) ([]IPv4Address, error)
+	GetIPv6Addresses(netview string, cidr string, status string) ([]IPv6Address, error)
+	GetIPv6Address(netview string, ipAddr string) (*IPv6Address, error)
+	GetNextAvailableIPs(netview string, cidr string, num int, exclude []string) ([]string, error)
+	CSVImport(action string, path string) (*CSVImportTask, error)
+	GetCSVImportTask(ref string) (*CSVImportTask, error)
+	CSVExport(objType string, w io.Writer) error
+	DownloadGridBackup(w io.Writer) error
+	ReserveIP(netview string, cidr string, ipAddr string, macAddress string, name string, vmID string, vmName string, ttl time.Duration) (*FixedAddress, error)
+	ConfirmReservation(ref string) (string, error)
+	ReapExpiredReservations(netview string) ([]string, error)
+	GetGridTimeSettings() (*Grid, error)
+	CheckClockSkew(maxSkew time.Duration) (time.Duration, error)
+	GetNetworkContainerByRef(ref string) (*NetworkContainer, error)
+	AllocateNetworkFromContainer(containerRef string, prefixLen uint, name string) (*Network, error)
+	UpdateNetworkContainer(ref string, nc NetworkContainer) (*NetworkContainer, error)
+	GetNetworkContainerChildren(ref string, recursive bool) ([]Network, []NetworkContainer, error)
+	DeleteNetworkContainer(ref string, policy DeleteNetworkPolicy) (string, error)
+	SetDhcpOptions(ref string, options []DhcpOption) (string, error)
+	MergeDhcpOptions(ref string, options []DhcpOption) (string, error)
+	SetDhcpBootParameters(ref string, bootfile string, nextserver string, bootserver string) (string, error)
+	Resolve(objectType string, name string, view string) (string, error)
+	GetMembers(filter MemberFilter) ([]Member, error)
+	GetMember(hostName string) (*Member, error)
+	SetMemberDnsEnabled(hostName string, enable bool, restart bool) (*MemberDns, error)
+	SetMemberDhcpEnabled(hostName string, enable bool, restart bool) (*MemberDhcpProperties, error)
+	CreateDtcServer(ds DtcServer) (*DtcServer, error)
+	GetDtcServerByRef(ref string) (*DtcServer, error)
+	GetDtcServerByName(name string) (*DtcServer, error)
+	UpdateDtcServer(ref string, ds DtcServer) (*DtcServer, error)
+	DeleteDtcServer(ref string) (string, error)
+	CreateDtcPool(dp DtcPool) (*DtcPool, error)
+	GetDtcPoolByRef(ref string) (*DtcPool, error)
+	GetDtcPoolByName(name string) (*DtcPool, error)
+	UpdateDtcPool(ref string, dp DtcPool) (*DtcPool, error)
+	DeleteDtcPool(ref string) (string, error)
+	CreateDtcLbdn(dl DtcLbdn) (*DtcLbdn, error)
+	GetDtcLbdnByRef(ref string) (*DtcLbdn, error)
+	GetDtcLbdnByName(name string) (*DtcLbdn, error)
+	UpdateDtcLbdn(ref string, dl DtcLbdn) (*DtcLbdn, error)
+	DeleteDtcLbdn(ref string) (string, error)
+	CreateDtcMonitor(dm DtcMonitor) (*DtcMonitor, error)
+	GetDtcMonitorByRef(ref string) (*DtcMonitor, error)
+	GetDtcMonitorByName(monitorType string, name string) (*DtcMonitor, error)
+	UpdateDtcMonitor(ref string, dm DtcMonitor) (*DtcMonitor, error)
+	DeleteDtcMonitor(ref string) (string, error)
+	AddPoolToLbdn(lbdnRef string, poolRef string, ratio uint32) (*DtcLbdn, error)
+	RemovePoolFromLbdn(lbdnRef string, poolRef string) (*DtcLbdn, error)
+	AddMonitorToPool(poolRef string, monitorRef string) (*DtcPool, error)
+	RemoveMonitorFromPool(poolRef string, monitorRef string) (*DtcPool, error)
+	CreateView(v View) (*View, error)
+	GetViewByRef(ref string) (*View, error)
+	GetViewByName(name string) (*View, error)
+	UpdateView(ref string, v View) (*View, error)
+	DeleteView(ref string) (string, error)
+	CreateNSGroup(ns NSGroup) (*NSGroup, error)
+	GetNSGroupByRef(ref string) (*NSGroup, error)
+	GetNSGroupByName(name string) (*NSGroup, error)
+	UpdateNSGroup(ref string, ns NSGroup) (*NSGroup, error)
+	DeleteNSGroup(ref string) (string, error)
+	GetLeases(filter LeaseFilter) ([]Lease, error)
+	GetActiveLease(netview string, ipAddr string) (*Lease, error)
+	ClearLease(ref string) (string, error)
+	GetGridDnsClientSubnet() (*GridDns, error)
+	UpdateGridDnsClientSubnet(ref string, ecs DnsClientSubnet) (*GridDns, error)
+	GetMemberDnsClientSubnet(hostName string) (*MemberDns, error)
+	UpdateMemberDnsClientSubnet(ref string, ecs DnsClientSubnet) (*MemberDns, error)
+	GetMaxMindDBInfo() (*MaxMindDBInfo, error)
+	CreateNamedACL(na NamedACL) (*NamedACL, error)
+	GetNamedACLByName(name string) (*NamedACL, error)
+	UpdateNamedACL(ref string, na NamedACL) (*NamedACL, error)
+	DeleteNamedACL(ref string) (string, error)
+	ConditionalForwarder(domain string, dnsview string, forwarders []NameServer, members []string) (*ZoneForward, *NamedACL, error)
+	ListNetworkViews(ea EA) ([]NetworkViewSummary, error)
+	TransferOwnership(refs []string, newTenantEA EA) (*OwnershipTransfer, error)
+	ValidateEA(ea EA) error
+	GetEAInheritance(ref string) (EAInheritance, error)
+	CreateNetworkWithTemplate(netview string, cidr string, name string, template string) (*Network, error)
+	AllocateNetworkWithTemplate(netview string, cidr string, prefixLen uint, name string, template string) (network *Network, err error)
+	AllocateNetworkWithOptions(netview string, cidr string, prefixLen uint, name string, opts NetworkOptions) (network *Network, err error)
+	CreateNetworkTemplate(nt NetworkTemplate) (*NetworkTemplate, error)
+	GetNetworkTemplateByName(name string) (*NetworkTemplate, error)
+	UpdateNetworkTemplate(ref string, nt NetworkTemplate) (*NetworkTemplate, error)
+	DeleteNetworkTemplate(ref string) (string, error)
+	CreateRangeTemplate(rt RangeTemplate) (*RangeTemplate, error)
+	GetRangeTemplateByName(name string) (*RangeTemplate, error)
+	UpdateRangeTemplate(ref string, rt RangeTemplate) (*RangeTemplate, error)
+	DeleteRangeTemplate(ref string) (string, error)
+	ProvisionSubnet(containerRef string, prefixLen uint, template string, hosts []HostSpec) (*Network, []HostRecord, error)
+	RestoreQuarantined(ref string) (string, error)
+	PurgeQuarantined(olderThan time.Duration) ([]string, error)
+	CreateSharedRecordGroup(srg SharedRecordGroup) (*SharedRecordGroup, error)
+	GetSharedRecordGroupByName(name string) (*SharedRecordGroup, error)
+	UpdateSharedRecordGroup(ref string, srg SharedRecordGroup) (*SharedRecordGroup, error)
+	DeleteSharedRecordGroup(ref string) (string, error)
+	AssociateSharedRecordGroup(ref string, zones []ZoneAssociation) (*SharedRecordGroup, error)
+	DissociateSharedRecordGroup(ref string, zones []ZoneAssociation) (*SharedRecordGroup, error)
+	CreateSharedRecordA(sr SharedRecordA) (*SharedRecordA, error)
+	GetSharedRecordsA(group string) ([]SharedRecordA, error)
+	UpdateSharedRecordA(ref string, sr SharedRecordA) (*SharedRecordA, error)
+	DeleteSharedRecordA(ref string) (string, error)
+	CreateSharedRecordAAAA(sr SharedRecordAAAA) (*SharedRecordAAAA, error)
+	GetSharedRecordsAAAA(group string) ([]SharedRecordAAAA, error)
+	UpdateSharedRecordAAAA(ref string, sr SharedRecordAAAA) (*SharedRecordAAAA, error)
+	DeleteSharedRecordAAAA(ref string) (string, error)
+	CreateSharedRecordCNAME(sr SharedRecordCNAME) (*SharedRecordCNAME, error)
+	GetSharedRecordsCNAME(group string) ([]SharedRecordCNAME, error)
+	UpdateSharedRecordCNAME(ref string, sr SharedRecordCNAME) (*SharedRecordCNAME, error)
+	DeleteSharedRecordCNAME(ref string) (string, error)
+	LookupByName(fqdn string, view string) (*LookupAnswer, error)
+	LookupByIP(ip string, netview string) (*LookupAnswer, error)
+	ClearMemberDnsCache(hostName string, domain string, view string, fullTree bool) error
+	ClearRecursiveDnsCaches(domain string, view string, fullTree bool) ([]string, error)
+	EnsureNetwork(netview string, cidr string, name string, template string) (*Network, bool, error)
+	EnsureHostRecord(enabledns bool, recordName string, netview string, dnsview string, ipv4Addrs []HostRecordIpv4Addr, ipv6Addrs []HostRecordIpv6Addr, aliases []string, opts RecordOptions) (*HostRecord, bool, error)
+	EnsureARecord(dnsview string, recordname string, ipAddr string, opts RecordOptions) (*RecordA, bool, error)
+	EnsureCNAMERecord(canonical string, recordname string, dnsview string, opts RecordOptions) (*RecordCNAME, bool, error)
+	Import(state *ImportState) (*ImportReport, error)
+	GetCompatibility() (*Compatibility, error)
+	GetCACertificates() ([]CACertificate, error)
+	GetCertificateExpiries() ([]CertificateExpiry, error)
 }
 
 type ObjectManager struct {
@@ -49,6 +271,52 @@ type ObjectManager struct {
 	tenantID  string
 	// If OmitCloudAttrs is true no extra attributes for cloud are set
 	OmitCloudAttrs bool
+	// If CheckNetworkOverlap is true CreateNetwork and CreateIPv6Network
+	// return an *OverlapError instead of creating overlapping networks
+	CheckNetworkOverlap bool
+	// If CheckRecordConflicts is true the creation of a CNAME record
+	// returns a *RecordConflictError when A, AAAA, TXT or host records
+	// have its name, and the creation of those records when a CNAME record
+	// has their name
+	CheckRecordConflicts bool
+	// dnsViews and resolved are shared with the copies made by WithContext
+	dnsViews *dnsViewCache
+	resolved *refCache
+	// If CheckEADefinitions is true the EAs of the objects created and
+	// updated are checked by ValidateEA, and an *InvalidEAError is
+	// returned instead of sending invalid EAs
+	CheckEADefinitions bool
+	// eaDefs caches the EA definitions, it is shared with the copies made
+	// by WithContext
+	eaDefs *eaDefCache
+	// AllocationRetryPolicy retries the creations allocating the next
+	// available address or network which conflict with a concurrent
+	// allocation, e.g. by AllocateIP or AllocateNetwork. Allocations are
+	// sent once when MaxAttempts is lower than 2, RetryOn defaults to
+	// matching ErrConflict.
+	AllocationRetryPolicy RetryPolicy
+	// MaxRequestBodySize is the size in bytes of the requests sent by the
+	// bulk operations, DefaultMaxRequestBodySize if not positive
+	MaxRequestBodySize int
+	// If Quarantine is true the Delete methods disable the objects which
+	// can be disabled and stamp them with the QuarantineDeletedAtEA EA
+	// instead of deleting them, PurgeQuarantined deletes them once the
+	// recovery window has passed
+	Quarantine bool
+	// idempotencyKey is stamped on the objects created, see
+	// WithIdempotencyKey
+	idempotencyKey string
+	// returnFields are requested by the Get methods instead of, or with
+	// returnFieldsPlus in addition to, their default fields, see
+	// WithReturnFields
+	returnFields     []string
+	returnFieldsPlus bool
+}
+
+// dnsViewCache maps network view names to their default DNS view
+type dnsViewCache struct {
+	mu    sync.Mutex
+	views map[string]string
 }
 
 func NewObjectManager(connector IBConnector, cmpType string, tenantID string) *ObjectManager {
@@ -58,6 +326,9 @@ func NewObjectManager(connector IBConnector, cmpType string, tenantID string) *O
 	objMgr.cmpType = cmpType
 	objMgr.tenantID = tenantID
 	objMgr.OmitCloudAttrs = true
+	objMgr.dnsViews = &dnsViewCache{views: make(map[string]string)}
+	objMgr.resolved = &refCache{refs: make(map[refCacheKey]string)}
+	objMgr.eaDefs = &eaDefCache{defs: make(map[string]*EADefinition)}
 
 	return objMgr
 }
@@ -66,7 +337,36 @@ func NewLocalObjectManager(connector IBConnector) *ObjectManager {
 	return &ObjectManager{
 		connector:      connector,
 		OmitCloudAttrs: true,
+		dnsViews:       &dnsViewCache{views: make(map[string]string)},
+		resolved:       &refCache{refs: make(map[refCacheKey]string)},
+	}
+}
+
+// WithContext returns a copy of the ObjectManager whose requests are
+// bound to ctx. The context is ignored if the connector does not
+// implement IBContextConnector.
+func (objMgr *ObjectManager) WithContext(ctx context.Context) *ObjectManager {
+	res := *objMgr
+	if conn, ok := objMgr.connector.(IBContextConnector); ok {
+		res.connector = conn.WithContext(ctx)
 	}
+
+	return &res
+}
+
+// WithTimeout returns a copy of the ObjectManager whose requests must
+// complete within timeout, instead of the HttpRequestTimeout of the
+// transport, and the function releasing its context. The timeout can be
+// shorter or longer than HttpRequestTimeout, e.g. for long exports, but
+// not longer than a deadline of the context the ObjectManager is bound to.
+func (objMgr *ObjectManager) WithTimeout(timeout time.Duration) (*ObjectManager, context.CancelFunc) {
+	parent := context.Background()
+	if conn, ok := objMgr.connector.(*Connector); ok {
+		parent = conn.Context()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+
+	return objMgr.WithContext(ctx), cancel
 }
 
 func (objMgr *ObjectManager) getBasicEA(cloudAPIOwned Bool) EA {
@@ -98,7 +398,7 @@ func (objMgr *ObjectManager) CreateNetworkView(name string) (*NetworkView, error
 		Name: name,
 		Ea:   objMgr.getBasicEA(false)})
 
-	ref, err := objMgr.connector.CreateObject(networkView)
+	ref, err := objMgr.createObject(networkView)
 	networkView.Ref = ref
 
 	return networkView, err
@@ -106,7 +406,7 @@ func (objMgr *ObjectManager) CreateNetworkView(name string) (*NetworkView, error
 
 func (objMgr *ObjectManager) makeNetworkView(netviewName string) (netviewRef string, err error) {
 	var netviewObj *NetworkView
-	if netviewObj, err = objMgr.GetNetworkView(netviewName); err != nil {
+	if netviewObj, err = objMgr.withDefaultReturnFields().GetNetworkView(netviewName); err != nil {
 		return
 	}
 	if netviewObj == nil {
@@ -133,15 +433,29 @@ func (objMgr *ObjectManager) CreateDefaultNetviews(globalNetview string, localNe
 }
 
 func (objMgr *ObjectManager) CreateNetwork(netview string, cidr string, name string) (*Network, error) {
+	return objMgr.CreateNetworkWithTemplate(netview, cidr, name, "")
+}
+
+// CreateNetworkWithTemplate creates the network cidr from the network
+// template named template, if not empty, so the network gets the options,
+// ranges, fixed addresses and EAs of the template
+func (objMgr *ObjectManager) CreateNetworkWithTemplate(netview string, cidr string, name string, template string) (*Network, error) {
+	if objMgr.CheckNetworkOverlap {
+		if err := objMgr.checkNetworkOverlap(netview, cidr); err != nil {
+			return nil, err
+		}
+	}
+
 	network := NewNetwork(Network{
 		NetviewName: netview,
 		Cidr:        cidr,
+		Template:    template,
 		Ea:          objMgr.getBasicEA(true)})
 
 	if name != "" {
 		network.Ea["Network Name"] = name
 	}
-	ref, err := objMgr.connector.CreateObject(network)
+	ref, err := objMgr.createObject(network)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +470,7 @@ func (objMgr *ObjectManager) CreateNetworkContainer(netview string, cidr string)
 		Cidr:        cidr,
 		Ea:          objMgr.getBasicEA(true)})
 
-	ref, err := objMgr.connector.CreateObject(container)
+	ref, err := objMgr.createObject(container)
 	container.Ref = ref
 
 	return container, err
@@ -167,7 +481,7 @@ func (objMgr *ObjectManager) GetNetworkView(name string) (*NetworkView, error) {
 
 	netview := NewNetworkView(NetworkView{Name: name})
 
-	err := objMgr.connector.GetObject(netview, "", &res)
+	err := objMgr.getObject(netview, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -176,12 +490,63 @@ func (objMgr *ObjectManager) GetNetworkView(name string) (*NetworkView, error) {
 	return &res[0], nil
 }
 
+// GetDefaultDNSView returns the DNS view associated with the network view:
+// "default" for the default network view and "default.<netview>" for the
+// others. The result is cached for the lifetime of the ObjectManager.
+func (objMgr *ObjectManager) GetDefaultDNSView(netview string) (string, error) {
+	if objMgr.dnsViews != nil {
+		objMgr.dnsViews.mu.Lock()
+		view, ok := objMgr.dnsViews.views[netview]
+		objMgr.dnsViews.mu.Unlock()
+		if ok {
+			return view, nil
+		}
+	}
+
+	var res []NetworkView
+	nv := NewNetworkView(NetworkView{Name: netview})
+	nv.returnFields = []string{"associated_dns_views", "name"}
+	err := objMgr.readObject(nv, "", &res)
+	if err != nil {
+		return "", err
+	}
+	if len(res) == 0 {
+		return "", fmt.Errorf("network view '%s' not found", netview)
+	}
+
+	view := ""
+	expected := "default." + netview
+	if netview == "default" {
+		expected = "default"
+	}
+	for _, v := range res[0].AssociatedDnsViews {
+		if v == expected {
+			view = v
+			break
+		}
+	}
+	if view == "" {
+		if len(res[0].AssociatedDnsViews) == 0 {
+			return "", fmt.Errorf("network view '%s' has no associated DNS view", netview)
+		}
+		view = res[0].AssociatedDnsViews[0]
+	}
+
+	if objMgr.dnsViews != nil {
+		objMgr.dnsViews.mu.Lock()
+		objMgr.dnsViews.views[netview] = view
+		objMgr.dnsViews.mu.Unlock()
+	}
+
+	return view, nil
+}
+
 func (objMgr *ObjectManager) UpdateNetworkViewEA(ref string, addEA EA, removeEA EA) error {
 	var res NetworkView
 
 	nv := NetworkView{}
 	nv.returnFields = []string{"extattrs"}
-	err := objMgr.connector.GetObject(&nv, ref, &res)
+	err := objMgr.readObject(&nv, ref, &res)
 
 	if err != nil {
 		return err
@@ -198,7 +563,7 @@ func (objMgr *ObjectManager) UpdateNetworkViewEA(ref string, addEA EA, removeEA
 		}
 	}
 
-	_, err = objMgr.connector.UpdateObject(&res, ref)
+	_, err = objMgr.updateObject(&res, ref)
 	return err
 }
 
@@ -223,7 +588,7 @@ func BuildNetworkFromRef(ref string) *Network {
 	m := r.FindStringSubmatch(ref)
 
 	if m == nil {
-		return nil
+		return BuildIPv6NetworkFromRef(ref)
 	}
 
 	return &Network{
@@ -233,6 +598,27 @@ func BuildNetworkFromRef(ref string) *Network {
 	}
 }
 
+func BuildIPv6NetworkFromRef(ref string) *Network {
+	// ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi82NC8w:2001%3Adb8%3A%3A/64/global_view
+	r := regexp.MustCompile(`ipv6network/\w+:([0-9a-fA-F%:.]+/\d+)/(.+)`)
+	m := r.FindStringSubmatch(ref)
+
+	if m == nil {
+		return nil
+	}
+
+	cidr, err := url.PathUnescape(m[1])
+	if err != nil {
+		return nil
+	}
+
+	return &Network{
+		Ref:         ref,
+		NetviewName: m[2],
+		Cidr:        cidr,
+	}
+}
+
 func (objMgr *ObjectManager) GetNetwork(netview string, cidr string, ea EA) (*Network, error) {
 	var res []Network
 
@@ -247,7 +633,7 @@ func (objMgr *ObjectManager) GetNetwork(netview string, cidr string, ea EA) (*Ne
 		network.eaSearch = EASearch(ea)
 	}
 
-	err := objMgr.connector.GetObject(network, "", &res)
+	err := objMgr.getObject(network, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -256,9 +642,129 @@ func (objMgr *ObjectManager) GetNetwork(netview string, cidr string, ea EA) (*Ne
 	return &res[0], nil
 }
 
+// getObjectPaged fetches all matching objects page by page if the connector
+// supports paging, otherwise with a single request
+func (objMgr *ObjectManager) getObjectPaged(obj IBObject, pageSize int, res interface{}) error {
+	objMgr.applyReturnFields(obj)
+	return objMgr.readObjectPaged(obj, pageSize, res)
+}
+
+// readObjectPaged gets the objects matching obj page by page, as
+// getObjectPaged, with the own return fields of obj as readObject
+func (objMgr *ObjectManager) readObjectPaged(obj IBObject, pageSize int, res interface{}) error {
+	if conn, ok := objMgr.connector.(IBPagingConnector); ok {
+		return conn.GetObjectPaged(obj, pageSize, res)
+	}
+	return objMgr.readObject(obj, "", res)
+}
+
+// SearchObjects returns the objects of objType matching filters and
+// eaFilters. Filter names may carry WAPI search modifiers such as "name~"
+// for regular expressions. returnFields are fetched in addition to the
+// default fields of objType.
+func (objMgr *ObjectManager) SearchObjects(objType string, filters map[string]string, eaFilters EA, returnFields []string) ([]map[string]interface{}, error) {
+	var res []map[string]interface{}
+
+	var searchFilters map[string]interface{}
+	if filters != nil {
+		searchFilters = make(map[string]interface{})
+		for k, v := range filters {
+			searchFilters[k] = v
+		}
+	}
+
+	obj := NewSearchObject(objType, searchFilters, eaFilters, returnFields)
+	err := objMgr.readObjectPaged(obj, DefaultPageSize, &res)
+
+	return res, err
+}
+
+// timestampSearchFields are the object types searchable by timestamp, with
+// the timestamp field searched
+var timestampSearchFields = map[string]string{
+	"record:a":     "creation_time",
+	"record:aaaa":  "creation_time",
+	"record:cname": "creation_time",
+	"record:ptr":   "creation_time",
+	"record:txt":   "creation_time",
+}
+
+// ListModifiedSince returns the objects of objectType changed after t. WAPI
+// only keeps the creation time of DNS records, so these are listed when
+// they were created after t. Other object types return an error.
+// ListChangedSinceSequence lists the objects modified too.
+func (objMgr *ObjectManager) ListModifiedSince(objectType string, t time.Time) ([]map[string]interface{}, error) {
+	var res []map[string]interface{}
+
+	field, ok := timestampSearchFields[objectType]
+	if !ok {
+		return nil, fmt.Errorf("object type '%s' cannot be searched by timestamp", objectType)
+	}
+
+	obj := NewSearchObject(objectType, map[string]interface{}{field + ">": t.Unix()}, nil, []string{field})
+	err := objMgr.readObjectPaged(obj, DefaultPageSize, &res)
+
+	return res, err
+}
+
+// ListChangedSinceSequence returns the objects of objectType, or of all the
+// types if objectType is empty, created or modified after the database
+// sequence id sequenceID, or all of them if sequenceID is empty, and the
+// sequence id to pass to the next call. Deleted objects are not listed.
+func (objMgr *ObjectManager) ListChangedSinceSequence(objectType string, sequenceID string) ([]DBObject, string, error) {
+	var res []DBObject
+
+	filters := map[string]interface{}{}
+	if objectType != "" {
+		filters["object_types"] = objectType
+	}
+	if sequenceID != "" {
+		filters["start_sequence_id"] = sequenceID
+	}
+
+	obj := NewSearchObject("db_objects", filters, nil, []string{"last_sequence_id", "object", "object_type", "unique_id"})
+	if err := objMgr.readObject(obj, "", &res); err != nil {
+		return nil, sequenceID, err
+	}
+
+	last := sequenceID
+	if len(res) > 0 {
+		last = res[len(res)-1].LastSequenceID
+	}
+	return res, last, nil
+}
+
+// GetAllNetworks returns all networks of the network view matching ea,
+// fetched in pages of pageSize networks
+func (objMgr *ObjectManager) GetAllNetworks(netview string, ea EA, pageSize int) ([]Network, error) {
+	var res []Network
+
+	network := NewNetwork(Network{NetviewName: netview})
+	if ea != nil && len(ea) > 0 {
+		network.eaSearch = EASearch(ea)
+	}
+
+	err := objMgr.getObjectPaged(network, pageSize, &res)
+	return res, err
+}
+
+// GetAllHostRecords returns all host records of the DNS view matching ea,
+// fetched in pages of pageSize records
+func (objMgr *ObjectManager) GetAllHostRecords(dnsview string, ea EA, pageSize int) ([]HostRecord, error) {
+	var res []HostRecord
+
+	recordHost := NewHostRecord(HostRecord{View: dnsview})
+	if ea != nil && len(ea) > 0 {
+		recordHost.eaSearch = EASearch(ea)
+	}
+
+	err := objMgr.getObjectPaged(recordHost, pageSize, &res)
+	return res, err
+}
+
 func (objMgr *ObjectManager) GetNetworkwithref(ref string) (*Network, error) {
 	network := NewNetwork(Network{})
-	err := objMgr.connector.GetObject(network, ref, &network)
+	err := objMgr.getObject(network, ref, &network)
 	return network, err
 }
 
@@ -269,7 +775,7 @@ func (objMgr *ObjectManager) GetNetworkContainer(netview string, cidr string) (*
 		NetviewName: netview,
 		Cidr:        cidr})
 
-	err := objMgr.connector.GetObject(nwcontainer, "", &res)
+	err := objMgr.getObject(nwcontainer, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -286,15 +792,30 @@ func GetIPAddressFromRef(ref string) string {
 	if m != nil {
 		return m[1]
 	}
+
+	// ipv6fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:2001%3Adb8%3A%3A10/external
+	r = regexp.MustCompile(`ipv6fixedaddress/\w+:([0-9a-fA-F%:.]+)/.+`)
+	m = r.FindStringSubmatch(ref)
+
+	if m != nil {
+		if ip, err := url.PathUnescape(m[1]); err == nil {
+			return ip
+		}
+	}
 	return ""
 }
 
 func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr string, macAddress string, name string, vmID string, vmName string) (*FixedAddress, error) {
+	return objMgr.allocateFixedAddress(netview, cidr, ipAddr, macAddress, name, objMgr.getBasicVMEA(true, vmID, vmName))
+}
+
+// allocateFixedAddress creates the fixed address of ipAddr, or of the next
+// available address of cidr if ipAddr is empty, with the EAs ea
+func (objMgr *ObjectManager) allocateFixedAddress(netview string, cidr string, ipAddr string, macAddress string, name string, ea EA) (*FixedAddress, error) {
 	if len(macAddress) == 0 {
 		macAddress = MACADDR_ZERO
 	}
 
-	ea := objMgr.getBasicVMEA(true, vmID, vmName)
 	fixedAddr := NewFixedAddress(FixedAddress{
 		NetviewName: netview,
 		Cidr:        cidr,
@@ -308,7 +829,37 @@ func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr stri
 		fixedAddr.IPAddress = ipAddr
 	}
 
-	ref, err := objMgr.connector.CreateObject(fixedAddr)
+	ref, err := objMgr.createObject(fixedAddr)
+	fixedAddr.Ref = ref
+	fixedAddr.IPAddress = GetIPAddressFromRef(ref)
+
+	return fixedAddr, err
+}
+
+// AllocateIPByClientID reserves ipAddr, or the next available address of
+// cidr if ipAddr is empty, for the DHCP client identifier clientID rather
+// than a MAC address
+func (objMgr *ObjectManager) AllocateIPByClientID(netview string, cidr string, ipAddr string, clientID string, name string, vmID string, vmName string) (*FixedAddress, error) {
+	if clientID == "" {
+		return nil, errors.New("client identifier is required to match client CLIENT_ID")
+	}
+
+	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+	fixedAddr := NewFixedAddress(FixedAddress{
+		NetviewName: netview,
+		Cidr:        cidr,
+		MatchClient: string(MatchClientClientID),
+		ClientID:    clientID,
+		Name:        name,
+		Ea:          ea})
+
+	if ipAddr == "" {
+		fixedAddr.IPAddress = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		fixedAddr.IPAddress = ipAddr
+	}
+
+	ref, err := objMgr.createObject(fixedAddr)
 	fixedAddr.Ref = ref
 	fixedAddr.IPAddress = GetIPAddressFromRef(ref)
 
@@ -316,18 +867,80 @@ func (objMgr *ObjectManager) AllocateIP(netview string, cidr string, ipAddr stri
 }
 
 func (objMgr *ObjectManager) AllocateNetwork(netview string, cidr string, prefixLen uint, name string) (network *Network, err error) {
+	return objMgr.AllocateNetworkWithOptions(netview, cidr, prefixLen, name, NetworkOptions{})
+}
+
+// AllocateNetworkWithTemplate allocates the next available network of
+// prefix length prefixLen in cidr, created from the network template named
+// template if not empty
+func (objMgr *ObjectManager) AllocateNetworkWithTemplate(netview string, cidr string, prefixLen uint, name string, template string) (network *Network, err error) {
+	return objMgr.AllocateNetworkWithOptions(netview, cidr, prefixLen, name, NetworkOptions{Template: template})
+}
+
+// NetworkOptions are the attributes an allocated network is created with,
+// besides its name
+type NetworkOptions struct {
+	Template string
+	Comment  string
+	// Ea is added to the EAs of the tenant
+	Ea      EA
+	Options []DhcpOption
+	// Members are the grid members serving DHCP for the network, their
+	// Struct defaults to "dhcpmember"
+	Members []DhcpMember
+	// The utilization thresholds of the network, in percent, the grid
+	// defaults when zero
+	HighWaterMark      uint32
+	HighWaterMarkReset uint32
+	LowWaterMark       uint32
+	LowWaterMarkReset  uint32
+}
+
+// AllocateNetworkWithOptions allocates the next available network of
+// prefix length prefixLen in cidr, created with the comment, EAs, DHCP
+// options, members and utilization thresholds of opts in the same request
+func (objMgr *ObjectManager) AllocateNetworkWithOptions(netview string, cidr string, prefixLen uint, name string, opts NetworkOptions) (network *Network, err error) {
 	network = nil
 
-	networkReq := NewNetwork(Network{
-		NetviewName: netview,
-		Cidr:        fmt.Sprintf("func:nextavailablenetwork:%s,%s,%d", cidr, netview, prefixLen),
-		Ea:          objMgr.getBasicEA(true)})
+	if err = validatePrefixLen(cidr, prefixLen); err != nil {
+		return
+	}
+
+	ea := objMgr.getBasicEA(true)
+	for k, v := range opts.Ea {
+		ea[k] = v
+	}
 	if name != "" {
-		networkReq.Ea["Network Name"] = name
+		ea["Network Name"] = name
 	}
 
-	ref, err := objMgr.connector.CreateObject(networkReq)
-	if err == nil && len(ref) > 0 {
+	var members []DhcpMember
+	for _, m := range opts.Members {
+		if m.Struct == "" {
+			m.Struct = "dhcpmember"
+		}
+		members = append(members, m)
+	}
+
+	networkReq := NewNetwork(Network{
+		NetviewName:        netview,
+		Cidr:               fmt.Sprintf("func:nextavailablenetwork:%s,%s,%d", cidr, netview, prefixLen),
+		Template:           opts.Template,
+		Comment:            opts.Comment,
+		Options:            opts.Options,
+		Members:            members,
+		HighWaterMark:      opts.HighWaterMark,
+		HighWaterMarkReset: opts.HighWaterMarkReset,
+		LowWaterMark:       opts.LowWaterMark,
+		LowWaterMarkReset:  opts.LowWaterMarkReset,
+		Ea:                 ea})
+
+	ref, err := objMgr.createObject(networkReq)
+	if err != nil {
+		err = objMgr.allocationError(netview, cidr, prefixLen, err)
+		return
+	}
+	if len(ref) > 0 {
 		network = BuildNetworkFromRef(ref)
 	}
 
@@ -346,7 +959,26 @@ func (objMgr *ObjectManager) GetFixedAddress(netview string, cidr string, ipAddr
 		fixedAddr.Mac = macAddr
 	}
 
-	err := objMgr.connector.GetObject(fixedAddr, "", &res)
+	err := objMgr.getObject(fixedAddr, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// GetFixedAddressByClientID returns the fixed address reserved for the
+// DHCP client identifier clientID in the network view
+func (objMgr *ObjectManager) GetFixedAddressByClientID(netview string, clientID string) (*FixedAddress, error) {
+	var res []FixedAddress
+
+	fixedAddr := NewFixedAddress(FixedAddress{
+		NetviewName: netview,
+		ClientID:    clientID})
+	fixedAddr.returnFields = append(fixedAddr.returnFields, "dhcp_client_identifier", "match_client")
+
+	err := objMgr.getObject(fixedAddr, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -357,24 +989,42 @@ func (objMgr *ObjectManager) GetFixedAddress(netview string, cidr string, ipAddr
 
 func (objMgr *ObjectManager) GetFixedAddressByRef(ref string) (*FixedAddress, error) {
 	fixedAddr := NewFixedAddress(FixedAddress{})
-	err := objMgr.connector.GetObject(fixedAddr, ref, &fixedAddr)
+	fixedAddr.returnFields = append(fixedAddr.returnFields, "dhcp_client_identifier", "match_client")
+	err := objMgr.getObject(fixedAddr, ref, &fixedAddr)
 	return fixedAddr, err
 }
 
 func (objMgr *ObjectManager) DeleteFixedAddress(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.deleteObject(ref)
 }
 
-// validation  for match_client
-func validateMatchClient(value string) bool {
-	match_client := [5]string{"MAC_ADDRESS", "CLIENT_ID", "RESERVED", "CIRCUIT_ID", "REMOTE_ID"}
+// GetIPv4Address returns the IPAM status of ipAddr in the network view
+// along with its discovered data
+func (objMgr *ObjectManager) GetIPv4Address(netview string, ipAddr string) (*IPv4Address, error) {
+	var res []IPv4Address
 
-	for _, val := range match_client {
-		if val == value {
-			return true
-		}
+	addr := NewIPv4Address(IPv4Address{
+		NetviewName: netview,
+		IPAddress:   ipAddr})
+
+	err := objMgr.getObject(addr, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
 	}
-	return false
+
+	return &res[0], nil
+}
+
+// GetDiscoveredData returns the discovered data of ipAddr in the network
+// view, nil is returned if nothing has been discovered for the address
+func (objMgr *ObjectManager) GetDiscoveredData(netview string, ipAddr string) (*DiscoveredData, error) {
+	addr, err := objMgr.withDefaultReturnFields().GetIPv4Address(netview, ipAddr)
+	if err != nil || addr == nil {
+		return nil, err
+	}
+
+	return addr.DiscoveredData, nil
 }
 
 func (objMgr *ObjectManager) UpdateFixedAddress(fixedAddrRef string, matchClient string, macAddress string, vmID string, vmName string) (*FixedAddress, error) {
@@ -389,41 +1039,168 @@ func (objMgr *ObjectManager) UpdateFixedAddress(fixedAddrRef string, matchClient
 	updateFixedAddr.Ea = ea
 
 	if matchClient != "" {
-		if validateMatchClient(matchClient) {
+		if MatchClient(matchClient).IsValid() {
 			updateFixedAddr.MatchClient = matchClient
 		} else {
 			return nil, fmt.Errorf("wrong value for match_client passed %s \n ", matchClient)
 		}
 	}
 
-	refResp, err := objMgr.connector.UpdateObject(updateFixedAddr, fixedAddrRef)
+	refResp, err := objMgr.updateObject(updateFixedAddr, fixedAddrRef)
+	updateFixedAddr.Ref = refResp
+	return updateFixedAddr, err
+}
+
+// UpdateFixedAddressClientID makes the fixed address referenced by
+// fixedAddrRef match the DHCP client identifier clientID instead of its
+// MAC address
+func (objMgr *ObjectManager) UpdateFixedAddressClientID(fixedAddrRef string, clientID string, vmID string, vmName string) (*FixedAddress, error) {
+	if clientID == "" {
+		return nil, errors.New("client identifier is required to match client CLIENT_ID")
+	}
+
+	updateFixedAddr := NewFixedAddress(FixedAddress{
+		MatchClient: string(MatchClientClientID),
+		ClientID:    clientID,
+		Ea:          objMgr.getBasicVMEA(true, vmID, vmName)})
+
+	refResp, err := objMgr.updateObject(updateFixedAddr, fixedAddrRef)
 	updateFixedAddr.Ref = refResp
 	return updateFixedAddr, err
 }
 
 func (objMgr *ObjectManager) ReleaseIP(netview string, cidr string, ipAddr string, macAddr string) (string, error) {
-	fixAddress, _ := objMgr.GetFixedAddress(netview, cidr, ipAddr, macAddr)
+	fixAddress, _ := objMgr.withDefaultReturnFields().GetFixedAddress(netview, cidr, ipAddr, macAddr)
 	if fixAddress == nil {
 		return "", nil
 	}
-	return objMgr.connector.DeleteObject(fixAddress.Ref)
+	return objMgr.deleteObject(fixAddress.Ref)
 }
 
 func (objMgr *ObjectManager) DeleteNetwork(ref string, netview string) (string, error) {
 	network := BuildNetworkFromRef(ref)
 	if network != nil && network.NetviewName == netview {
-		return objMgr.connector.DeleteObject(ref)
+		return objMgr.deleteObject(ref)
 	}
 
 	return "", nil
 }
 
+type DeleteNetworkPolicy int
+
+const (
+	// DeleteNetworkIfEmpty refuses to delete a network with child objects
+	DeleteNetworkIfEmpty DeleteNetworkPolicy = iota
+	// DeleteNetworkCascade deletes the child objects before the network
+	DeleteNetworkCascade
+	// DeleteNetworkReparent moves the child networks and containers of a
+	// network container to its parent container. The fixed addresses,
+	// host records and ranges of a network cannot be moved out of it, so
+	// networks cannot be deleted with this policy.
+	DeleteNetworkReparent
+)
+
+// network children which are removed along with the network by NIOS
+var networkChildTypes = []string{"fixedaddress", "ipv6fixedaddress", "record:host", "range", "ipv6range"}
+
+func isNetworkChild(ref string) bool {
+	for _, t := range networkChildTypes {
+		if strings.HasPrefix(ref, t+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNetworkChildren returns the refs of fixed addresses, host records and
+// ranges defined in the IPv4 or IPv6 network
+func (objMgr *ObjectManager) GetNetworkChildren(netview string, cidr string) ([]string, error) {
+	var addrs []struct {
+		Objects []string `json:"objects"`
+	}
+	var ranges []struct {
+		Ref string `json:"_ref"`
+	}
+
+	var addr, rng IBObject
+	if isIPv6CIDR(cidr) {
+		addr6 := NewIPv6Address(IPv6Address{NetviewName: netview, Network: cidr, Status: "USED"})
+		addr6.returnFields = []string{"objects"}
+		rng6 := NewIPv6Range(IPv6Range{NetviewName: netview, Network: cidr})
+		rng6.returnFields = []string{"network", "network_view"}
+		addr, rng = addr6, rng6
+	} else {
+		addr4 := NewIPv4Address(IPv4Address{NetviewName: netview, Network: cidr, Status: "USED"})
+		addr4.returnFields = []string{"objects"}
+		rng4 := NewRange(Range{NetviewName: netview, Network: cidr})
+		rng4.returnFields = []string{"network", "network_view"}
+		addr, rng = addr4, rng4
+	}
+
+	if err := objMgr.readObjectPaged(addr, DefaultPageSize, &addrs); err != nil {
+		return nil, err
+	}
+	if err := objMgr.readObjectPaged(rng, DefaultPageSize, &ranges); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var children []string
+	for _, a := range addrs {
+		for _, ref := range a.Objects {
+			if isNetworkChild(ref) && !seen[ref] {
+				seen[ref] = true
+				children = append(children, ref)
+			}
+		}
+	}
+	for _, r := range ranges {
+		children = append(children, r.Ref)
+	}
+
+	return children, nil
+}
+
+// DeleteNetworkWithPolicy deletes the IPv4 or IPv6 network after checking
+// it for child fixed addresses, host records and ranges. A *NotEmptyError
+// is returned if children exist unless DeleteNetworkCascade is requested.
+// DeleteNetworkReparent returns an error, the children of a network cannot
+// be moved to another one.
+func (objMgr *ObjectManager) DeleteNetworkWithPolicy(ref string, netview string, policy DeleteNetworkPolicy) (string, error) {
+	if policy == DeleteNetworkReparent {
+		return "", fmt.Errorf("cannot delete network '%s': the children of a network cannot be reparented", ref)
+	}
+
+	network := BuildNetworkFromRef(ref)
+	if network == nil || network.NetviewName != netview {
+		return "", nil
+	}
+
+	children, err := objMgr.withDefaultReturnFields().GetNetworkChildren(netview, network.Cidr)
+	if err != nil {
+		return "", err
+	}
+
+	if len(children) > 0 {
+		if policy != DeleteNetworkCascade {
+			return "", &NotEmptyError{Ref: ref, Children: children}
+		}
+		for _, child := range children {
+			if _, err = objMgr.deleteObject(child); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return objMgr.deleteObject(ref)
+}
+
 func (objMgr *ObjectManager) GetEADefinition(name string) (*EADefinition, error) {
 	var res []EADefinition
 
 	eadef := NewEADefinition(EADefinition{Name: name})
 
-	err := objMgr.connector.GetObject(eadef, "", &res)
+	err := objMgr.getObject(eadef, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -435,15 +1212,25 @@ func (objMgr *ObjectManager) GetEADefinition(name string) (*EADefinition, error)
 func (objMgr *ObjectManager) CreateEADefinition(eadef EADefinition) (*EADefinition, error) {
 	newEadef := NewEADefinition(eadef)
 
-	ref, err := objMgr.connector.CreateObject(newEadef)
+	ref, err := objMgr.createObject(newEadef)
 	newEadef.Ref = ref
 
 	return newEadef, err
 }
 
 func (objMgr *ObjectManager) CreateHostRecord(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, vmID string, vmName string) (*HostRecord, error) {
+	return objMgr.CreateHostRecordWithOptions(enabledns, recordName, netview, dnsview, cidr, ipAddr, macAddress,
+		RecordOptions{VmID: vmID, VmName: vmName})
+}
 
-	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+func (objMgr *ObjectManager) CreateHostRecordWithOptions(enabledns bool, recordName string, netview string, dnsview string, cidr string, ipAddr string, macAddress string, opts RecordOptions) (*HostRecord, error) {
+	if enabledns {
+		if err := objMgr.checkRecordConflict("record:host", recordName, dnsview); err != nil {
+			return nil, err
+		}
+	}
+
+	ea := objMgr.recordEA(opts, false)
 
 	recordHostIpAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Mac: macAddress})
 
@@ -461,17 +1248,19 @@ func (objMgr *ObjectManager) CreateHostRecord(enabledns bool, recordName string,
 		NetworkView: netview,
 		View:        dnsview,
 		Ipv4Addrs:   recordHostIpAddrSlice,
+		Comment:     opts.Comment,
 		Ea:          ea})
+	recordHost.Ttl, recordHost.UseTtl = opts.ttl()
 
-	ref, err := objMgr.connector.CreateObject(recordHost)
+	ref, err := objMgr.createObject(recordHost)
 	recordHost.Ref = ref
-	err = objMgr.connector.GetObject(recordHost, ref, &recordHost)
+	err = objMgr.readObject(recordHost, ref, &recordHost)
 	return recordHost, err
 }
 
 func (objMgr *ObjectManager) GetHostRecordByRef(ref string) (*HostRecord, error) {
 	recordHost := NewHostRecord(HostRecord{})
-	err := objMgr.connector.GetObject(recordHost, ref, &recordHost)
+	err := objMgr.getObject(recordHost, ref, &recordHost)
 	return recordHost, err
 }
 
@@ -483,7 +1272,7 @@ func (objMgr *ObjectManager) GetHostRecord(recordName string, netview string, ci
 		recordHost.Name = recordName
 	}
 
-	err := objMgr.connector.GetObject(recordHost, "", &res)
+	err := objMgr.getObject(recordHost, "", &res)
 
 	if err != nil || res == nil || len(res) == 0 {
 		return nil, err
@@ -492,116 +1281,358 @@ func (objMgr *ObjectManager) GetHostRecord(recordName string, netview string, ci
 
 }
 
+// GetHostRecordIpv4Addr returns the host address object for ipAddr
+// including the discovered data collected for it
+func (objMgr *ObjectManager) GetHostRecordIpv4Addr(ipAddr string) (*HostRecordIpv4Addr, error) {
+	var res []HostRecordIpv4Addr
+
+	hostAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Ipv4Addr: ipAddr})
+	hostAddr.returnFields = []string{"discovered_data", "host", "ipv4addr", "mac", "network"}
+
+	err := objMgr.getObject(hostAddr, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
 func (objMgr *ObjectManager) GetIpAddressFromHostRecord(host HostRecord) (string, error) {
-	err := objMgr.connector.GetObject(&host, host.Ref, &host)
+	err := objMgr.getObject(&host, host.Ref, &host)
 	return host.Ipv4Addrs[0].Ipv4Addr, err
 }
 
 func (objMgr *ObjectManager) UpdateHostRecord(hostRref string, ipAddr string, macAddress string, vmID string, vmName string) (string, error) {
+	return objMgr.UpdateHostRecordWithOptions(hostRref, ipAddr, macAddress, RecordOptions{VmID: vmID, VmName: vmName})
+}
+
+func (objMgr *ObjectManager) UpdateHostRecordWithOptions(hostRref string, ipAddr string, macAddress string, opts RecordOptions) (string, error) {
 
 	recordHostIpAddr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Mac: macAddress, Ipv4Addr: ipAddr})
 	recordHostIpAddrSlice := []HostRecordIpv4Addr{*recordHostIpAddr}
-	updateHostRecord := NewHostRecord(HostRecord{Ipv4Addrs: recordHostIpAddrSlice})
+	updateHostRecord := NewHostRecord(HostRecord{Ipv4Addrs: recordHostIpAddrSlice, Comment: opts.Comment})
+	updateHostRecord.Ttl, updateHostRecord.UseTtl = opts.ttl()
 
-	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+	ea := objMgr.recordEA(opts, false)
 
 	updateHostRecord.Ea = ea
 
-	ref, err := objMgr.connector.UpdateObject(updateHostRecord, hostRref)
+	ref, err := objMgr.updateObject(updateHostRecord, hostRref)
 	return ref, err
 }
 
+// findZoneAuth returns the authoritative zone of the DNS view containing
+// fqdn, or nil if the view has no such zone
+func (objMgr *ObjectManager) findZoneAuth(fqdn string, dnsview string) (*ZoneAuth, error) {
+	labels := strings.Split(strings.TrimSuffix(fqdn, "."), ".")
+	for i := 1; i < len(labels); i++ {
+		zone, err := objMgr.withDefaultReturnFields().GetZoneAuthByFQDN(strings.Join(labels[i:], "."), dnsview)
+		if err != nil || zone != nil {
+			return zone, err
+		}
+	}
+
+	return nil, nil
+}
+
+// RenameHostRecord changes the name of the host record referenced by ref,
+// keeping its addresses and EAs. The new name must belong to an
+// authoritative zone of the DNS view of the record.
+func (objMgr *ObjectManager) RenameHostRecord(ref string, newName string) (*HostRecord, error) {
+	recordHost, err := objMgr.withDefaultReturnFields().GetHostRecordByRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := objMgr.findZoneAuth(newName, recordHost.View)
+	if err != nil {
+		return nil, err
+	}
+	if zone == nil {
+		return nil, fmt.Errorf("no authoritative zone for '%s' in DNS view '%s'", newName, recordHost.View)
+	}
+
+	renameHostRecord := NewHostRecord(HostRecord{Name: newName})
+	newRef, err := objMgr.updateObject(renameHostRecord, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetHostRecordByRef(newRef)
+}
+
 func (objMgr *ObjectManager) DeleteHostRecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.deleteObject(ref)
+}
+
+// RecordOptions holds the optional settings of DNS records
+type RecordOptions struct {
+	// Ttl overrides the TTL of the zone when set
+	Ttl *uint32
+	// UseZoneTtl reverts an updated record to the TTL of the zone
+	UseZoneTtl bool
+	Comment    string
+	VmID       string
+	VmName     string
+	Ea         EA
+}
+
+// ttl returns the ttl and use_ttl fields of a record
+func (opts RecordOptions) ttl() (uint32, *bool) {
+	if opts.Ttl != nil {
+		useTtl := true
+		return *opts.Ttl, &useTtl
+	}
+	if opts.UseZoneTtl {
+		useTtl := false
+		return 0, &useTtl
+	}
+	return 0, nil
+}
+
+// recordEA merges opts.Ea over the cloud and VM EAs. If omitEmpty is true
+// nil is returned when opts sets no EA, leaving the EAs of an updated
+// record unchanged.
+func (objMgr *ObjectManager) recordEA(opts RecordOptions, omitEmpty bool) EA {
+	if omitEmpty && opts.Ea == nil && opts.VmID == "" && opts.VmName == "" {
+		return nil
+	}
+
+	ea := objMgr.getBasicVMEA(true, opts.VmID, opts.VmName)
+	for k, v := range opts.Ea {
+		ea[k] = v
+	}
+	return ea
 }
 
 func (objMgr *ObjectManager) CreateARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordA, error) {
+	return objMgr.CreateARecordWithOptions(netview, dnsview, recordname, cidr, ipAddr,
+		RecordOptions{VmID: vmID, VmName: vmName})
+}
 
-	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+func (objMgr *ObjectManager) CreateARecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordA, error) {
+	if err := objMgr.checkRecordConflict("record:a", recordname, dnsview); err != nil {
+		return nil, err
+	}
+
+	ea := objMgr.recordEA(opts, false)
 
 	recordA := NewRecordA(RecordA{
-		View: dnsview,
-		Name: recordname,
-		Ea:   ea})
+		View:    dnsview,
+		Name:    recordname,
+		Comment: opts.Comment,
+		Ea:      ea})
+	recordA.Ttl, recordA.UseTtl = opts.ttl()
 
 	if ipAddr == "" {
 		recordA.Ipv4Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
 	} else {
 		recordA.Ipv4Addr = ipAddr
 	}
-	ref, err := objMgr.connector.CreateObject(recordA)
+	ref, err := objMgr.createObject(recordA)
 	recordA.Ref = ref
 	return recordA, err
 }
 
 func (objMgr *ObjectManager) GetARecordByRef(ref string) (*RecordA, error) {
 	recordA := NewRecordA(RecordA{})
-	err := objMgr.connector.GetObject(recordA, ref, &recordA)
+	recordA.returnFields = append(recordA.returnFields, "creation_time")
+	err := objMgr.getObject(recordA, ref, &recordA)
 	return recordA, err
 }
 
+// UpdateARecord changes the name, address, TTL, comment and EAs of the A
+// record referenced by ref. Empty values leave the record unchanged.
+func (objMgr *ObjectManager) UpdateARecord(ref string, recordname string, ipAddr string, opts RecordOptions) (*RecordA, error) {
+	updateRecordA := NewRecordA(RecordA{
+		Name:     recordname,
+		Ipv4Addr: ipAddr,
+		Comment:  opts.Comment,
+		Ea:       objMgr.recordEA(opts, true)})
+	updateRecordA.Ttl, updateRecordA.UseTtl = opts.ttl()
+
+	newRef, err := objMgr.updateObject(updateRecordA, ref)
+	updateRecordA.Ref = newRef
+	return updateRecordA, err
+}
+
 func (objMgr *ObjectManager) DeleteARecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.deleteObject(ref)
 }
 
 func (objMgr *ObjectManager) CreateCNAMERecord(canonical string, recordname string, dnsview string) (*RecordCNAME, error) {
+	return objMgr.CreateCNAMERecordWithOptions(canonical, recordname, dnsview, RecordOptions{})
+}
+
+func (objMgr *ObjectManager) CreateCNAMERecordWithOptions(canonical string, recordname string, dnsview string, opts RecordOptions) (*RecordCNAME, error) {
+	if err := objMgr.checkRecordConflict("record:cname", recordname, dnsview); err != nil {
+		return nil, err
+	}
+
+	ea := objMgr.recordEA(opts, false)
+	if len(ea) == 0 {
+		// without the cloud attributes, the record is created without EAs
+		ea = nil
+	}
 
 	recordCNAME := NewRecordCNAME(RecordCNAME{
 		View:      dnsview,
 		Name:      recordname,
-		Canonical: canonical})
+		Canonical: canonical,
+		Comment:   opts.Comment,
+		Ea:        ea})
+	recordCNAME.Ttl, recordCNAME.UseTtl = opts.ttl()
 
-	ref, err := objMgr.connector.CreateObject(recordCNAME)
+	ref, err := objMgr.createObject(recordCNAME)
 	recordCNAME.Ref = ref
 	return recordCNAME, err
 }
 
 func (objMgr *ObjectManager) GetCNAMERecordByRef(ref string) (*RecordCNAME, error) {
 	recordCNAME := NewRecordCNAME(RecordCNAME{})
-	err := objMgr.connector.GetObject(recordCNAME, ref, &recordCNAME)
+	recordCNAME.returnFields = append(recordCNAME.returnFields, "creation_time")
+	err := objMgr.getObject(recordCNAME, ref, &recordCNAME)
 	return recordCNAME, err
 }
 
+// UpdateCNAMERecord changes the canonical name, alias, TTL, comment and EAs
+// of the CNAME record referenced by ref. Empty values leave the record
+// unchanged.
+func (objMgr *ObjectManager) UpdateCNAMERecord(ref string, canonical string, recordname string, opts RecordOptions) (*RecordCNAME, error) {
+	updateRecordCNAME := NewRecordCNAME(RecordCNAME{
+		Canonical: canonical,
+		Name:      recordname,
+		Comment:   opts.Comment,
+		Ea:        objMgr.recordEA(opts, true)})
+	updateRecordCNAME.Ttl, updateRecordCNAME.UseTtl = opts.ttl()
+
+	newRef, err := objMgr.updateObject(updateRecordCNAME, ref)
+	updateRecordCNAME.Ref = newRef
+	return updateRecordCNAME, err
+}
+
 func (objMgr *ObjectManager) DeleteCNAMERecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.deleteObject(ref)
 }
 
 func (objMgr *ObjectManager) CreatePTRRecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordPTR, error) {
+	return objMgr.CreatePTRRecordWithOptions(netview, dnsview, recordname, cidr, ipAddr,
+		RecordOptions{VmID: vmID, VmName: vmName})
+}
 
-	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+func (objMgr *ObjectManager) CreatePTRRecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordPTR, error) {
+
+	ea := objMgr.recordEA(opts, false)
 
 	recordPTR := NewRecordPTR(RecordPTR{
 		View:     dnsview,
 		PtrdName: recordname,
+		Comment:  opts.Comment,
 		Ea:       ea})
+	recordPTR.Ttl, recordPTR.UseTtl = opts.ttl()
 
 	if ipAddr == "" {
 		recordPTR.Ipv4Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
 	} else {
 		recordPTR.Ipv4Addr = ipAddr
 	}
-	ref, err := objMgr.connector.CreateObject(recordPTR)
+	ref, err := objMgr.createObject(recordPTR)
 	recordPTR.Ref = ref
 	return recordPTR, err
 }
 
 func (objMgr *ObjectManager) GetPTRRecordByRef(ref string) (*RecordPTR, error) {
 	recordPTR := NewRecordPTR(RecordPTR{})
-	err := objMgr.connector.GetObject(recordPTR, ref, &recordPTR)
+	recordPTR.returnFields = append(recordPTR.returnFields, "creation_time")
+	err := objMgr.getObject(recordPTR, ref, &recordPTR)
 	return recordPTR, err
 }
 
+// UpdatePTRRecord changes the domain name, IPv4 or IPv6 address, TTL,
+// comment and EAs of the PTR record referenced by ref. Empty values leave
+// the record unchanged.
+func (objMgr *ObjectManager) UpdatePTRRecord(ref string, ptrdname string, ipAddr string, opts RecordOptions) (*RecordPTR, error) {
+	updateRecordPTR := NewRecordPTR(RecordPTR{
+		PtrdName: ptrdname,
+		Comment:  opts.Comment,
+		Ea:       objMgr.recordEA(opts, true)})
+	if strings.Contains(ipAddr, ":") {
+		updateRecordPTR.Ipv6Addr = ipAddr
+	} else {
+		updateRecordPTR.Ipv4Addr = ipAddr
+	}
+	updateRecordPTR.Ttl, updateRecordPTR.UseTtl = opts.ttl()
+
+	newRef, err := objMgr.updateObject(updateRecordPTR, ref)
+	updateRecordPTR.Ref = newRef
+	return updateRecordPTR, err
+}
+
 func (objMgr *ObjectManager) DeletePTRRecord(ref string) (string, error) {
-	return objMgr.connector.DeleteObject(ref)
+	return objMgr.deleteObject(ref)
 }
 
-// CreateMultiObject unmarshals the result into slice of maps
-func (objMgr *ObjectManager) CreateMultiObject(req *MultiRequest) ([]map[string]interface{}, error) {
+// GetDiscoveryDevice returns the discovered network device by name
+func (objMgr *ObjectManager) GetDiscoveryDevice(netview string, name string) (*DiscoveryDevice, error) {
+	var res []DiscoveryDevice
+
+	device := NewDiscoveryDevice(DiscoveryDevice{
+		NetviewName: netview,
+		Name:        name})
+
+	err := objMgr.getObject(device, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// GetDeviceInterfaces returns the interfaces of the discovered device
+func (objMgr *ObjectManager) GetDeviceInterfaces(deviceRef string) ([]DiscoveryDeviceInterface, error) {
+	var res []DiscoveryDeviceInterface
+
+	intf := NewDiscoveryDeviceInterface(DiscoveryDeviceInterface{Device: deviceRef})
+	err := objMgr.getObject(intf, "", &res)
+	return res, err
+}
+
+// validation for port admin status
+func validatePortAdminStatus(value string) bool {
+	return value == "UP" || value == "DOWN"
+}
+
+// SetDeviceInterfaceAdminStatus schedules a port control task to bring the
+// switch port UP or DOWN
+func (objMgr *ObjectManager) SetDeviceInterfaceAdminStatus(ref string, adminStatus string) (string, error) {
+	if !validatePortAdminStatus(adminStatus) {
+		return "", fmt.Errorf("wrong value for admin_status passed %s", adminStatus)
+	}
+
+	intf := NewDiscoveryDeviceInterface(DiscoveryDeviceInterface{
+		PortConfigAdminStatus: &PortConfigAdminStatus{AdminStatus: adminStatus}})
 
-	conn := objMgr.connector.(*Connector)
-	queryParams := QueryParams{forceProxy: false}
-	res, err := conn.makeRequest(CREATE, req, "", queryParams)
+	return objMgr.updateObject(intf, ref)
+}
+
+// SetDeviceInterfaceVlan schedules a port control task assigning the data
+// VLAN of the switch port
+func (objMgr *ObjectManager) SetDeviceInterfaceVlan(ref string, vlanID int, vlanName string) (string, error) {
+	if vlanID < 1 || vlanID > 4094 {
+		return "", fmt.Errorf("wrong value for vlan id passed %d", vlanID)
+	}
+
+	intf := NewDiscoveryDeviceInterface(DiscoveryDeviceInterface{
+		PortConfigVlanInfo: &PortConfigVlanInfo{
+			DataVlanInfo: &VlanInfo{ID: vlanID, Name: vlanName}}})
+
+	return objMgr.updateObject(intf, ref)
+}
 
+// CreateMultiObject unmarshals the result into slice of maps
+func (objMgr *ObjectManager) CreateMultiObject(req *MultiRequest) ([]map[string]interface{}, error) {
+	res, err := objMgr.multiRequest(req)
 	if err != nil {
 		return nil, err
 	}
@@ -626,37 +1657,61 @@ func (objMgr *ObjectManager) GetUpgradeStatus(statusType string) ([]UpgradeStatu
 		msg := fmt.Sprintf("Status type can not be nil")
 		return res, errors.New(msg)
 	}
+	if !UpgradeStatusType(statusType).IsValid() {
+		return res, fmt.Errorf("wrong value for upgrade status type passed %s", statusType)
+	}
 	upgradestatus := NewUpgradeStatus(UpgradeStatus{Type: statusType})
-	err := objMgr.connector.GetObject(upgradestatus, "", &res)
+	err := objMgr.getObject(upgradestatus, "", &res)
 
 	return res, err
 }
 
 // GetAllMembers returns all members information
 func (objMgr *ObjectManager) GetAllMembers() ([]Member, error) {
-	var res []Member
-
-	memberObj := NewMember(Member{})
-	err := objMgr.connector.GetObject(memberObj, "", &res)
-	return res, err
+	return objMgr.GetMembers(MemberFilter{})
 }
 
-// GetCapacityReport returns all capacity for members
+// GetCapacityReport returns all capacity for members, an empty name
+// returns the reports of all members
 func (objMgr *ObjectManager) GetCapacityReport(name string) ([]CapacityReport, error) {
 	var res []CapacityReport
 
 	capacityObj := CapacityReport{Name: name}
 	capacityReport := NewCapcityReport(capacityObj)
-	err := objMgr.connector.GetObject(capacityReport, "", &res)
+	err := objMgr.getObject(capacityReport, "", &res)
 	return res, err
 }
 
+// GetCapacitySummary returns the capacity reports of all members together
+// with grid-level totals
+func (objMgr *ObjectManager) GetCapacitySummary() (*CapacitySummary, error) {
+	reports, err := objMgr.withDefaultReturnFields().GetCapacityReport("")
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &CapacitySummary{Members: reports}
+	for _, r := range reports {
+		summary.TotalObjects += r.TotalObjects
+		summary.MaxCapacity += r.MaxCapacity
+		if r.PercentUsed > summary.MaxPercentUsed || summary.MaxPercentUsedBy == "" {
+			summary.MaxPercentUsed = r.PercentUsed
+			summary.MaxPercentUsedBy = r.Name
+		}
+	}
+	if summary.MaxCapacity > 0 {
+		summary.PercentUsed = summary.TotalObjects * 100 / summary.MaxCapacity
+	}
+
+	return summary, nil
+}
+
 // GetLicense returns the license details for member
 func (objMgr *ObjectManager) GetLicense() ([]License, error) {
 	var res []License
 
 	licenseObj := NewLicense(License{})
-	err := objMgr.connector.GetObject(licenseObj, "", &res)
+	err := objMgr.getObject(licenseObj, "", &res)
 	return res, err
 }
 
@@ -665,15 +1720,16 @@ func (objMgr *ObjectManager) GetGridLicense() ([]License, error) {
 	var res []License
 
 	licenseObj := NewGridLicense(License{})
-	err := objMgr.connector.GetObject(licenseObj, "", &res)
+	err := objMgr.getObject(licenseObj, "", &res)
 	return res, err
 }
 
-// GetGridInfo returns the details for grid
+// GetGridInfo returns the details for grid, see GetCompatibility for its
+// NIOS and WAPI versions
 func (objMgr *ObjectManager) GetGridInfo() ([]Grid, error) {
 	var res []Grid
 
 	gridObj := NewGrid(Grid{})
-	err := objMgr.connector.GetObject(gridObj, "", &res)
+	err := objMgr.getObject(gridObj, "", &res)
 	return res, err
 }
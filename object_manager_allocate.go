@@ -0,0 +1,58 @@
+package ibclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// allocatesNext tells whether obj holds a next available address or
+// network function, which the grid evaluates when creating it
+func allocatesNext(obj IBObject) bool {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Contains(body, []byte("func:nextavailable"))
+}
+
+// sleep waits for d, or until the context of the connector is done
+func (objMgr *ObjectManager) sleep(d time.Duration) error {
+	if conn, ok := objMgr.connector.(*Connector); ok {
+		return conn.sleep(d)
+	}
+
+	time.Sleep(d)
+	return nil
+}
+
+// createAllocated creates obj. The creation of an object allocating the
+// next available address or network is retried as configured by
+// AllocationRetryPolicy when another client allocated the same one first.
+func (objMgr *ObjectManager) createAllocated(obj IBObject) (ref string, err error) {
+	policy := objMgr.AllocationRetryPolicy
+	if policy.MaxAttempts < 2 || !allocatesNext(obj) {
+		return objMgr.connector.CreateObject(obj)
+	}
+	retryOn := policy.RetryOn
+	if retryOn == nil {
+		retryOn = func(err error) bool {
+			return errors.Is(err, ErrConflict)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		ref, err = objMgr.connector.CreateObject(obj)
+		if err == nil || attempt >= policy.MaxAttempts || !retryOn(err) {
+			return
+		}
+
+		log.Printf("Allocation of '%s' conflicted, attempt %d of %d\n", obj.ObjectType(), attempt, policy.MaxAttempts)
+		if sleepErr := objMgr.sleep(policy.backoff(attempt)); sleepErr != nil {
+			return "", sleepErr
+		}
+	}
+}
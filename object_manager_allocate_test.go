@@ -0,0 +1,54 @@
+package ibclient
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager allocation retries", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	conflict := newWapiError(http.StatusBadRequest, "400 Bad Request",
+		[]byte(`{"Error": "AdmConDataError: None (IBDataConflictError: IB.Data.Conflict:The IP address is in use)",
+			"code": "Client.Ibap.Data.Conflict", "text": "The IP address is in use"}`))
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Jitter: 0.5}
+
+	It("should retry the allocations conflicting with a concurrent one", func() {
+		// each attempt falls back to the Grid Master once
+		requestor := &flakyRequestor{errs: []error{conflict, conflict}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.AllocationRetryPolicy = policy
+
+		network, err := objMgr.AllocateNetwork("default", "10.0.0.0/16", 24, "")
+		Expect(err).To(BeNil())
+		Expect(network.Cidr).To(Equal("10.0.0.0/24"))
+		Expect(requestor.sends).To(Equal(3))
+	})
+
+	It("should fail once the attempts are exhausted", func() {
+		requestor := &flakyRequestor{errs: []error{conflict, conflict, conflict, conflict, conflict, conflict}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.AllocationRetryPolicy = policy
+
+		_, err := objMgr.AllocateIP("default", "10.0.0.0/24", "", "", "", "", "")
+		Expect(errors.Is(err, ErrConflict)).To(BeTrue())
+		Expect(requestor.sends).To(Equal(6))
+	})
+
+	It("should not retry the creation of a given address", func() {
+		requestor := &flakyRequestor{errs: []error{conflict, conflict}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.AllocationRetryPolicy = policy
+
+		_, err := objMgr.AllocateIP("default", "10.0.0.0/24", "10.0.0.10", "", "", "", "")
+		Expect(errors.Is(err, ErrConflict)).To(BeTrue())
+		Expect(requestor.sends).To(Equal(2))
+	})
+})
@@ -0,0 +1,57 @@
+package ibclient
+
+var memberAnycastReturnFields = []string{"additional_ip_list", "bgp_as", "host_name", "ospf_list"}
+
+// GetMemberAnycast returns the additional addresses, BGP and OSPF
+// configuration of the member hostName
+func (objMgr *ObjectManager) GetMemberAnycast(hostName string) (*Member, error) {
+	var res []Member
+
+	member := NewMember(Member{HostName: hostName})
+	member.returnFields = memberAnycastReturnFields
+	err := objMgr.getObject(member, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateMemberAnycast replaces the additional addresses, BGP and OSPF
+// configuration of the member referenced by ref. A nil list leaves the
+// matching configuration unchanged.
+func (objMgr *ObjectManager) UpdateMemberAnycast(ref string, addresses []MemberInterface, bgpAs []BgpAs, ospfList []Ospf) (*Member, error) {
+	member := NewMember(Member{
+		AdditionalIpList: addresses,
+		BgpAs:            bgpAs,
+		OspfList:         ospfList})
+	member.returnFields = memberAnycastReturnFields
+
+	newRef, err := objMgr.updateObject(member, ref)
+	member.Ref = newRef
+	return member, err
+}
+
+func (objMgr *ObjectManager) GetMemberDns(hostName string) (*MemberDns, error) {
+	var res []MemberDns
+
+	memberDns := NewMemberDns(MemberDns{HostName: hostName})
+	err := objMgr.getObject(memberDns, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateMemberDnsAdditionalIPs sets the additional addresses, such as
+// anycast addresses, the DNS service of the member listens on
+func (objMgr *ObjectManager) UpdateMemberDnsAdditionalIPs(ref string, addresses []string) (*MemberDns, error) {
+	memberDns := NewMemberDns(MemberDns{AdditionalIpList: addresses})
+
+	newRef, err := objMgr.updateObject(memberDns, ref)
+	memberDns.Ref = newRef
+	return memberDns, err
+}
@@ -0,0 +1,74 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Anycast", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	memberRef := "member/b25lLnZpcnR1YWxfbm9kZSQw:ns1.example.com"
+	anycast := []MemberInterface{{
+		Anycast:            true,
+		Interface:          "LOOPBACK",
+		EnableBgp:          true,
+		Ipv4NetworkSetting: &InterfaceIpv4Setting{Address: "10.255.0.53", SubnetMask: "255.255.255.255"},
+	}}
+
+	Describe("GetMemberAnycast", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"member": []Member{{Ref: memberRef, HostName: "ns1.example.com", AdditionalIpList: anycast}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the anycast configuration of the member", func() {
+			member, err := objMgr.GetMemberAnycast("ns1.example.com")
+			Expect(err).To(BeNil())
+			Expect(member.AdditionalIpList).To(Equal(anycast))
+
+			search := conn.getObjs[0].(*Member)
+			Expect(search.HostName).To(Equal("ns1.example.com"))
+			Expect(search.ReturnFields()).To(ConsistOf("additional_ip_list", "bgp_as", "host_name", "ospf_list"))
+		})
+	})
+
+	Describe("UpdateMemberAnycast", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should only send the configuration given", func() {
+			bgp := []BgpAs{{As: 65001, Neighbors: []BgpNeighbor{{NeighborIp: "10.0.0.1", RemoteAs: 65000}}}}
+			member, err := objMgr.UpdateMemberAnycast(memberRef, anycast, bgp, nil)
+			Expect(err).To(BeNil())
+			Expect(member.Ref).To(Equal(memberRef))
+
+			Expect(conn.updateRefs).To(Equal([]string{memberRef}))
+			updated := conn.updateObjs[0].(*Member)
+			updated.Ref = ""
+			js, _ := json.Marshal(updated)
+			Expect(js).To(MatchJSON(`{
+				"additional_ip_list": [{"anycast": true, "interface": "LOOPBACK", "enable_bgp": true, "enable_ospf": false,
+					"ipv4_network_setting": {"address": "10.255.0.53", "subnet_mask": "255.255.255.255"}}],
+				"bgp_as": [{"as": 65001, "neighbors": [{"neighbor_ip": "10.0.0.1", "remote_as": 65000}]}]}`))
+		})
+	})
+
+	Describe("UpdateMemberDnsAdditionalIPs", func() {
+		dnsRef := "member:dns/ZG5zLm1lbWJlcl9kbnNfcHJvcGVydGllcyQw:ns1.example.com"
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should set the addresses the DNS service listens on", func() {
+			memberDns, err := objMgr.UpdateMemberDnsAdditionalIPs(dnsRef, []string{"10.255.0.53"})
+			Expect(err).To(BeNil())
+			Expect(memberDns.Ref).To(Equal(dnsRef))
+			Expect(conn.updateObjs[0].(*MemberDns).AdditionalIpList).To(Equal([]string{"10.255.0.53"}))
+			Expect(conn.updateObjs[0].ObjectType()).To(Equal("member:dns"))
+		})
+	})
+})
@@ -0,0 +1,259 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+)
+
+// BulkUpdateChunkSize is the number of objects updated per WAPI request
+const BulkUpdateChunkSize = 100
+
+// DefaultMaxRequestBodySize is the size in bytes of the requests sent by
+// the bulk operations unless ObjectManager.MaxRequestBodySize is set
+const DefaultMaxRequestBodySize = 1 << 20
+
+// bulkItem is an object updated by a bulk operation, with the requests
+// updating it
+type bulkItem struct {
+	ref  string
+	body []*RequestBody
+}
+
+// size returns the size of the requests of item in a request body
+func (item bulkItem) size() int {
+	size := 0
+	for _, b := range item.body {
+		js, _ := json.Marshal(b)
+		size += len(js) + 1
+	}
+	return size
+}
+
+func (objMgr *ObjectManager) maxRequestBodySize() int {
+	if objMgr.MaxRequestBodySize > 0 {
+		return objMgr.MaxRequestBodySize
+	}
+	return DefaultMaxRequestBodySize
+}
+
+// applyBulk sends the requests of items through the request object, in
+// chunks of at most BulkUpdateChunkSize items and MaxRequestBodySize bytes
+// applied atomically. A chunk rejected by the grid as too large is split in
+// halves sent in turn. The indexes of the items applied are returned in
+// order, with the chunks that failed.
+func (objMgr *ObjectManager) applyBulk(items []bulkItem) ([]int, []BulkFailure) {
+	var applied []int
+	var failures []BulkFailure
+
+	maxSize := objMgr.maxRequestBodySize()
+	for start := 0; start < len(items); {
+		// a single item larger than maxSize is still sent, on its own
+		end, size := start, 2
+		for end < len(items) && end-start < BulkUpdateChunkSize {
+			itemSize := items[end].size()
+			if end > start && size+itemSize > maxSize {
+				break
+			}
+			size += itemSize
+			end++
+		}
+
+		chunkApplied, chunkFailures := objMgr.applyBulkChunk(items, start, end)
+		applied = append(applied, chunkApplied...)
+		failures = append(failures, chunkFailures...)
+		start = end
+	}
+
+	return applied, failures
+}
+
+func (objMgr *ObjectManager) applyBulkChunk(items []bulkItem, start int, end int) ([]int, []BulkFailure) {
+	var body []*RequestBody
+	for _, item := range items[start:end] {
+		body = append(body, item.body...)
+	}
+
+	_, err := objMgr.CreateMultiObject(NewMultiRequest(body))
+	if errors.Is(err, ErrRequestTooLarge) && end-start > 1 {
+		mid := (start + end) / 2
+		applied, failures := objMgr.applyBulkChunk(items, start, mid)
+		endApplied, endFailures := objMgr.applyBulkChunk(items, mid, end)
+		return append(applied, endApplied...), append(failures, endFailures...)
+	}
+
+	if err != nil {
+		refs := make([]string, 0, end-start)
+		for _, item := range items[start:end] {
+			refs = append(refs, item.ref)
+		}
+		return nil, []BulkFailure{{Refs: refs, Err: err}}
+	}
+
+	applied := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		applied = append(applied, i)
+	}
+	return applied, nil
+}
+
+func bulkEARequestBody(ref string, addEA EA, removeEA EA) []*RequestBody {
+	var body []*RequestBody
+
+	if len(addEA) > 0 {
+		body = append(body, &RequestBody{
+			Method:  "PUT",
+			Object:  ref,
+			Data:    map[string]interface{}{"extattrs+": addEA},
+			Discard: true,
+		})
+	}
+
+	if len(removeEA) > 0 {
+		remove := make(map[string]interface{})
+		for k := range removeEA {
+			remove[k] = map[string]interface{}{}
+		}
+		body = append(body, &RequestBody{
+			Method:  "PUT",
+			Object:  ref,
+			Data:    map[string]interface{}{"extattrs-": remove},
+			Discard: true,
+		})
+	}
+
+	return body
+}
+
+// BulkUpdateEA adds addEA to and removes the keys of removeEA from every
+// object in refs. The objects are updated through the request object in
+// chunks of BulkUpdateChunkSize objects and MaxRequestBodySize bytes; each
+// chunk is applied atomically. The refs of updated objects are returned,
+// and a *BulkUpdateError lists the chunks that failed.
+func (objMgr *ObjectManager) BulkUpdateEA(refs []string, addEA EA, removeEA EA) ([]string, error) {
+	var updated []string
+
+	if len(addEA) == 0 && len(removeEA) == 0 {
+		return updated, nil
+	}
+	if objMgr.CheckEADefinitions {
+		if err := objMgr.ValidateEA(addEA); err != nil {
+			return updated, err
+		}
+	}
+
+	items := make([]bulkItem, len(refs))
+	for i, ref := range refs {
+		items[i] = bulkItem{ref: ref, body: bulkEARequestBody(ref, addEA, removeEA)}
+	}
+
+	applied, failures := objMgr.applyBulk(items)
+	for _, i := range applied {
+		updated = append(updated, refs[i])
+	}
+
+	if len(failures) > 0 {
+		return updated, &BulkUpdateError{Failures: failures}
+	}
+
+	return updated, nil
+}
+
+// renameRecordTypes are the types of allrecords renamed by RenameRecords
+var renameRecordTypes = map[string]bool{
+	"record:a":     true,
+	"record:aaaa":  true,
+	"record:cname": true,
+	"record:host":  true,
+	"record:mx":    true,
+	"record:srv":   true,
+	"record:txt":   true,
+}
+
+// RenameChange is a record renamed by RenameRecords
+type RenameChange struct {
+	Ref     string
+	Type    string
+	OldName string
+	NewName string
+}
+
+// RenameRecords renames the A, AAAA, CNAME, host, MX, SRV and TXT records
+// of the forward zones of dnsview whose FQDN matches matchRegex. The
+// matches are replaced with replaceTemplate, which may refer to submatches
+// as in regexp.Expand, e.g. "${1}.new.example.com". The records are
+// renamed in chunks like BulkUpdateEA, each chunk atomically. The
+// changes applied are returned, or the changes to apply if dryRun is true;
+// a *BulkUpdateError lists the refs of the chunks that failed.
+func (objMgr *ObjectManager) RenameRecords(dnsview string, matchRegex string, replaceTemplate string, dryRun bool) ([]RenameChange, error) {
+	re, err := regexp.Compile(matchRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []ZoneAuth
+	zoneAuth := NewZoneAuth(ZoneAuth{View: dnsview})
+	zoneAuth.returnFields = append(zoneAuth.returnFields, "zone_format")
+	if err = objMgr.readObjectPaged(zoneAuth, 0, &zones); err != nil {
+		return nil, err
+	}
+
+	var changes []RenameChange
+	for _, zone := range zones {
+		if zone.ZoneFormat != "" && zone.ZoneFormat != "FORWARD" {
+			continue
+		}
+
+		var records []AllRecords
+		err = objMgr.readObjectPaged(NewAllRecords(AllRecords{View: dnsview, Zone: zone.Fqdn}), 0, &records)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rec := range records {
+			if !renameRecordTypes[rec.Type] || rec.Record == "" {
+				continue
+			}
+
+			name := zone.Fqdn
+			if rec.Name != "" {
+				name = rec.Name + "." + zone.Fqdn
+			}
+			if !re.MatchString(name) {
+				continue
+			}
+			newName := re.ReplaceAllString(name, replaceTemplate)
+			if newName == name {
+				continue
+			}
+
+			changes = append(changes, RenameChange{Ref: rec.Record, Type: rec.Type, OldName: name, NewName: newName})
+		}
+	}
+
+	if dryRun {
+		return changes, nil
+	}
+
+	var renamed []RenameChange
+	items := make([]bulkItem, len(changes))
+	for i, c := range changes {
+		items[i] = bulkItem{ref: c.Ref, body: []*RequestBody{{
+			Method:  "PUT",
+			Object:  c.Ref,
+			Data:    map[string]interface{}{"name": c.NewName},
+			Discard: true,
+		}}}
+	}
+
+	applied, failures := objMgr.applyBulk(items)
+	for _, i := range applied {
+		renamed = append(renamed, changes[i])
+	}
+
+	if len(failures) > 0 {
+		return renamed, &BulkUpdateError{Failures: failures}
+	}
+
+	return renamed, nil
+}
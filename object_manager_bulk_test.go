@@ -0,0 +1,214 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type bulkRequestor struct {
+	bodies [][]map[string]interface{}
+	errs   []error
+}
+
+func (hr *bulkRequestor) Init(config TransportConfig) {}
+
+func (hr *bulkRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	var body []map[string]interface{}
+	b, _ := ioutil.ReadAll(req.Body)
+	json.Unmarshal(b, &body)
+	hr.bodies = append(hr.bodies, body)
+
+	var err error
+	if len(hr.errs) > 0 {
+		err = hr.errs[0]
+		hr.errs = hr.errs[1:]
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(`[]`), nil
+}
+
+// renameRequestor returns the zones and the records of the zone searched
+// for, and records the bodies of the multi requests
+type renameRequestor struct {
+	zones   string
+	records map[string]string
+	bodies  [][]map[string]interface{}
+}
+
+func (hr *renameRequestor) Init(config TransportConfig) {}
+
+func (hr *renameRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	b, _ := ioutil.ReadAll(req.Body)
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/zone_auth"):
+		return []byte(`{"result": ` + hr.zones + `}`), nil
+	case strings.HasSuffix(req.URL.Path, "/allrecords"):
+		var search AllRecords
+		json.Unmarshal(b, &search)
+		return []byte(`{"result": ` + hr.records[search.Zone] + `}`), nil
+	}
+
+	var body []map[string]interface{}
+	json.Unmarshal(b, &body)
+	hr.bodies = append(hr.bodies, body)
+	return []byte(`[]`), nil
+}
+
+var _ = Describe("Object Manager Bulk", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	Describe("BulkUpdateEA", func() {
+		var refs []string
+		for i := 0; i < BulkUpdateChunkSize+1; i++ {
+			refs = append(refs, fmt.Sprintf("network/ZG5zLm5ldHdvcms:%d", i))
+		}
+
+		It("should add and remove EAs for every ref in one request per chunk", func() {
+			requestor := &bulkRequestor{}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			updated, err := objMgr.BulkUpdateEA(refs, EA{"Department": "Ops"}, EA{"Owner": ""})
+			Expect(err).To(BeNil())
+			Expect(updated).To(Equal(refs))
+
+			Expect(len(requestor.bodies)).To(Equal(2))
+			Expect(len(requestor.bodies[0])).To(Equal(2 * BulkUpdateChunkSize))
+			Expect(requestor.bodies[0][0]).To(Equal(map[string]interface{}{
+				"method":  "PUT",
+				"object":  refs[0],
+				"data":    map[string]interface{}{"extattrs+": map[string]interface{}{"Department": map[string]interface{}{"value": "Ops"}}},
+				"discard": true,
+			}))
+			Expect(requestor.bodies[0][1]["data"]).To(Equal(
+				map[string]interface{}{"extattrs-": map[string]interface{}{"Owner": map[string]interface{}{}}}))
+			Expect(requestor.bodies[1][0]["object"]).To(Equal(refs[BulkUpdateChunkSize]))
+		})
+
+		It("should report the refs of failed chunks and keep going", func() {
+			// the failed request is retried once through the proxy
+			wapiErr := errors.New("AdmConDataError")
+			requestor := &bulkRequestor{errs: []error{wapiErr, wapiErr}}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			updated, err := objMgr.BulkUpdateEA(refs, EA{"Department": "Ops"}, nil)
+			Expect(updated).To(Equal(refs[BulkUpdateChunkSize:]))
+			Expect(len(requestor.bodies[0])).To(Equal(BulkUpdateChunkSize))
+
+			bulkErr, ok := err.(*BulkUpdateError)
+			Expect(ok).To(BeTrue())
+			Expect(len(bulkErr.Failures)).To(Equal(1))
+			Expect(bulkErr.Failures[0].Refs).To(Equal(refs[:BulkUpdateChunkSize]))
+			Expect(bulkErr.Error()).To(ContainSubstring("100 object(s)"))
+		})
+
+		It("should keep the requests under the maximum body size", func() {
+			requestor := &bulkRequestor{}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			objMgr.MaxRequestBodySize = 4096
+
+			updated, err := objMgr.BulkUpdateEA(refs, EA{"Department": "Ops"}, nil)
+			Expect(err).To(BeNil())
+			Expect(updated).To(Equal(refs))
+
+			Expect(len(requestor.bodies)).To(BeNumerically(">", 2))
+			var sent []string
+			for _, body := range requestor.bodies {
+				js, _ := json.Marshal(body)
+				Expect(len(js)).To(BeNumerically("<=", 4096))
+				for _, b := range body {
+					sent = append(sent, b["object"].(string))
+				}
+			}
+			Expect(sent).To(Equal(refs))
+		})
+
+		It("should split the chunks rejected as too large", func() {
+			tooLarge := &RequestTooLargeError{WapiError{StatusCode: 413, Status: "413 Request Entity Too Large"}}
+			requestor := &bulkRequestor{errs: []error{tooLarge}}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			updated, err := objMgr.BulkUpdateEA(refs, EA{"Department": "Ops"}, nil)
+			Expect(err).To(BeNil())
+			Expect(updated).To(Equal(refs))
+
+			// the rejected chunk is not sent again through the proxy
+			Expect(len(requestor.bodies)).To(Equal(4))
+			Expect(len(requestor.bodies[1])).To(Equal(BulkUpdateChunkSize / 2))
+			Expect(requestor.bodies[2][0]["object"]).To(Equal(refs[BulkUpdateChunkSize/2]))
+			Expect(requestor.bodies[3][0]["object"]).To(Equal(refs[BulkUpdateChunkSize]))
+		})
+	})
+
+	Describe("RenameRecords", func() {
+		newRequestor := func() *renameRequestor {
+			return &renameRequestor{
+				zones: `[{"fqdn": "old.example.com", "view": "default", "zone_format": "FORWARD"},
+					{"fqdn": "10.0.0.0/24", "view": "default", "zone_format": "IPV4"}]`,
+				records: map[string]string{
+					"old.example.com": `[
+						{"name": "www", "type": "record:a", "record": "record:a/ZG5zLmJpbmRfYQ:www.old.example.com/default"},
+						{"name": "db", "type": "record:host", "record": "record:host/ZG5zLmhvc3Q:db.old.example.com/default"},
+						{"name": "", "type": "record:soa", "record": ""},
+						{"name": "mail", "type": "record:mx", "record": "record:mx/ZG5zLmJpbmRfbXg:mail.old.example.com/default"}]`,
+				},
+			}
+		}
+
+		It("should report the renames without applying them on a dry run", func() {
+			requestor := newRequestor()
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			changes, err := objMgr.RenameRecords("default", `^(www|db)\.old\.example\.com$`, "${1}.new.example.com", true)
+			Expect(err).To(BeNil())
+			Expect(changes).To(Equal([]RenameChange{
+				{Ref: "record:a/ZG5zLmJpbmRfYQ:www.old.example.com/default", Type: "record:a",
+					OldName: "www.old.example.com", NewName: "www.new.example.com"},
+				{Ref: "record:host/ZG5zLmhvc3Q:db.old.example.com/default", Type: "record:host",
+					OldName: "db.old.example.com", NewName: "db.new.example.com"},
+			}))
+			Expect(requestor.bodies).To(BeEmpty())
+		})
+
+		It("should rename the records in one request per chunk", func() {
+			requestor := newRequestor()
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			changes, err := objMgr.RenameRecords("default", `old\.example\.com$`, "new.example.com", false)
+			Expect(err).To(BeNil())
+			Expect(len(changes)).To(Equal(3))
+
+			Expect(len(requestor.bodies)).To(Equal(1))
+			Expect(requestor.bodies[0][2]).To(Equal(map[string]interface{}{
+				"method":  "PUT",
+				"object":  "record:mx/ZG5zLmJpbmRfbXg:mail.old.example.com/default",
+				"data":    map[string]interface{}{"name": "mail.new.example.com"},
+				"discard": true,
+			}))
+		})
+
+		It("should reject an invalid regular expression", func() {
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: newRequestor()}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.RenameRecords("default", `(`, "", true)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package ibclient
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Usages of the certificates of a CertificateExpiry
+const (
+	// CertificateUsageHTTPS is the certificate of the HTTPS service of a
+	// member, serving the GUI and WAPI
+	CertificateUsageHTTPS = "ADMIN"
+	// CertificateUsageCA is a CA certificate uploaded to the grid
+	CertificateUsageCA = "CA"
+)
+
+// CertificateExpiry is the validity of a certificate of the grid: the
+// HTTPS certificate of Member, or a CA certificate without Member. Err is
+// set instead when the certificate of the member could not be read.
+type CertificateExpiry struct {
+	Member    string
+	Usage     string
+	Subject   string
+	Issuer    string
+	Serial    string
+	NotBefore time.Time
+	NotAfter  time.Time
+	Err       error
+}
+
+// ExpiresWithin reports whether the certificate expires within d, or has
+// already expired
+func (c *CertificateExpiry) ExpiresWithin(d time.Duration) bool {
+	return c.Err == nil && time.Now().Add(d).After(c.NotAfter)
+}
+
+// parseCertificatePEM returns the first certificate of a PEM bundle
+func parseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no certificate found in PEM data")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// getMemberHTTPSCertificate downloads the HTTPS certificate of the member
+// hostName
+func (objMgr *ObjectManager) getMemberHTTPSCertificate(hostName string) (*x509.Certificate, error) {
+	var pemData bytes.Buffer
+	args := &fileopArgs{CertificateUsage: CertificateUsageHTTPS, Member: hostName}
+	if err := objMgr.download("downloadcertificate", args, &pemData); err != nil {
+		return nil, err
+	}
+
+	return parseCertificatePEM(pemData.Bytes())
+}
+
+// GetCACertificates returns the CA certificates uploaded to the grid
+func (objMgr *ObjectManager) GetCACertificates() ([]CACertificate, error) {
+	var res []CACertificate
+
+	err := objMgr.getObject(NewCACertificate(CACertificate{}), "", &res)
+	return res, err
+}
+
+// GetCertificateExpiries returns the validity of the HTTPS certificate of
+// each member of the grid, then of the CA certificates of the grid, to
+// renew them before they expire. A member whose certificate cannot be
+// downloaded is reported with the error and does not stop the report.
+func (objMgr *ObjectManager) GetCertificateExpiries() ([]CertificateExpiry, error) {
+	members, err := objMgr.withDefaultReturnFields().GetMembers(MemberFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]CertificateExpiry, 0, len(members))
+	for _, m := range members {
+		expiry := CertificateExpiry{Member: m.HostName, Usage: CertificateUsageHTTPS}
+		cert, err := objMgr.getMemberHTTPSCertificate(m.HostName)
+		if err != nil {
+			expiry.Err = fmt.Errorf("cannot read the HTTPS certificate of member '%s': %s", m.HostName, err)
+		} else {
+			expiry.Subject = cert.Subject.String()
+			expiry.Issuer = cert.Issuer.String()
+			expiry.Serial = cert.SerialNumber.String()
+			expiry.NotBefore = cert.NotBefore
+			expiry.NotAfter = cert.NotAfter
+		}
+		res = append(res, expiry)
+	}
+
+	caCerts, err := objMgr.withDefaultReturnFields().GetCACertificates()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range caCerts {
+		res = append(res, CertificateExpiry{
+			Usage:     CertificateUsageCA,
+			Subject:   c.DistinguishedName,
+			Issuer:    c.Issuer,
+			Serial:    c.Serial,
+			NotBefore: time.Unix(c.ValidNotBefore, 0),
+			NotAfter:  time.Unix(c.ValidNotAfter, 0)})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package ibclient
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager certificates", func() {
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443", Username: "admin", Password: "infoblox"}
+
+	It("should report the expiry of the HTTPS certificates of the members and of the CA certificates", func() {
+		dir, err := ioutil.TempDir("", "ibclient-cert")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		certFile, _, cert := writeClientCert(dir)
+		certPEM, err := ioutil.ReadFile(certFile)
+		Expect(err).To(BeNil())
+
+		requestor := &fileopRequestor{res: map[string]string{
+			"/wapi/v2.11/member": `[{"_ref": "member/b25lLnZpcnR1YWxfbm9kZSQw:gm.localdomain", "host_name": "gm.localdomain"}]`,
+			"downloadcertificate": `{"token": "eJylkMtOwzAQRf",
+				"url": "https://172.22.18.66/http_direct_file_io/req_id-DOWNLOAD-1/cert.pem"}`,
+			"/http_direct_file_io/req_id-DOWNLOAD-1/cert.pem": string(certPEM),
+			"downloadcomplete": `{}`,
+			"/wapi/v2.11/cacertificate": `[{"_ref": "cacertificate/b25lLmNlcnRpZmljYXRlJDE:CN%3D%22corp-ca%22",
+				"distinguished_name": "CN=\"corp-ca\"", "issuer": "CN=\"corp-ca\"", "serial": "2a",
+				"valid_not_before": 1577836800, "valid_not_after": 1893456000}]`,
+		}}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+		objMgr := NewObjectManager(conn, "Docker", "0123")
+
+		expiries, err := objMgr.GetCertificateExpiries()
+		Expect(err).To(BeNil())
+		Expect(expiries).To(HaveLen(2))
+
+		Expect(expiries[0].Member).To(Equal("gm.localdomain"))
+		Expect(expiries[0].Usage).To(Equal(CertificateUsageHTTPS))
+		Expect(expiries[0].Err).To(BeNil())
+		Expect(expiries[0].Subject).To(Equal("CN=ipam-controller"))
+		Expect(expiries[0].NotAfter.Equal(cert.NotAfter)).To(BeTrue())
+		Expect(expiries[0].ExpiresWithin(2 * time.Hour)).To(BeTrue())
+		Expect(expiries[0].ExpiresWithin(0)).To(BeFalse())
+
+		Expect(expiries[1].Member).To(Equal(""))
+		Expect(expiries[1].Usage).To(Equal(CertificateUsageCA))
+		Expect(expiries[1].Serial).To(Equal("2a"))
+		Expect(expiries[1].NotAfter.Unix()).To(BeEquivalentTo(1893456000))
+
+		Expect(requestor.functions).To(Equal([]string{"downloadcertificate", "downloadcomplete"}))
+		Expect(requestor.bodies[0]).To(MatchJSON(`{"certificate_usage": "ADMIN", "member": "gm.localdomain"}`))
+	})
+
+	It("should report the members whose certificate cannot be read", func() {
+		requestor := &fileopRequestor{res: map[string]string{
+			"/wapi/v2.11/member": `[{"_ref": "member/b25lLnZpcnR1YWxfbm9kZSQw:gm.localdomain", "host_name": "gm.localdomain"}]`,
+			"downloadcertificate": `{"token": "eJylkMtOwzAQRf",
+				"url": "https://172.22.18.66/http_direct_file_io/req_id-DOWNLOAD-1/cert.pem"}`,
+			"/http_direct_file_io/req_id-DOWNLOAD-1/cert.pem": "not a certificate",
+			"downloadcomplete":          `{}`,
+			"/wapi/v2.11/cacertificate": `[]`,
+		}}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+		objMgr := NewObjectManager(conn, "Docker", "0123")
+
+		expiries, err := objMgr.GetCertificateExpiries()
+		Expect(err).To(BeNil())
+		Expect(expiries).To(HaveLen(1))
+		Expect(expiries[0].Err).To(MatchError(
+			"cannot read the HTTPS certificate of member 'gm.localdomain': no certificate found in PEM data"))
+		Expect(expiries[0].ExpiresWithin(time.Hour)).To(BeFalse())
+	})
+})
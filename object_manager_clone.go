@@ -0,0 +1,82 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CloneNetwork creates the network newCidr in the network view of the
+// network referenced by srcRef, copying its comment, DHCP options and EAs.
+// An IPv6 network is cloned when srcRef references one.
+func (objMgr *ObjectManager) CloneNetwork(srcRef string, newCidr string) (*Network, error) {
+	newNetwork := NewNetwork
+	if strings.HasPrefix(srcRef, "ipv6network/") {
+		newNetwork = NewIPv6Network
+	}
+
+	src := newNetwork(Network{})
+	src.returnFields = append(src.returnFields, "comment", "options")
+	if err := objMgr.readObject(src, srcRef, &src); err != nil {
+		return nil, err
+	}
+
+	if objMgr.CheckNetworkOverlap {
+		if err := objMgr.checkNetworkOverlap(src.NetviewName, newCidr); err != nil {
+			return nil, err
+		}
+	}
+
+	network := newNetwork(Network{
+		NetviewName: src.NetviewName,
+		Cidr:        newCidr,
+		Options:     src.Options,
+		Comment:     src.Comment,
+		Ea:          src.Ea})
+
+	ref, err := objMgr.createObject(network)
+	if err != nil {
+		return nil, err
+	}
+	network.Ref = ref
+
+	return network, nil
+}
+
+// CloneHostRecord creates the host record newName with the address newIP,
+// in the views of the host record referenced by srcRef, copying its
+// comment, TTL, DNS setting and EAs. newIP may be a next available address
+// function. The addresses, MAC addresses and aliases of the source are not
+// copied as they belong to it.
+func (objMgr *ObjectManager) CloneHostRecord(srcRef string, newName string, newIP string) (*HostRecord, error) {
+	if newIP == "" {
+		return nil, fmt.Errorf("an address is required to clone host record '%s'", srcRef)
+	}
+
+	src := NewHostRecord(HostRecord{})
+	src.returnFields = append(append([]string{}, hostRecordReturnFields...), "configure_for_dns", "ttl", "use_ttl")
+	if err := objMgr.readObject(src, srcRef, &src); err != nil {
+		return nil, err
+	}
+
+	recordHost := NewHostRecord(HostRecord{
+		Name:        newName,
+		View:        src.View,
+		NetworkView: src.NetworkView,
+		EnableDns:   src.EnableDns,
+		Ttl:         src.Ttl,
+		UseTtl:      src.UseTtl,
+		Comment:     src.Comment,
+		Ea:          src.Ea})
+	if isIPv6Addr(newIP) {
+		recordHost.Ipv6Addrs = []HostRecordIpv6Addr{{Ipv6Addr: newIP}}
+	} else {
+		recordHost.Ipv4Addrs = []HostRecordIpv4Addr{{Ipv4Addr: newIP}}
+	}
+
+	ref, err := objMgr.createObject(recordHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.getHostRecordWithAddresses(ref)
+}
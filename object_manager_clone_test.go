@@ -0,0 +1,74 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Clone", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	requestBody := func(obj IBObject) string {
+		var body map[string]interface{}
+		js, _ := json.Marshal(obj)
+		json.Unmarshal(js, &body)
+		delete(body, "_ref")
+		js, _ = json.Marshal(body)
+		return string(js)
+	}
+
+	Describe("Clone Network", func() {
+		srcRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/dev"
+		newRef := "network/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMjQvMA:10.1.0.0/24/dev"
+		conn := &fakeMultiConnector{
+			createRefs: []string{newRef},
+			getResults: map[string]interface{}{
+				srcRef: Network{Ref: srcRef, NetviewName: "dev", Cidr: "10.0.0.0/24", Comment: "web tier",
+					Options: []DhcpOption{{Name: "routers", Num: 3, Value: "10.0.0.1"}},
+					Ea:      EA{"Site": "dc1"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the new network with the settings of the source", func() {
+			network, err := objMgr.CloneNetwork(srcRef, "10.1.0.0/24")
+			Expect(err).To(BeNil())
+			Expect(network.Ref).To(Equal(newRef))
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("options"))
+			Expect(requestBody(conn.createObjs[0])).To(MatchJSON(`{"network_view": "dev", "network": "10.1.0.0/24",
+				"comment": "web tier", "options": [{"name": "routers", "num": 3, "value": "10.0.0.1"}],
+				"extattrs": {"Site": {"value": "dc1"}}}`))
+		})
+	})
+
+	Describe("Clone Host Record", func() {
+		srcRef := "record:host/ZG5zLmhvc3Q:web.dev.example.com/default"
+		enableDNS := true
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				srcRef: HostRecord{Ref: srcRef, Name: "web.dev.example.com", View: "default",
+					NetworkView: "dev", EnableDns: &enableDNS, Ttl: 300, Comment: "web server",
+					Ipv4Addrs: []HostRecordIpv4Addr{{Ipv4Addr: "10.0.0.10", Mac: "11:22:33:44:55:66"}},
+					Aliases:   []string{"www.dev.example.com"},
+					Ea:        EA{"Site": "dc1"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the new record with the settings but not the addresses of the source", func() {
+			_, err := objMgr.CloneHostRecord(srcRef, "web.staging.example.com", "10.1.0.10")
+			Expect(err).To(BeNil())
+			Expect(requestBody(conn.createObjs[0])).To(MatchJSON(`{"name": "web.staging.example.com",
+				"view": "default", "network_view": "dev", "configure_for_dns": true, "ttl": 300,
+				"comment": "web server", "ipv4addrs": [{"ipv4addr": "10.1.0.10"}],
+				"extattrs": {"Site": {"value": "dc1"}}}`))
+		})
+
+		It("should require an address", func() {
+			_, err := objMgr.CloneHostRecord(srcRef, "web.staging.example.com", "")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package ibclient
+
+// cnameConflictTypes are the record types which cannot share their name
+// with a CNAME record
+var cnameConflictTypes = []string{"record:a", "record:aaaa", "record:txt", "record:host"}
+
+// recordRefsByName returns the refs of the records of objectType named
+// name in the DNS view view, in all views if view is empty
+func (objMgr *ObjectManager) recordRefsByName(objectType string, name string, view string) ([]string, error) {
+	var res []recordName
+
+	obj := &recordName{IBBase: IBBase{objectType: objectType, returnFields: []string{"name"}},
+		Name: name, View: view}
+	if err := objMgr.readObject(obj, "", &res); err != nil {
+		return nil, err
+	}
+
+	refs := make([]string, 0, len(res))
+	for _, r := range res {
+		refs = append(refs, r.Ref)
+	}
+	return refs, nil
+}
+
+// recordName searches the records of any type by name and view
+type recordName struct {
+	IBBase `json:"-"`
+	Ref    string `json:"_ref,omitempty"`
+	Name   string `json:"name"`
+	View   string `json:"view,omitempty"`
+}
+
+// checkRecordConflict returns a *RecordConflictError if a record of
+// objectType named name would conflict with existing records of the view:
+// a CNAME record with A, AAAA, TXT or host records, the others with a
+// CNAME record. It does nothing unless CheckRecordConflicts is set.
+func (objMgr *ObjectManager) checkRecordConflict(objectType string, name string, view string) error {
+	if !objMgr.CheckRecordConflicts {
+		return nil
+	}
+
+	conflictTypes := []string{"record:cname"}
+	if objectType == "record:cname" {
+		conflictTypes = cnameConflictTypes
+	}
+
+	var conflicts []string
+	for _, t := range conflictTypes {
+		refs, err := objMgr.recordRefsByName(t, name, view)
+		if err != nil {
+			return err
+		}
+		conflicts = append(conflicts, refs...)
+	}
+
+	if len(conflicts) > 0 {
+		return &RecordConflictError{ObjectType: objectType, Name: name, View: view, Conflicts: conflicts}
+	}
+	return nil
+}
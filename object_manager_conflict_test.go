@@ -0,0 +1,70 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager record conflicts", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	aRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsd3d3LDEwLjAuMC41:www.example.com/default"
+	txtRef := "record:txt/ZG5zLmJpbmRfdHh0JC5fZGVmYXVsdC5jb20uZXhhbXBsZS53d3c:www.example.com/default"
+	cnameRef := "record:cname/ZG5zLmJpbmRfY25hbWUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLnd3dw:www.example.com/default"
+
+	It("should not create a CNAME record at the name of other records", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:a":   []RecordA{{Ref: aRef, Name: "www.example.com"}},
+				"record:txt": []RecordTXT{{Ref: txtRef, Name: "www.example.com"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.CheckRecordConflicts = true
+
+		_, err := objMgr.CreateCNAMERecord("web.example.com", "www.example.com", "default")
+		Expect(errors.Is(err, ErrRecordConflict)).To(BeTrue())
+		var conflictErr *RecordConflictError
+		Expect(errors.As(err, &conflictErr)).To(BeTrue())
+		Expect(conflictErr.Conflicts).To(Equal([]string{aRef, txtRef}))
+		Expect(conn.createObjs).To(BeEmpty())
+
+		js, _ := json.Marshal(conn.getObjs[0])
+		Expect(js).To(MatchJSON(`{"name": "www.example.com", "view": "default"}`))
+		Expect(conn.getObjs).To(HaveLen(4))
+		Expect(conn.getObjs[3].ObjectType()).To(Equal("record:host"))
+	})
+
+	It("should not create records at the name of a CNAME record", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:cname": []RecordCNAME{{Ref: cnameRef, Name: "www.example.com"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.CheckRecordConflicts = true
+
+		_, err := objMgr.CreateARecord("default", "default", "www.example.com", "", "10.0.0.5", "", "")
+		Expect(err).To(Equal(&RecordConflictError{ObjectType: "record:a", Name: "www.example.com",
+			View: "default", Conflicts: []string{cnameRef}}))
+		_, err = objMgr.CreateTXTRecord("www.example.com", "v=spf1 -all", "default", RecordOptions{})
+		Expect(errors.Is(err, ErrRecordConflict)).To(BeTrue())
+		Expect(conn.createObjs).To(BeEmpty())
+
+		// a host record without DNS has no name in the zone
+		_, err = objMgr.CreateHostRecord(false, "www.example.com", "default", "default", "", "10.0.0.5", "", "", "")
+		Expect(err).To(BeNil())
+		Expect(conn.createObjs).To(HaveLen(1))
+	})
+
+	It("should not check the conflicts unless asked to", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:a": []RecordA{{Ref: aRef, Name: "www.example.com"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, err := objMgr.CreateCNAMERecord("web.example.com", "www.example.com", "default")
+		Expect(err).To(BeNil())
+		Expect(conn.getObjs).To(BeEmpty())
+		Expect(conn.createObjs).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,166 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func isIPv6ContainerRef(ref string) bool {
+	return strings.HasPrefix(ref, "ipv6networkcontainer/")
+}
+
+// GetNetworkContainerByRef returns the network container, IPv4 or IPv6,
+// referenced by ref
+func (objMgr *ObjectManager) GetNetworkContainerByRef(ref string) (*NetworkContainer, error) {
+	newContainer := NewNetworkContainer
+	if isIPv6ContainerRef(ref) {
+		newContainer = NewIPv6NetworkContainer
+	}
+
+	container := newContainer(NetworkContainer{})
+	container.returnFields = append(container.returnFields, "comment", "network_container")
+	err := objMgr.getObject(container, ref, &container)
+	return container, err
+}
+
+// AllocateNetworkFromContainer creates the next available network of
+// prefixLen in the network container referenced by containerRef
+func (objMgr *ObjectManager) AllocateNetworkFromContainer(containerRef string, prefixLen uint, name string) (*Network, error) {
+	container, err := objMgr.withDefaultReturnFields().GetNetworkContainerByRef(containerRef)
+	if err != nil {
+		return nil, err
+	}
+	if container.Cidr == "" {
+		return nil, fmt.Errorf("network container '%s' not found", containerRef)
+	}
+
+	if isIPv6ContainerRef(containerRef) {
+		return objMgr.AllocateIPv6Network(container.NetviewName, container.Cidr, prefixLen, name)
+	}
+	return objMgr.AllocateNetwork(container.NetviewName, container.Cidr, prefixLen, name)
+}
+
+// UpdateNetworkContainer updates the comment and the EAs of the network
+// container referenced by ref. The network view and network of a container
+// cannot be changed and are ignored.
+func (objMgr *ObjectManager) UpdateNetworkContainer(ref string, nc NetworkContainer) (*NetworkContainer, error) {
+	newContainer := NewNetworkContainer
+	if isIPv6ContainerRef(ref) {
+		newContainer = NewIPv6NetworkContainer
+	}
+
+	updateContainer := newContainer(NetworkContainer{
+		Comment: nc.Comment,
+		Ea:      nc.Ea})
+
+	newRef, err := objMgr.updateObject(updateContainer, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetNetworkContainerByRef(newRef)
+}
+
+// GetNetworkContainerChildren returns the networks and the network
+// containers of the network container referenced by ref, and of its child
+// containers if recursive is true
+func (objMgr *ObjectManager) GetNetworkContainerChildren(ref string, recursive bool) ([]Network, []NetworkContainer, error) {
+	container, err := objMgr.withDefaultReturnFields().GetNetworkContainerByRef(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newNetwork, newContainer := NewNetwork, NewNetworkContainer
+	if isIPv6ContainerRef(ref) {
+		newNetwork, newContainer = NewIPv6Network, NewIPv6NetworkContainer
+	}
+
+	var networks []Network
+	var containers []NetworkContainer
+	parents := []string{container.Cidr}
+	for len(parents) > 0 {
+		parent := parents[0]
+		parents = parents[1:]
+
+		var nets []Network
+		network := newNetwork(Network{NetviewName: container.NetviewName, Parent: parent})
+		network.returnFields = append(network.returnFields, "network_container")
+		if err = objMgr.getObjectPaged(network, 0, &nets); err != nil {
+			return nil, nil, err
+		}
+		networks = append(networks, nets...)
+
+		var ncs []NetworkContainer
+		nc := newContainer(NetworkContainer{NetviewName: container.NetviewName, Parent: parent})
+		nc.returnFields = append(nc.returnFields, "network_container")
+		if err = objMgr.getObjectPaged(nc, 0, &ncs); err != nil {
+			return nil, nil, err
+		}
+		containers = append(containers, ncs...)
+
+		if recursive {
+			for _, c := range ncs {
+				parents = append(parents, c.Cidr)
+			}
+		}
+	}
+
+	return networks, containers, nil
+}
+
+// DeleteNetworkContainer deletes the network container referenced by ref.
+// With DeleteNetworkIfEmpty a container holding networks or containers is
+// not deleted and a *NotEmptyError is returned; with DeleteNetworkCascade
+// the grid deletes them along with the container; with
+// DeleteNetworkReparent the grid moves them to the parent container.
+func (objMgr *ObjectManager) DeleteNetworkContainer(ref string, policy DeleteNetworkPolicy) (string, error) {
+	if policy == DeleteNetworkReparent {
+		return objMgr.deleteKeepingSubnets(ref)
+	}
+	if policy != DeleteNetworkCascade {
+		networks, containers, err := objMgr.withDefaultReturnFields().GetNetworkContainerChildren(ref, false)
+		if err != nil {
+			return "", err
+		}
+
+		var children []string
+		for _, n := range networks {
+			children = append(children, n.Ref)
+		}
+		for _, c := range containers {
+			children = append(children, c.Ref)
+		}
+		if len(children) > 0 {
+			return "", &NotEmptyError{Ref: ref, Children: children}
+		}
+	}
+
+	return objMgr.deleteObject(ref)
+}
+
+// deleteKeepingSubnets deletes the network container referenced by ref,
+// the grid moving its networks and containers to the parent container.
+// The delete is sent through the request object, which passes the
+// remove_subnets argument of the delete.
+func (objMgr *ObjectManager) deleteKeepingSubnets(ref string) (string, error) {
+	req := NewMultiRequest([]*RequestBody{{
+		Method: "DELETE",
+		Object: ref,
+		Args:   map[string]string{"remove_subnets": "false"},
+	}})
+
+	resp, err := objMgr.multiRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	var refs []string
+	if err = json.Unmarshal(resp, &refs); err != nil {
+		return "", err
+	}
+	if len(refs) != 1 {
+		return "", fmt.Errorf("delete of '%s' returned %d results", ref, len(refs))
+	}
+	return refs[0], nil
+}
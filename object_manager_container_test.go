@@ -0,0 +1,132 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// containerConnector returns the children of the network container
+// searched for
+type containerConnector struct {
+	fakeMultiConnector
+}
+
+func (c *containerConnector) GetObject(obj IBObject, ref string, res interface{}) error {
+	switch o := obj.(type) {
+	case *Network:
+		ref = o.ObjectType() + "@" + o.Parent
+	case *NetworkContainer:
+		if ref == "" {
+			ref = o.ObjectType() + "@" + o.Parent
+		}
+	}
+
+	return c.fakeMultiConnector.GetObject(obj, ref, res)
+}
+
+var _ = Describe("Object Manager Network Containers", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	rootRef := "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEwLjAuMC4wLzgvMA:10.0.0.0/8/default"
+	childRef := "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEwLjEuMC4wLzE2LzA:10.1.0.0/16/default"
+	newConn := func() *containerConnector {
+		return &containerConnector{fakeMultiConnector{
+			getResults: map[string]interface{}{
+				rootRef: NetworkContainer{Ref: rootRef, NetviewName: "default", Cidr: "10.0.0.0/8", Parent: "/"},
+				"network@10.0.0.0/8": []Network{
+					{Ref: "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default", Cidr: "10.0.0.0/24"}},
+				"networkcontainer@10.0.0.0/8": []NetworkContainer{
+					{Ref: childRef, NetviewName: "default", Cidr: "10.1.0.0/16"}},
+				"network@10.1.0.0/16": []Network{
+					{Ref: "network/ZG5zLm5ldHdvcmskMTAuMS4wLjAvMjQvMA:10.1.0.0/24/default", Cidr: "10.1.0.0/24"}},
+			},
+		}}
+	}
+
+	Describe("Allocate Network From Container", func() {
+		conn := newConn()
+		conn.createRefs = []string{"network/ZG5zLm5ldHdvcmskMTAuMi4wLjAvMjQvMA:10.2.0.0/24/default"}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should allocate the next available network of the container", func() {
+			network, err := objMgr.AllocateNetworkFromContainer(rootRef, 24, "app")
+			Expect(err).To(BeNil())
+			Expect(network.Cidr).To(Equal("10.2.0.0/24"))
+			Expect(conn.createObjs[0].(*Network).Cidr).To(Equal("func:nextavailablenetwork:10.0.0.0/8,default,24"))
+		})
+	})
+
+	Describe("Get Network Container Children", func() {
+		It("should list the direct children", func() {
+			objMgr := NewObjectManager(newConn(), cmpType, tenantID)
+			networks, containers, err := objMgr.GetNetworkContainerChildren(rootRef, false)
+			Expect(err).To(BeNil())
+			Expect(len(networks)).To(Equal(1))
+			Expect(containers[0].Ref).To(Equal(childRef))
+		})
+
+		It("should list the children of the child containers when recursive", func() {
+			objMgr := NewObjectManager(newConn(), cmpType, tenantID)
+			networks, containers, err := objMgr.GetNetworkContainerChildren(rootRef, true)
+			Expect(err).To(BeNil())
+			Expect(len(networks)).To(Equal(2))
+			Expect(networks[1].Cidr).To(Equal("10.1.0.0/24"))
+			Expect(len(containers)).To(Equal(1))
+		})
+	})
+
+	Describe("Update Network Container", func() {
+		conn := newConn()
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should only send the comment and the EAs", func() {
+			_, err := objMgr.UpdateNetworkContainer(rootRef, NetworkContainer{
+				NetviewName: "other", Cidr: "10.0.0.0/7", Comment: "datacenter", Ea: EA{"Site": "dc1"}})
+			Expect(err).To(BeNil())
+
+			updated := conn.updateObjs[0].(*NetworkContainer)
+			Expect(updated.Cidr).To(BeEmpty())
+			Expect(updated.NetviewName).To(BeEmpty())
+			Expect(updated.Comment).To(Equal("datacenter"))
+			Expect(conn.updateRefs).To(Equal([]string{rootRef}))
+		})
+	})
+
+	Describe("Delete Network Container", func() {
+		It("should refuse to delete a container with children", func() {
+			conn := newConn()
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.DeleteNetworkContainer(rootRef, DeleteNetworkIfEmpty)
+			notEmpty, ok := err.(*NotEmptyError)
+			Expect(ok).To(BeTrue())
+			Expect(notEmpty.Children).To(ContainElement(childRef))
+			Expect(conn.deleteRefs).To(BeEmpty())
+		})
+
+		It("should delete the container and its children on cascade", func() {
+			conn := newConn()
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			ref, err := objMgr.DeleteNetworkContainer(rootRef, DeleteNetworkCascade)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(rootRef))
+			Expect(conn.deleteRefs).To(Equal([]string{rootRef}))
+		})
+
+		It("should move the children to the parent container when reparenting", func() {
+			requestor := &multiRequestor{res: []byte(`["` + rootRef + `"]`)}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			ref, err := objMgr.DeleteNetworkContainer(rootRef, DeleteNetworkReparent)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(rootRef))
+			Expect(requestor.body).To(Equal([]map[string]interface{}{{
+				"method": "DELETE",
+				"object": rootRef,
+				"args":   map[string]interface{}{"remove_subnets": "false"},
+			}}))
+		})
+	})
+})
@@ -0,0 +1,111 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dhcpOptions holds the DHCP options of a network, a range or a fixed
+// address, an empty list removes them all
+type dhcpOptions struct {
+	IBBase  `json:"-"`
+	Options []DhcpOption `json:"options"`
+}
+
+// dhcpBootParameters holds the boot parameters of a network, a range or a
+// fixed address
+type dhcpBootParameters struct {
+	IBBase        `json:"-"`
+	Bootfile      string `json:"bootfile,omitempty"`
+	UseBootfile   *bool  `json:"use_bootfile,omitempty"`
+	Nextserver    string `json:"nextserver,omitempty"`
+	UseNextserver *bool  `json:"use_nextserver,omitempty"`
+	Bootserver    string `json:"bootserver,omitempty"`
+	UseBootserver *bool  `json:"use_bootserver,omitempty"`
+}
+
+// dhcpObjectType returns the object type of ref, which must be a network, a
+// range or a fixed address
+func dhcpObjectType(ref string) (string, error) {
+	objType := strings.SplitN(ref, "/", 2)[0]
+	switch objType {
+	case "network", "ipv6network", "range", "ipv6range", "fixedaddress", "ipv6fixedaddress":
+		return objType, nil
+	}
+
+	return "", fmt.Errorf("'%s' is not a network, a range or a fixed address", ref)
+}
+
+// SetDhcpOptions replaces the DHCP options of the network, range or fixed
+// address referenced by ref, e.g. {Name: "routers", Value: "10.0.0.1"}.
+// Options inherited from the parent object, such as routers or
+// domain-name-servers, are only overridden when their UseOption is true.
+func (objMgr *ObjectManager) SetDhcpOptions(ref string, options []DhcpOption) (string, error) {
+	objType, err := dhcpObjectType(ref)
+	if err != nil {
+		return "", err
+	}
+	if options == nil {
+		options = []DhcpOption{}
+	}
+
+	update := &dhcpOptions{IBBase: IBBase{objectType: objType}, Options: options}
+	return objMgr.updateObject(update, ref)
+}
+
+// MergeDhcpOptions sets options on the network, range or fixed address
+// referenced by ref, keeping its other options. An option replaces the one
+// with the same name, or number, and vendor class.
+func (objMgr *ObjectManager) MergeDhcpOptions(ref string, options []DhcpOption) (string, error) {
+	objType, err := dhcpObjectType(ref)
+	if err != nil {
+		return "", err
+	}
+
+	current := &dhcpOptions{IBBase: IBBase{objectType: objType, returnFields: []string{"options"}}}
+	if err = objMgr.connector.GetObject(current, ref, current); err != nil {
+		return "", err
+	}
+
+	merged := current.Options
+	for _, opt := range options {
+		replaced := false
+		for i, cur := range merged {
+			if cur.VendorClass == opt.VendorClass &&
+				((opt.Name != "" && cur.Name == opt.Name) || (opt.Num != 0 && cur.Num == opt.Num)) {
+				merged[i] = opt
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, opt)
+		}
+	}
+
+	return objMgr.SetDhcpOptions(ref, merged)
+}
+
+// SetDhcpBootParameters sets the PXE boot file, next server and boot
+// server of the network, range or fixed address referenced by ref. Empty
+// parameters are left unchanged.
+func (objMgr *ObjectManager) SetDhcpBootParameters(ref string, bootfile string, nextserver string, bootserver string) (string, error) {
+	objType, err := dhcpObjectType(ref)
+	if err != nil {
+		return "", err
+	}
+
+	use := true
+	update := &dhcpBootParameters{IBBase: IBBase{objectType: objType}}
+	if bootfile != "" {
+		update.Bootfile, update.UseBootfile = bootfile, &use
+	}
+	if nextserver != "" {
+		update.Nextserver, update.UseNextserver = nextserver, &use
+	}
+	if bootserver != "" {
+		update.Bootserver, update.UseBootserver = bootserver, &use
+	}
+
+	return objMgr.updateObject(update, ref)
+}
@@ -0,0 +1,86 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager DHCP Options", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	networkRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+	useOption := true
+
+	Describe("Set DHCP Options", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should replace the options with their use flags", func() {
+			_, err := objMgr.SetDhcpOptions(networkRef, []DhcpOption{
+				{Name: "routers", Num: 3, Value: "10.0.0.1", UseOption: &useOption},
+				{Name: "dhcp-lease-time", Num: 51, Value: "3600"}})
+			Expect(err).To(BeNil())
+			Expect(conn.updateObjs[0].ObjectType()).To(Equal("network"))
+
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"options": [
+				{"name": "routers", "num": 3, "value": "10.0.0.1", "use_option": true},
+				{"name": "dhcp-lease-time", "num": 51, "value": "3600"}]}`))
+		})
+
+		It("should send an empty list to remove the options", func() {
+			_, err := objMgr.SetDhcpOptions(networkRef, nil)
+			Expect(err).To(BeNil())
+			js, _ := json.Marshal(conn.updateObjs[1])
+			Expect(js).To(MatchJSON(`{"options": []}`))
+		})
+
+		It("should reject other objects", func() {
+			_, err := objMgr.SetDhcpOptions("record:a/ZG5zLmJpbmRfYQ:www.example.com/default", nil)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Merge DHCP Options", func() {
+		fixedAddrRef := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.5/default"
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				fixedAddrRef: FixedAddress{Ref: fixedAddrRef, Options: []DhcpOption{
+					{Name: "routers", Num: 3, Value: "10.0.0.1", UseOption: &useOption},
+					{Name: "domain-name", Num: 15, Value: "example.com"}}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should replace the options with the same name and keep the others", func() {
+			_, err := objMgr.MergeDhcpOptions(fixedAddrRef, []DhcpOption{
+				{Name: "routers", Value: "10.0.0.254", UseOption: &useOption},
+				{Name: "domain-name-servers", Value: "10.0.0.53", UseOption: &useOption}})
+			Expect(err).To(BeNil())
+			Expect(conn.getObjs[0].ReturnFields()).To(Equal([]string{"options"}))
+
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"options": [
+				{"name": "routers", "value": "10.0.0.254", "use_option": true},
+				{"name": "domain-name", "num": 15, "value": "example.com"},
+				{"name": "domain-name-servers", "value": "10.0.0.53", "use_option": true}]}`))
+		})
+	})
+
+	Describe("Set DHCP Boot Parameters", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should set the given parameters with their use flags", func() {
+			_, err := objMgr.SetDhcpBootParameters("range/ZG5zLmRoY3BfcmFuZ2Uk:10.0.0.10/10.0.0.50/default",
+				"pxelinux.0", "10.0.0.2", "")
+			Expect(err).To(BeNil())
+
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"bootfile": "pxelinux.0", "use_bootfile": true,
+				"nextserver": "10.0.0.2", "use_nextserver": true}`))
+		})
+	})
+})
@@ -0,0 +1,368 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dtcEA merges the user supplied EAs of a DTC object over the cloud EAs. If
+// omitEmpty is true nil is returned when ea is nil, leaving the EAs of an
+// updated object unchanged.
+func (objMgr *ObjectManager) dtcEA(ea EA, omitEmpty bool) EA {
+	return objMgr.recordEA(RecordOptions{Ea: ea}, omitEmpty)
+}
+
+// CreateDtcServer creates a DTC server
+func (objMgr *ObjectManager) CreateDtcServer(ds DtcServer) (*DtcServer, error) {
+	server := NewDtcServer(ds)
+	server.Ea = objMgr.dtcEA(ds.Ea, false)
+
+	ref, err := objMgr.createObject(server)
+	server.Ref = ref
+
+	return server, err
+}
+
+func (objMgr *ObjectManager) GetDtcServerByRef(ref string) (*DtcServer, error) {
+	server := NewDtcServer(DtcServer{})
+	err := objMgr.getObject(server, ref, &server)
+	return server, err
+}
+
+func (objMgr *ObjectManager) GetDtcServerByName(name string) (*DtcServer, error) {
+	var res []DtcServer
+
+	server := NewDtcServer(DtcServer{Name: name})
+	err := objMgr.getObject(server, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateDtcServer updates the DTC server referenced by ref, empty values
+// leave the server unchanged
+func (objMgr *ObjectManager) UpdateDtcServer(ref string, ds DtcServer) (*DtcServer, error) {
+	server := NewDtcServer(ds)
+	server.Ref = ""
+	server.Ea = objMgr.dtcEA(ds.Ea, true)
+
+	newRef, err := objMgr.updateObject(server, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetDtcServerByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteDtcServer(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateDtcPool creates a DTC pool of Servers checked by Monitors
+func (objMgr *ObjectManager) CreateDtcPool(dp DtcPool) (*DtcPool, error) {
+	pool := NewDtcPool(dp)
+	pool.Ea = objMgr.dtcEA(dp.Ea, false)
+
+	ref, err := objMgr.createObject(pool)
+	pool.Ref = ref
+
+	return pool, err
+}
+
+func (objMgr *ObjectManager) GetDtcPoolByRef(ref string) (*DtcPool, error) {
+	pool := NewDtcPool(DtcPool{})
+	err := objMgr.getObject(pool, ref, &pool)
+	return pool, err
+}
+
+func (objMgr *ObjectManager) GetDtcPoolByName(name string) (*DtcPool, error) {
+	var res []DtcPool
+
+	pool := NewDtcPool(DtcPool{Name: name})
+	err := objMgr.getObject(pool, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateDtcPool updates the DTC pool referenced by ref, empty values and
+// lists leave the pool unchanged
+func (objMgr *ObjectManager) UpdateDtcPool(ref string, dp DtcPool) (*DtcPool, error) {
+	pool := NewDtcPool(dp)
+	pool.Ref = ""
+	pool.Ea = objMgr.dtcEA(dp.Ea, true)
+
+	newRef, err := objMgr.updateObject(pool, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetDtcPoolByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteDtcPool(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateDtcLbdn creates a DTC LBDN answering with the servers of Pools
+// validateLbdnTypes checks the record types served by a DTC LBDN
+func validateLbdnTypes(types []RecordType) error {
+	for _, t := range types {
+		switch t {
+		case RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeNAPTR, RecordTypeSRV:
+		default:
+			return fmt.Errorf("record type '%s' cannot be served by a DTC LBDN", t)
+		}
+	}
+	return nil
+}
+
+func (objMgr *ObjectManager) CreateDtcLbdn(dl DtcLbdn) (*DtcLbdn, error) {
+	if err := validateLbdnTypes(dl.Types); err != nil {
+		return nil, err
+	}
+	lbdn := NewDtcLbdn(dl)
+	lbdn.Ea = objMgr.dtcEA(dl.Ea, false)
+
+	ref, err := objMgr.createObject(lbdn)
+	lbdn.Ref = ref
+
+	return lbdn, err
+}
+
+func (objMgr *ObjectManager) GetDtcLbdnByRef(ref string) (*DtcLbdn, error) {
+	lbdn := NewDtcLbdn(DtcLbdn{})
+	err := objMgr.getObject(lbdn, ref, &lbdn)
+	return lbdn, err
+}
+
+func (objMgr *ObjectManager) GetDtcLbdnByName(name string) (*DtcLbdn, error) {
+	var res []DtcLbdn
+
+	lbdn := NewDtcLbdn(DtcLbdn{Name: name})
+	err := objMgr.getObject(lbdn, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateDtcLbdn updates the DTC LBDN referenced by ref, empty values and
+// lists leave the LBDN unchanged
+func (objMgr *ObjectManager) UpdateDtcLbdn(ref string, dl DtcLbdn) (*DtcLbdn, error) {
+	if err := validateLbdnTypes(dl.Types); err != nil {
+		return nil, err
+	}
+	lbdn := NewDtcLbdn(dl)
+	lbdn.Ref = ""
+	lbdn.Ea = objMgr.dtcEA(dl.Ea, true)
+
+	newRef, err := objMgr.updateObject(lbdn, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetDtcLbdnByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteDtcLbdn(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// dtcMonitorType returns the monitor type of ref, e.g. "http" for
+// dtc:monitor:http/ZG5zLmlkbnNfbW9uaXRvcl9odHRwJGh0dHA:http
+func dtcMonitorType(ref string) (string, error) {
+	objType := strings.SplitN(ref, "/", 2)[0]
+	if !strings.HasPrefix(objType, "dtc:monitor:") {
+		return "", fmt.Errorf("'%s' is not a DTC monitor", ref)
+	}
+
+	return strings.TrimPrefix(objType, "dtc:monitor:"), nil
+}
+
+// CreateDtcMonitor creates a DTC health monitor of dm.Type
+func (objMgr *ObjectManager) CreateDtcMonitor(dm DtcMonitor) (*DtcMonitor, error) {
+	if dm.Type == "" {
+		return nil, fmt.Errorf("the type of DTC monitor '%s' is required", dm.Name)
+	}
+	monitor := NewDtcMonitor(dm)
+	monitor.Ea = objMgr.dtcEA(dm.Ea, false)
+
+	ref, err := objMgr.createObject(monitor)
+	monitor.Ref = ref
+
+	return monitor, err
+}
+
+func (objMgr *ObjectManager) GetDtcMonitorByRef(ref string) (*DtcMonitor, error) {
+	monitorType, err := dtcMonitorType(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor := NewDtcMonitor(DtcMonitor{Type: monitorType})
+	err = objMgr.getObject(monitor, ref, &monitor)
+	monitor.Type = monitorType
+	return monitor, err
+}
+
+func (objMgr *ObjectManager) GetDtcMonitorByName(monitorType string, name string) (*DtcMonitor, error) {
+	var res []DtcMonitor
+
+	monitor := NewDtcMonitor(DtcMonitor{Type: monitorType, Name: name})
+	err := objMgr.getObject(monitor, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	res[0].Type = monitorType
+	return &res[0], nil
+}
+
+// UpdateDtcMonitor updates the DTC monitor referenced by ref, empty values
+// leave the monitor unchanged. The type of a monitor cannot be changed.
+func (objMgr *ObjectManager) UpdateDtcMonitor(ref string, dm DtcMonitor) (*DtcMonitor, error) {
+	monitorType, err := dtcMonitorType(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	dm.Type = monitorType
+	monitor := NewDtcMonitor(dm)
+	monitor.Ref = ""
+	monitor.Ea = objMgr.dtcEA(dm.Ea, true)
+
+	newRef, err := objMgr.updateObject(monitor, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetDtcMonitorByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteDtcMonitor(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// dtcLbdnPools replaces the pools of an LBDN, an empty list removes them all
+type dtcLbdnPools struct {
+	IBBase `json:"-"`
+	Pools  []DtcPoolLink `json:"pools"`
+}
+
+// dtcPoolMonitors replaces the monitors of a pool, an empty list removes
+// them all
+type dtcPoolMonitors struct {
+	IBBase   `json:"-"`
+	Monitors []string `json:"monitors"`
+}
+
+func (objMgr *ObjectManager) setLbdnPools(lbdnRef string, pools []DtcPoolLink) (*DtcLbdn, error) {
+	if pools == nil {
+		pools = []DtcPoolLink{}
+	}
+
+	update := &dtcLbdnPools{IBBase: IBBase{objectType: "dtc:lbdn"}, Pools: pools}
+	newRef, err := objMgr.updateObject(update, lbdnRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetDtcLbdnByRef(newRef)
+}
+
+// AddPoolToLbdn adds the DTC pool referenced by poolRef to the LBDN
+// referenced by lbdnRef with ratio, or sets the ratio of the pool if the
+// LBDN already has it
+func (objMgr *ObjectManager) AddPoolToLbdn(lbdnRef string, poolRef string, ratio uint32) (*DtcLbdn, error) {
+	lbdn, err := objMgr.withDefaultReturnFields().GetDtcLbdnByRef(lbdnRef)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := lbdn.Pools
+	found := false
+	for i := range pools {
+		if pools[i].Pool == poolRef {
+			pools[i].Ratio = ratio
+			found = true
+		}
+	}
+	if !found {
+		pools = append(pools, DtcPoolLink{Pool: poolRef, Ratio: ratio})
+	}
+
+	return objMgr.setLbdnPools(lbdnRef, pools)
+}
+
+// RemovePoolFromLbdn removes the DTC pool referenced by poolRef from the
+// LBDN referenced by lbdnRef
+func (objMgr *ObjectManager) RemovePoolFromLbdn(lbdnRef string, poolRef string) (*DtcLbdn, error) {
+	lbdn, err := objMgr.withDefaultReturnFields().GetDtcLbdnByRef(lbdnRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []DtcPoolLink
+	for _, p := range lbdn.Pools {
+		if p.Pool != poolRef {
+			pools = append(pools, p)
+		}
+	}
+
+	return objMgr.setLbdnPools(lbdnRef, pools)
+}
+
+func (objMgr *ObjectManager) setPoolMonitors(poolRef string, monitors []string) (*DtcPool, error) {
+	if monitors == nil {
+		monitors = []string{}
+	}
+
+	update := &dtcPoolMonitors{IBBase: IBBase{objectType: "dtc:pool"}, Monitors: monitors}
+	newRef, err := objMgr.updateObject(update, poolRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetDtcPoolByRef(newRef)
+}
+
+// AddMonitorToPool makes the DTC monitor referenced by monitorRef check the
+// servers of the pool referenced by poolRef
+func (objMgr *ObjectManager) AddMonitorToPool(poolRef string, monitorRef string) (*DtcPool, error) {
+	pool, err := objMgr.withDefaultReturnFields().GetDtcPoolByRef(poolRef)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range pool.Monitors {
+		if m == monitorRef {
+			return pool, nil
+		}
+	}
+
+	return objMgr.setPoolMonitors(poolRef, append(pool.Monitors, monitorRef))
+}
+
+// RemoveMonitorFromPool stops the DTC monitor referenced by monitorRef
+// from checking the servers of the pool referenced by poolRef
+func (objMgr *ObjectManager) RemoveMonitorFromPool(poolRef string, monitorRef string) (*DtcPool, error) {
+	pool, err := objMgr.withDefaultReturnFields().GetDtcPoolByRef(poolRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var monitors []string
+	for _, m := range pool.Monitors {
+		if m != monitorRef {
+			monitors = append(monitors, m)
+		}
+	}
+
+	return objMgr.setPoolMonitors(poolRef, monitors)
+}
@@ -0,0 +1,125 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager DTC", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	serverRef := "dtc:server/ZG5zLmlkbnNfc2VydmVyJHdlYjE:web1"
+	poolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCR3ZWI:web"
+	lbdnRef := "dtc:lbdn/ZG5zLmlkbnNfbGJkbiR3d3c:www"
+	monitorRef := "dtc:monitor:http/ZG5zLmlkbnNfbW9uaXRvcl9odHRwJGh0dHA:http"
+
+	Describe("Create DTC objects", func() {
+		It("should create servers, pools and LBDNs with the cloud EAs", func() {
+			conn := &fakeMultiConnector{createRefs: []string{serverRef, poolRef, lbdnRef}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			objMgr.OmitCloudAttrs = false
+
+			server, err := objMgr.CreateDtcServer(DtcServer{Name: "web1", Host: "10.0.0.10", Ea: EA{"Site": "Lab"}})
+			Expect(err).To(BeNil())
+			Expect(server.Ref).To(Equal(serverRef))
+			js, _ := json.Marshal(conn.createObjs[0])
+			Expect(js).To(MatchJSON(`{"_ref": "` + serverRef + `", "name": "web1", "host": "10.0.0.10", "extattrs": {
+				"Cloud API Owned": {"value": "True"}, "CMP Type": {"value": "Docker"},
+				"Tenant ID": {"value": "01234567890abcdef01234567890abcdef"}, "Site": {"value": "Lab"}}}`))
+
+			pool, err := objMgr.CreateDtcPool(DtcPool{Name: "web", LbPreferredMethod: "ROUND_ROBIN",
+				Servers: []DtcServerLink{{Server: serverRef, Ratio: 1}}, Monitors: []string{monitorRef}})
+			Expect(err).To(BeNil())
+			Expect(pool.Ref).To(Equal(poolRef))
+			Expect(conn.createObjs[1].ObjectType()).To(Equal("dtc:pool"))
+
+			lbdn, err := objMgr.CreateDtcLbdn(DtcLbdn{Name: "www", LbMethod: "ROUND_ROBIN",
+				Pools: []DtcPoolLink{{Pool: poolRef, Ratio: 1}}, Patterns: []string{"www.example.com"}})
+			Expect(err).To(BeNil())
+			Expect(lbdn.Ref).To(Equal(lbdnRef))
+		})
+
+		It("should create monitors of the given type", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			secure := true
+			_, err := objMgr.CreateDtcMonitor(DtcMonitor{Type: "http", Name: "https", Port: 443, Secure: &secure,
+				Request: "GET /health", ResultCode: 200})
+			Expect(err).To(BeNil())
+			Expect(conn.createObjs[0].ObjectType()).To(Equal("dtc:monitor:http"))
+			Expect(conn.createObjs[0].ReturnFields()).To(ContainElement("secure"))
+
+			_, err = objMgr.CreateDtcMonitor(DtcMonitor{Name: "untyped"})
+			Expect(err).To(MatchError("the type of DTC monitor 'untyped' is required"))
+		})
+	})
+
+	Describe("Get and Update DTC monitors", func() {
+		It("should use the type of the ref", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					monitorRef: DtcMonitor{Ref: monitorRef, Name: "http", Interval: 10},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			monitor, err := objMgr.UpdateDtcMonitor(monitorRef, DtcMonitor{Interval: 10})
+			Expect(err).To(BeNil())
+			Expect(monitor.Type).To(Equal("http"))
+			Expect(monitor.Interval).To(Equal(uint32(10)))
+			Expect(conn.updateObjs[0].ObjectType()).To(Equal("dtc:monitor:http"))
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"interval": 10}`))
+
+			_, err = objMgr.GetDtcMonitorByRef(poolRef)
+			Expect(err).To(MatchError("'" + poolRef + "' is not a DTC monitor"))
+		})
+	})
+
+	Describe("Associate DTC objects", func() {
+		otherPoolRef := "dtc:pool/ZG5zLmlkbnNfcG9vbCRhcGk:api"
+
+		It("should add and remove the pools of an LBDN", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					lbdnRef: DtcLbdn{Ref: lbdnRef, Name: "www", Pools: []DtcPoolLink{{Pool: poolRef, Ratio: 1}}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.AddPoolToLbdn(lbdnRef, otherPoolRef, 2)
+			Expect(err).To(BeNil())
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"pools": [{"pool": "` + poolRef + `", "ratio": 1},
+				{"pool": "` + otherPoolRef + `", "ratio": 2}]}`))
+
+			_, err = objMgr.RemovePoolFromLbdn(lbdnRef, poolRef)
+			Expect(err).To(BeNil())
+			js, _ = json.Marshal(conn.updateObjs[1])
+			Expect(js).To(MatchJSON(`{"pools": []}`))
+			Expect(conn.updateRefs).To(Equal([]string{lbdnRef, lbdnRef}))
+		})
+
+		It("should add the monitors of a pool once", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					poolRef: DtcPool{Ref: poolRef, Name: "web", Monitors: []string{monitorRef}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.AddMonitorToPool(poolRef, monitorRef)
+			Expect(err).To(BeNil())
+			Expect(conn.updateObjs).To(BeEmpty())
+
+			icmpRef := "dtc:monitor:icmp/ZG5zLmlkbnNfbW9uaXRvcl9pY21wJGljbXA:icmp"
+			_, err = objMgr.AddMonitorToPool(poolRef, icmpRef)
+			Expect(err).To(BeNil())
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"monitors": ["` + monitorRef + `", "` + icmpRef + `"]}`))
+		})
+	})
+})
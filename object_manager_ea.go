@@ -0,0 +1,174 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eaDefCache maps EA names to their definitions
+type eaDefCache struct {
+	mu   sync.Mutex
+	defs map[string]*EADefinition
+}
+
+// getCachedEADefinition returns the definition of the EA name, fetched from
+// the grid the first time only
+func (objMgr *ObjectManager) getCachedEADefinition(name string) (*EADefinition, error) {
+	if objMgr.eaDefs != nil {
+		objMgr.eaDefs.mu.Lock()
+		def, ok := objMgr.eaDefs.defs[name]
+		objMgr.eaDefs.mu.Unlock()
+		if ok {
+			return def, nil
+		}
+	}
+
+	var res []EADefinition
+	eadef := NewEADefinition(EADefinition{Name: name})
+	if err := objMgr.connector.GetObject(eadef, "", &res); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	if objMgr.eaDefs != nil {
+		objMgr.eaDefs.mu.Lock()
+		objMgr.eaDefs.defs[name] = &res[0]
+		objMgr.eaDefs.mu.Unlock()
+	}
+
+	return &res[0], nil
+}
+
+// validateEAScalar checks a single value v of the EA defined by def
+func validateEAScalar(def *EADefinition, v interface{}) string {
+	if b, ok := v.(Bool); ok {
+		v = "False"
+		if b {
+			v = "True"
+		}
+	}
+
+	switch def.Type {
+	case "INTEGER":
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return ""
+		case json.Number:
+			if _, err := v.(json.Number).Int64(); err == nil {
+				return ""
+			}
+		}
+		return "must be an integer"
+	case "DATE":
+		switch value := v.(type) {
+		case time.Time:
+			return ""
+		case string:
+			if _, err := time.Parse(time.RFC3339, value); err == nil {
+				return ""
+			}
+		}
+		return "must be a date"
+	case "ENUM":
+		s, ok := v.(string)
+		if ok {
+			for _, lv := range def.ListValues {
+				if string(lv) == s {
+					return ""
+				}
+			}
+		}
+		return "must be one of the values of its definition"
+	}
+
+	if _, ok := v.(string); !ok {
+		return "must be a string"
+	}
+	return ""
+}
+
+// ValidateEA checks the EAs of ea are defined on the grid and their values
+// match the type of their definitions, with the allowed values of ENUM EAs
+// and only several values for multi-valued EAs. The definitions are
+// fetched once per ObjectManager. An *InvalidEAError is returned for the
+// first invalid EA.
+func (objMgr *ObjectManager) ValidateEA(ea EA) error {
+	for name, v := range ea {
+		if eaValue, ok := v.(EAValue); ok {
+			if eaValue.Value == nil {
+				continue
+			}
+			v = eaValue.Value
+		}
+
+		def, err := objMgr.getCachedEADefinition(name)
+		if err != nil {
+			return err
+		}
+		if def == nil {
+			return &InvalidEAError{Name: name, Value: v, Reason: "is not defined"}
+		}
+
+		values := []interface{}{v}
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			if !strings.Contains(def.Flags, "V") {
+				return &InvalidEAError{Name: name, Value: v, Reason: "cannot have several values"}
+			}
+			values = make([]interface{}, rv.Len())
+			for i := range values {
+				values[i] = rv.Index(i).Interface()
+			}
+		}
+		for _, value := range values {
+			if reason := validateEAScalar(def, value); reason != "" {
+				return &InvalidEAError{Name: name, Value: value, Reason: reason}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateObjectEA validates the EAs of obj if CheckEADefinitions is true
+func (objMgr *ObjectManager) validateObjectEA(obj IBObject) error {
+	if !objMgr.CheckEADefinitions {
+		return nil
+	}
+	field := eaField(obj)
+	if !field.IsValid() {
+		return nil
+	}
+
+	return objMgr.ValidateEA(field.Interface().(EA))
+}
+
+// updateObject updates the object referenced by ref with obj, once its EAs
+// are validated
+func (objMgr *ObjectManager) updateObject(obj IBObject, ref string) (string, error) {
+	if err := objMgr.validateObjectEA(obj); err != nil {
+		return "", err
+	}
+
+	return objMgr.connector.UpdateObject(obj, ref)
+}
+
+// GetEAInheritance returns the EAs of the object referenced by ref with
+// the parent objects the inherited ones come from
+func (objMgr *ObjectManager) GetEAInheritance(ref string) (EAInheritance, error) {
+	var res struct {
+		Ea EAInheritance `json:"extattrs"`
+	}
+	obj := &IBBase{
+		objectType:   strings.SplitN(ref, "/", 2)[0],
+		returnFields: []string{"extattrs"}}
+	if err := objMgr.readObject(obj, ref, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Ea, nil
+}
@@ -0,0 +1,116 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Typed EA values", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	It("should marshal the typed values", func() {
+		ea := EA{
+			"Site":     EAString("east"),
+			"Rack":     EAInt(42),
+			"Expires":  EADate(time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))),
+			"Owners":   EAList([]interface{}{"alice", "bob"}),
+			"Location": EAValue{InheritanceOperation: "INHERIT"}}
+
+		js, err := json.Marshal(ea)
+		Expect(err).To(BeNil())
+		Expect(js).To(MatchJSON(`{"Site": {"value": "east"}, "Rack": {"value": 42},
+			"Expires": {"value": "2026-01-02T02:04:05Z"}, "Owners": {"value": ["alice", "bob"]},
+			"Location": {"inheritance_operation": "INHERIT"}}`))
+	})
+
+	inheritedEA := []byte(`{"Site": {"value": "east",
+		"inheritance_source": {"_ref": "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/8/default"}},
+		"Rack": {"value": 42, "inheritance_source": {"_ref": "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/8/default"}},
+		"Owner": {"value": "ops"}}`)
+
+	It("should unmarshal the plain values of the inherited EAs", func() {
+		var ea EA
+		Expect(json.Unmarshal(inheritedEA, &ea)).To(BeNil())
+		Expect(ea).To(Equal(EA{"Site": "east", "Rack": 42, "Owner": "ops"}))
+	})
+
+	It("should unmarshal the inherited values with their source", func() {
+		var eas EAInheritance
+		Expect(json.Unmarshal(inheritedEA, &eas)).To(BeNil())
+		Expect(eas["Site"]).To(Equal(EAValue{Value: "east",
+			InheritanceSource: "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/8/default"}))
+		Expect(eas["Rack"].Value).To(Equal(42))
+		Expect(eas["Owner"]).To(Equal(EAValue{Value: "ops"}))
+	})
+
+	It("should get the EAs of an object with their inheritance", func() {
+		ref := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{ref: map[string]json.RawMessage{"extattrs": inheritedEA}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		eas, err := objMgr.GetEAInheritance(ref)
+		Expect(err).To(BeNil())
+		Expect(eas["Site"].InheritanceSource).To(Equal("networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/8/default"))
+		Expect(eas["Owner"].InheritanceSource).To(BeEmpty())
+		Expect(conn.getObjs[0].ObjectType()).To(Equal("network"))
+		Expect(conn.getObjs[0].ReturnFields()).To(Equal([]string{"extattrs"}))
+	})
+
+	Describe("Validate EA", func() {
+		newObjMgr := func(def EADefinition) (*ObjectManager, *fakeMultiConnector) {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{"extensibleattributedef": []EADefinition{def}}}
+			return NewObjectManager(conn, cmpType, tenantID), conn
+		}
+
+		It("should check the values against the type of the definition", func() {
+			objMgr, _ := newObjMgr(EADefinition{Name: "Rack", Type: "INTEGER"})
+			Expect(objMgr.ValidateEA(EA{"Rack": EAInt(42)})).To(BeNil())
+
+			err := objMgr.ValidateEA(EA{"Rack": "42"})
+			Expect(errors.Is(err, ErrInvalidEA)).To(BeTrue())
+			Expect(err).To(MatchError("extensible attribute 'Rack' must be an integer"))
+		})
+
+		It("should check the values of ENUM and multi-valued EAs", func() {
+			objMgr, _ := newObjMgr(EADefinition{Name: "Site", Type: "ENUM", Flags: "V",
+				ListValues: []EADefListValue{"east", "west"}})
+			Expect(objMgr.ValidateEA(EA{"Site": EAList([]interface{}{"east", "west"})})).To(BeNil())
+			Expect(objMgr.ValidateEA(EA{"Site": []string{"east", "north"}})).NotTo(BeNil())
+
+			objMgr, _ = newObjMgr(EADefinition{Name: "Site", Type: "STRING"})
+			Expect(objMgr.ValidateEA(EA{"Site": []string{"east", "west"}})).To(
+				MatchError("extensible attribute 'Site' cannot have several values"))
+		})
+
+		It("should fetch the definitions once", func() {
+			objMgr, conn := newObjMgr(EADefinition{Name: "Expires", Type: "DATE"})
+			Expect(objMgr.ValidateEA(EA{"Expires": EADate(time.Now())})).To(BeNil())
+			Expect(objMgr.ValidateEA(EA{"Expires": "2026-01-02T03:04:05Z"})).To(BeNil())
+			Expect(conn.getObjs).To(HaveLen(1))
+		})
+
+		It("should reject the EAs not defined", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			Expect(objMgr.ValidateEA(EA{"Site": "east"})).To(MatchError("extensible attribute 'Site' is not defined"))
+		})
+
+		It("should not update objects with invalid EAs", func() {
+			objMgr, conn := newObjMgr(EADefinition{Name: "Rack", Type: "INTEGER"})
+			objMgr.CheckEADefinitions = true
+
+			_, err := objMgr.UpdateNetworkContainer("networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/8/default",
+				NetworkContainer{Ea: EA{"Rack": "A1"}})
+			Expect(errors.Is(err, ErrInvalidEA)).To(BeTrue())
+			Expect(conn.updateObjs).To(BeEmpty())
+		})
+	})
+})
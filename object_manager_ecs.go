@@ -0,0 +1,84 @@
+package ibclient
+
+import (
+	"errors"
+)
+
+// GetGridDnsClientSubnet returns the EDNS client subnet settings of the DNS
+// service of the grid
+func (objMgr *ObjectManager) GetGridDnsClientSubnet() (*GridDns, error) {
+	var res []GridDns
+
+	gridDns := NewGridDns(GridDns{})
+	err := objMgr.getObject(gridDns, "", &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, errors.New("grid DNS properties not found")
+	}
+
+	return &res[0], nil
+}
+
+// UpdateGridDnsClientSubnet sets the EDNS client subnet settings of the DNS
+// service of the grid, referenced by ref. Unset settings are left
+// unchanged.
+func (objMgr *ObjectManager) UpdateGridDnsClientSubnet(ref string, ecs DnsClientSubnet) (*GridDns, error) {
+	gridDns := NewGridDns(GridDns{DnsClientSubnet: ecs})
+
+	newRef, err := objMgr.updateObject(gridDns, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	gridDns = NewGridDns(GridDns{})
+	err = objMgr.readObject(gridDns, newRef, &gridDns)
+	return gridDns, err
+}
+
+// GetMemberDnsClientSubnet returns the DNS properties of the member
+// hostName with its EDNS client subnet settings
+func (objMgr *ObjectManager) GetMemberDnsClientSubnet(hostName string) (*MemberDns, error) {
+	var res []MemberDns
+
+	memberDns := NewMemberDns(MemberDns{HostName: hostName})
+	memberDns.returnFields = append(memberDns.returnFields, dnsClientSubnetFields...)
+	err := objMgr.getObject(memberDns, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateMemberDnsClientSubnet sets the EDNS client subnet settings of the
+// DNS properties of the member referenced by ref. Unset settings are left
+// unchanged.
+func (objMgr *ObjectManager) UpdateMemberDnsClientSubnet(ref string, ecs DnsClientSubnet) (*MemberDns, error) {
+	memberDns := NewMemberDns(MemberDns{DnsClientSubnet: ecs})
+
+	newRef, err := objMgr.updateObject(memberDns, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	memberDns = NewMemberDns(MemberDns{})
+	memberDns.returnFields = append(memberDns.returnFields, dnsClientSubnetFields...)
+	err = objMgr.readObject(memberDns, newRef, &memberDns)
+	return memberDns, err
+}
+
+// GetMaxMindDBInfo returns the version and the deployment time of the
+// MaxMind geolocation database of the grid, nil if none was uploaded
+func (objMgr *ObjectManager) GetMaxMindDBInfo() (*MaxMindDBInfo, error) {
+	var res []MaxMindDBInfo
+
+	info := NewMaxMindDBInfo(MaxMindDBInfo{})
+	err := objMgr.getObject(info, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
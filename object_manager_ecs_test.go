@@ -0,0 +1,67 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager EDNS client subnet", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	gridDnsRef := "grid:dns/ZG5zLmNsdXN0ZXJfZG5zX3Byb3BlcnRpZXMkMA:Infoblox"
+	memberDnsRef := "member:dns/ZG5zLm1lbWJlcl9kbnNfcHJvcGVydGllcyQw:infoblox.localdomain"
+	enable := true
+
+	Describe("Update Grid DNS Client Subnet", func() {
+		It("should only send the ECS settings", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					gridDnsRef: GridDns{Ref: gridDnsRef, DnsClientSubnet: DnsClientSubnet{
+						EnableClientSubnetForwarding: &enable,
+						ClientSubnetIpv4PrefixLength: 24}}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			gridDns, err := objMgr.UpdateGridDnsClientSubnet(gridDnsRef, DnsClientSubnet{
+				EnableClientSubnetForwarding: &enable,
+				ClientSubnetIpv4PrefixLength: 24,
+				ClientSubnetDomains:          []ClientSubnetDomain{{Domain: "cdn.example.com", Permission: "ALLOW"}}})
+			Expect(err).To(BeNil())
+			Expect(gridDns.ClientSubnetIpv4PrefixLength).To(Equal(uint32(24)))
+			Expect(conn.updateRefs).To(Equal([]string{gridDnsRef}))
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"enable_client_subnet_forwarding": true, "client_subnet_ipv4_prefix_length": 24,
+				"client_subnet_domains": [{"domain": "cdn.example.com", "permission": "ALLOW"}]}`))
+		})
+	})
+
+	Describe("Get Member DNS Client Subnet", func() {
+		It("should request the ECS settings of the member", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"member:dns": []MemberDns{{Ref: memberDnsRef, HostName: "infoblox.localdomain",
+						DnsClientSubnet: DnsClientSubnet{EnableClientSubnetRecursive: &enable}}}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			memberDns, err := objMgr.GetMemberDnsClientSubnet("infoblox.localdomain")
+			Expect(err).To(BeNil())
+			Expect(*memberDns.EnableClientSubnetRecursive).To(BeTrue())
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("enable_client_subnet_recursive"))
+		})
+	})
+
+	Describe("Update Member DNS Client Subnet", func() {
+		It("should not change the other DNS properties of the member", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					memberDnsRef: MemberDns{Ref: memberDnsRef, HostName: "infoblox.localdomain"}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.UpdateMemberDnsClientSubnet(memberDnsRef, DnsClientSubnet{ClientSubnetIpv6PrefixLength: 56})
+			Expect(err).To(BeNil())
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"client_subnet_ipv6_prefix_length": 56}`))
+		})
+	})
+})
@@ -0,0 +1,68 @@
+package ibclient
+
+// WithReturnFields returns a copy of the ObjectManager whose Get methods
+// only request returnFields, e.g. []string{"name", "ipv4addrs"} for host
+// records, instead of the default fields of the objects, so that objects
+// with many EAs or large field sets are fetched partially. The fields not
+// requested are left empty in the objects returned. The reads the other
+// methods need to work, e.g. the checks before a creation, keep the fields
+// of their objects.
+func (objMgr *ObjectManager) WithReturnFields(returnFields []string) *ObjectManager {
+	res := *objMgr
+	res.returnFields = append([]string{}, returnFields...)
+	res.returnFieldsPlus = false
+
+	return &res
+}
+
+// WithAdditionalReturnFields returns a copy of the ObjectManager whose Get
+// methods request returnFields in addition to the default fields of the
+// objects, sent as _return_fields+
+func (objMgr *ObjectManager) WithAdditionalReturnFields(returnFields []string) *ObjectManager {
+	res := *objMgr
+	res.returnFields = append([]string{}, returnFields...)
+	res.returnFieldsPlus = true
+
+	return &res
+}
+
+// applyReturnFields sets the return fields of the ObjectManager, if any,
+// on obj
+func (objMgr *ObjectManager) applyReturnFields(obj IBObject) {
+	if objMgr.returnFields == nil {
+		return
+	}
+	if o, ok := obj.(interface {
+		setReturnFields([]string, bool)
+	}); ok {
+		o.setReturnFields(objMgr.returnFields, objMgr.returnFieldsPlus)
+	}
+}
+
+// withDefaultReturnFields returns objMgr, or a copy of it without the
+// return fields of WithReturnFields, for the methods reading objects with
+// the Get methods to work on their fields rather than return them
+func (objMgr *ObjectManager) withDefaultReturnFields() *ObjectManager {
+	if objMgr.returnFields == nil {
+		return objMgr
+	}
+
+	res := *objMgr
+	res.returnFields = nil
+	res.returnFieldsPlus = false
+
+	return &res
+}
+
+// getObject gets obj with the return fields of the ObjectManager, for the
+// Get methods returning obj to the caller
+func (objMgr *ObjectManager) getObject(obj IBObject, ref string, res interface{}) error {
+	objMgr.applyReturnFields(obj)
+	return objMgr.connector.GetObject(obj, ref, res)
+}
+
+// readObject gets obj with its own return fields, whatever the return
+// fields of the ObjectManager, for the reads the methods need to work
+func (objMgr *ObjectManager) readObject(obj IBObject, ref string, res interface{}) error {
+	return objMgr.connector.GetObject(obj, ref, res)
+}
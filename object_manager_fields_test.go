@@ -0,0 +1,76 @@
+package ibclient
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager return fields", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLmhvc3Q:host.example.com/default"
+
+	newConnector := func(requestor *fakeReportingRequestor) *Connector {
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		return &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+	}
+
+	It("should only request the given fields", func() {
+		requestor := &fakeReportingRequestor{res: [][]byte{
+			[]byte(`{"_ref": "` + hostRef + `", "name": "host.example.com"}`)}}
+		objMgr := NewObjectManager(newConnector(requestor), cmpType, tenantID)
+
+		host, err := objMgr.WithReturnFields([]string{"name", "ipv4addrs"}).GetHostRecordByRef(hostRef)
+		Expect(err).To(BeNil())
+		Expect(host.Name).To(Equal("host.example.com"))
+		Expect(requestor.reqs[0].URL.Query().Get("_return_fields")).To(Equal("name,ipv4addrs"))
+		Expect(requestor.reqs[0].URL.Query()).NotTo(HaveKey("_return_fields+"))
+	})
+
+	It("should request the given fields in addition to the default ones", func() {
+		requestor := &fakeReportingRequestor{res: [][]byte{
+			[]byte(`{"_ref": "` + hostRef + `", "name": "host.example.com", "ttl": 300}`)}}
+		objMgr := NewObjectManager(newConnector(requestor), cmpType, tenantID)
+
+		_, err := objMgr.WithAdditionalReturnFields([]string{"ttl"}).GetHostRecordByRef(hostRef)
+		Expect(err).To(BeNil())
+		Expect(requestor.reqs[0].URL.Query().Get("_return_fields+")).To(Equal("ttl"))
+	})
+
+	It("should not change the fields requested by the ObjectManager it was derived from", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.WithReturnFields([]string{"name"})
+
+		_, err := objMgr.GetHostRecordByRef(hostRef)
+		Expect(err).To(BeNil())
+		Expect(conn.getObjs[0].ReturnFields()).To(Equal(NewHostRecord(HostRecord{}).ReturnFields()))
+	})
+
+	It("should keep the fields the methods need in their own reads", func() {
+		conn := &fakeMultiConnector{getResults: map[string]interface{}{
+			"networkview": []NetworkView{{Name: "default", AssociatedDnsViews: []string{"default"}}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID).WithReturnFields([]string{"comment"})
+		objMgr.CheckRecordConflicts = true
+
+		view, err := objMgr.GetDefaultDNSView("default")
+		Expect(err).To(BeNil())
+		Expect(view).To(Equal("default"))
+		Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("associated_dns_views"))
+
+		_, err = objMgr.CreateHostRecord(true, "host.example.com", "default", "default", "10.0.0.0/24", "10.0.0.5", "", "", "")
+		Expect(err).To(BeNil())
+		for _, obj := range conn.getObjs[1:] {
+			Expect(obj.ReturnFields()).NotTo(Equal([]string{"comment"}))
+		}
+		Expect(conn.getObjs[len(conn.getObjs)-1].ReturnFields()).To(ContainElement("ipv4addrs"))
+
+		_, err = objMgr.PurgeQuarantined(time.Hour)
+		Expect(err).To(BeNil())
+		Expect(conn.getObjs[len(conn.getObjs)-1].ReturnFields()).To(Equal([]string{"extattrs"}))
+	})
+})
@@ -0,0 +1,164 @@
+package ibclient
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// fileopArgs holds the arguments of a fileop function
+type fileopArgs struct {
+	IBBase    `json:"-"`
+	Filename  string `json:"filename,omitempty"`
+	Token     string `json:"token,omitempty"`
+	Action    string `json:"action,omitempty"`
+	Operation string `json:"operation,omitempty"`
+	OnError   string `json:"on_error,omitempty"`
+	Object    string `json:"_object,omitempty"`
+	Type      string `json:"type,omitempty"`
+	// CertificateUsage and Member select the certificate downloaded by
+	// downloadcertificate
+	CertificateUsage string `json:"certificate_usage,omitempty"`
+	Member           string `json:"member,omitempty"`
+}
+
+// fileTransfer is the token and the URL of a file to upload or download
+type fileTransfer struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// uploadFile sends the content of r as the file name to the URL of an
+// uploadinit token
+func (c *Connector) uploadFile(urlStr string, name string, r io.Reader) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", urlStr, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	c.setBasicAuth(req)
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+
+	_, err = c.send(req)
+	return err
+}
+
+// downloadFile fetches the file at the URL of a download token
+func (c *Connector) downloadFile(urlStr string) ([]byte, error) {
+	req, err := http.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setBasicAuth(req)
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+
+	return c.send(req)
+}
+
+// CSVImport uploads the CSV file at path and starts its import with the
+// operation action, e.g. "INSERT", "UPDATE", "DELETE" or "MERGE". Rows in
+// error are skipped. The import runs on the grid, its progress is polled
+// with GetCSVImportTask.
+func (objMgr *ObjectManager) CSVImport(action string, path string) (*CSVImportTask, error) {
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+
+	var upload fileTransfer
+	if err = conn.callFunction("fileop", "uploadinit", &fileopArgs{Filename: name}, &upload); err != nil {
+		return nil, err
+	}
+	if err = conn.uploadFile(upload.URL, name, f); err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Task CSVImportTask `json:"csv_import_task"`
+	}
+	args := &fileopArgs{
+		Token:     upload.Token,
+		Action:    "START",
+		Operation: action,
+		OnError:   "CONTINUE"}
+	if err = conn.callFunction("fileop", "csv_import", args, &res); err != nil {
+		return nil, err
+	}
+
+	return &res.Task, nil
+}
+
+// GetCSVImportTask returns the state of the CSV import referenced by ref
+func (objMgr *ObjectManager) GetCSVImportTask(ref string) (*CSVImportTask, error) {
+	task := NewCSVImportTask(CSVImportTask{})
+	err := objMgr.getObject(task, ref, &task)
+	return task, err
+}
+
+// CSVExport writes the objects of objType, e.g. "network", to w as CSV
+func (objMgr *ObjectManager) CSVExport(objType string, w io.Writer) error {
+	return objMgr.download("csv_export", &fileopArgs{Object: objType}, w)
+}
+
+// DownloadGridBackup writes a backup of the grid to w
+func (objMgr *ObjectManager) DownloadGridBackup(w io.Writer) error {
+	return objMgr.download("getgriddata", &fileopArgs{Type: "BACKUP"}, w)
+}
+
+// download calls the fileop function preparing a file, writes the file to
+// w and releases it on the grid
+func (objMgr *ObjectManager) download(function string, args *fileopArgs, w io.Writer) error {
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return err
+	}
+
+	var download fileTransfer
+	if err = conn.callFunction("fileop", function, args, &download); err != nil {
+		return err
+	}
+
+	content, err := conn.downloadFile(download.URL)
+	if err == nil {
+		_, err = w.Write(content)
+	}
+
+	complete := &fileopArgs{Token: download.Token}
+	if cerr := conn.callFunction("fileop", "downloadcomplete", complete, nil); cerr != nil {
+		log.Printf("Failed to release downloaded file '%s': %s", download.URL, cerr)
+		if err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,111 @@
+package ibclient
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fileopRequestor answers the fileop functions and the file transfers and
+// records them
+type fileopRequestor struct {
+	functions []string
+	bodies    []string
+	uploads   []string
+	res       map[string]string
+}
+
+func (hr *fileopRequestor) Init(config TransportConfig) {}
+
+func (hr *fileopRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	var b []byte
+	if req.Body != nil {
+		b, _ = ioutil.ReadAll(req.Body)
+	}
+	if fn := req.URL.Query().Get("_function"); fn != "" {
+		hr.functions = append(hr.functions, fn)
+		hr.bodies = append(hr.bodies, string(b))
+		return []byte(hr.res[fn]), nil
+	}
+
+	if req.Method == "POST" {
+		hr.uploads = append(hr.uploads, string(b))
+		return nil, nil
+	}
+	return []byte(hr.res[req.URL.Path]), nil
+}
+
+var _ = Describe("Object Manager Fileop", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443", Username: "admin", Password: "infoblox"}
+	newObjMgr := func(requestor *fileopRequestor) *ObjectManager {
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+		return NewObjectManager(conn, cmpType, tenantID)
+	}
+
+	Describe("CSV Import", func() {
+		It("should upload the file and start the import with its token", func() {
+			dir, err := ioutil.TempDir("", "csvimport")
+			Expect(err).To(BeNil())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "networks.csv")
+			Expect(ioutil.WriteFile(path, []byte("header-network,address*,netmask*\nnetwork,10.0.0.0,255.255.255.0\n"), 0600)).To(BeNil())
+
+			requestor := &fileopRequestor{res: map[string]string{
+				"uploadinit": `{"token": "eJydkMFOwzAMhu9", "url": "https://172.22.18.66/http_direct_file_io/req_id-UPLOAD-1/import_file"}`,
+				"csv_import": `{"csv_import_task": {"_ref": "csvimporttask/b25lLmNzdl9pbXBvcnRfdGFzayQx:1",
+					"import_id": 1, "status": "PENDING", "operation": "INSERT"}}`,
+			}}
+
+			task, err := newObjMgr(requestor).CSVImport("INSERT", path)
+			Expect(err).To(BeNil())
+			Expect(task.ImportID).To(Equal(1))
+			Expect(task.Status).To(Equal("PENDING"))
+
+			Expect(requestor.functions).To(Equal([]string{"uploadinit", "csv_import"}))
+			Expect(requestor.bodies[0]).To(MatchJSON(`{"filename": "networks.csv"}`))
+			Expect(requestor.uploads[0]).To(ContainSubstring(`filename="networks.csv"`))
+			Expect(requestor.uploads[0]).To(ContainSubstring("network,10.0.0.0,255.255.255.0"))
+			Expect(requestor.bodies[1]).To(MatchJSON(`{"token": "eJydkMFOwzAMhu9", "action": "START",
+				"operation": "INSERT", "on_error": "CONTINUE"}`))
+		})
+	})
+
+	Describe("Download Grid Backup", func() {
+		It("should write the backup and release it on the grid", func() {
+			requestor := &fileopRequestor{res: map[string]string{
+				"getgriddata": `{"token": "eJylkMtOwzAQRf", "url": "https://172.22.18.66/http_direct_file_io/req_id-DOWNLOAD-1/database.bak"}`,
+				"/http_direct_file_io/req_id-DOWNLOAD-1/database.bak": "backup content",
+				"downloadcomplete": `{}`,
+			}}
+
+			var w bytes.Buffer
+			err := newObjMgr(requestor).DownloadGridBackup(&w)
+			Expect(err).To(BeNil())
+			Expect(w.String()).To(Equal("backup content"))
+
+			Expect(requestor.functions).To(Equal([]string{"getgriddata", "downloadcomplete"}))
+			Expect(requestor.bodies[0]).To(MatchJSON(`{"type": "BACKUP"}`))
+			Expect(requestor.bodies[1]).To(MatchJSON(`{"token": "eJylkMtOwzAQRf"}`))
+		})
+
+		It("should fail without panicking when the connector is not a Connector", func() {
+			objMgr := NewObjectManager(&fakeMultiConnector{}, cmpType, tenantID)
+
+			var w bytes.Buffer
+			err := objMgr.DownloadGridBackup(&w)
+			Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+			_, err = objMgr.CSVImport("INSERT", "hosts.csv")
+			Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+		})
+	})
+})
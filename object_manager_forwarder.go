@@ -0,0 +1,111 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+// ConditionalForwarderACLSuffix is appended to the domain of a conditional
+// forwarder to name the ACL of its forwarders
+const ConditionalForwarderACLSuffix = " forwarders"
+
+// CreateNamedACL creates a named ACL of the addresses in AccessList
+func (objMgr *ObjectManager) CreateNamedACL(na NamedACL) (*NamedACL, error) {
+	acl := NewNamedACL(na)
+	acl.Ea = objMgr.viewEA(na.Ea)
+
+	ref, err := objMgr.createObject(acl)
+	acl.Ref = ref
+
+	return acl, err
+}
+
+func (objMgr *ObjectManager) GetNamedACLByRef(ref string) (*NamedACL, error) {
+	acl := NewNamedACL(NamedACL{})
+	err := objMgr.getObject(acl, ref, &acl)
+	return acl, err
+}
+
+func (objMgr *ObjectManager) GetNamedACLByName(name string) (*NamedACL, error) {
+	var res []NamedACL
+
+	acl := NewNamedACL(NamedACL{Name: name})
+	err := objMgr.getObject(acl, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateNamedACL updates the named ACL referenced by ref, empty values and
+// lists leave the ACL unchanged
+func (objMgr *ObjectManager) UpdateNamedACL(ref string, na NamedACL) (*NamedACL, error) {
+	acl := NewNamedACL(na)
+	acl.Ref = ""
+
+	newRef, err := objMgr.updateObject(acl, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetNamedACLByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteNamedACL(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// ConditionalForwarder forwards the queries for domain in the DNS view
+// dnsview to forwarders, e.g. the inbound endpoints of the resolver of a
+// cloud provider, from the grid members named in members. The forward zone
+// of domain is created, or updated when it exists, along with the named
+// ACL allowing the forwarders, named after the domain with
+// ConditionalForwarderACLSuffix, so that the views and zones answering
+// the provider can refer to it.
+func (objMgr *ObjectManager) ConditionalForwarder(domain string, dnsview string, forwarders []NameServer, members []string) (*ZoneForward, *NamedACL, error) {
+	if len(forwarders) == 0 {
+		return nil, nil, fmt.Errorf("no forwarders given for '%s'", domain)
+	}
+
+	forwardingServers := make([]ForwardingMemberServer, 0, len(members))
+	for _, m := range members {
+		forwardingServers = append(forwardingServers, ForwardingMemberServer{Name: m, ForwardersOnly: true})
+	}
+	zf := ZoneForward{
+		Fqdn:              domain,
+		View:              dnsview,
+		ForwardTo:         forwarders,
+		ForwardersOnly:    true,
+		ForwardingServers: forwardingServers}
+
+	zone, err := objMgr.withDefaultReturnFields().GetZoneForwardByFQDN(domain, dnsview)
+	if err != nil {
+		return nil, nil, err
+	}
+	if zone == nil {
+		zone, err = objMgr.CreateZoneForward(zf)
+	} else {
+		zone, err = objMgr.UpdateZoneForward(zone.Ref, zf)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accessList := make([]AddressAC, 0, len(forwarders))
+	for _, f := range forwarders {
+		accessList = append(accessList, AddressAC{Address: f.Address, Permission: "ALLOW"})
+	}
+	na := NamedACL{Name: domain + ConditionalForwarderACLSuffix, AccessList: accessList}
+
+	acl, err := objMgr.withDefaultReturnFields().GetNamedACLByName(na.Name)
+	if err != nil {
+		return zone, nil, err
+	}
+	if acl == nil {
+		acl, err = objMgr.CreateNamedACL(na)
+	} else {
+		acl, err = objMgr.UpdateNamedACL(acl.Ref, NamedACL{AccessList: accessList})
+	}
+
+	return zone, acl, err
+}
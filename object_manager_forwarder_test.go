@@ -0,0 +1,66 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager conditional forwarders", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	zoneRef := "zone_forward/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5hd3MuaW50ZXJuYWw:aws.internal/default"
+	aclRef := "namedacl/b25lLmRlZmluZWRfYWNsJDAuYXdzLmludGVybmFs:aws.internal%20forwarders"
+	forwarders := []NameServer{
+		{Name: "resolver1.aws.internal", Address: "10.10.0.10"},
+		{Name: "resolver2.aws.internal", Address: "10.10.1.10"}}
+
+	It("should create the forward zone and the ACL of the forwarders", func() {
+		conn := &fakeMultiConnector{createRefs: []string{zoneRef, aclRef}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = true
+
+		zone, acl, err := objMgr.ConditionalForwarder("aws.internal", "default", forwarders, []string{"ns1.example.com"})
+		Expect(err).To(BeNil())
+		Expect(zone.Ref).To(Equal(zoneRef))
+		Expect(acl.Ref).To(Equal(aclRef))
+
+		js, _ := json.Marshal(conn.createObjs[0])
+		Expect(js).To(MatchJSON(`{"_ref": "` + zoneRef + `", "fqdn": "aws.internal", "view": "default",
+			"forward_to": [{"name": "resolver1.aws.internal", "address": "10.10.0.10"},
+				{"name": "resolver2.aws.internal", "address": "10.10.1.10"}],
+			"forwarders_only": true, "forwarding_servers": [{"name": "ns1.example.com", "forwarders_only": true}]}`))
+		js, _ = json.Marshal(conn.createObjs[1])
+		Expect(js).To(MatchJSON(`{"_ref": "` + aclRef + `", "name": "aws.internal forwarders",
+			"access_list": [{"address": "10.10.0.10", "permission": "ALLOW"},
+				{"address": "10.10.1.10", "permission": "ALLOW"}]}`))
+	})
+
+	It("should update the existing forward zone and ACL", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"zone_forward": []ZoneForward{{Ref: zoneRef, Fqdn: "aws.internal", View: "default"}},
+				zoneRef:        ZoneForward{Ref: zoneRef, Fqdn: "aws.internal", View: "default"},
+				"namedacl":     []NamedACL{{Ref: aclRef, Name: "aws.internal forwarders"}},
+				aclRef:         NamedACL{Ref: aclRef, Name: "aws.internal forwarders"}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, _, err := objMgr.ConditionalForwarder("aws.internal", "default", forwarders, nil)
+		Expect(err).To(BeNil())
+		Expect(conn.createObjs).To(BeEmpty())
+		Expect(conn.updateRefs).To(Equal([]string{zoneRef, aclRef}))
+		js, _ := json.Marshal(conn.updateObjs[1])
+		Expect(js).To(MatchJSON(`{"access_list": [{"address": "10.10.0.10", "permission": "ALLOW"},
+			{"address": "10.10.1.10", "permission": "ALLOW"}]}`))
+	})
+
+	It("should require forwarders", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, _, err := objMgr.ConditionalForwarder("aws.internal", "default", nil, nil)
+		Expect(err).NotTo(BeNil())
+		Expect(conn.getObjs).To(BeEmpty())
+	})
+})
@@ -0,0 +1,96 @@
+package ibclient
+
+import (
+	"errors"
+	"log"
+	"time"
+)
+
+// gridRestartServices holds the arguments of the restartservices function
+// of the grid
+type gridRestartServices struct {
+	IBBase   `json:"-"`
+	Services []string `json:"services,omitempty"`
+	Members  []string `json:"members,omitempty"`
+	Mode     string   `json:"mode,omitempty"`
+}
+
+// RestartServices restarts the services, e.g. "DNS" or "DHCP", of the
+// members of the grid so the pending changes become live. All services and
+// all members are restarted when services or members are empty. mode is
+// "GROUPED", "SEQUENTIAL" or "SIMULTANEOUS", the grid default when empty.
+func (objMgr *ObjectManager) RestartServices(services []string, members []string, mode string) error {
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return err
+	}
+
+	grids, err := objMgr.withDefaultReturnFields().GetGridInfo()
+	if err != nil {
+		return err
+	}
+	if len(grids) == 0 {
+		return errors.New("grid object not found")
+	}
+
+	args := &gridRestartServices{
+		IBBase:   IBBase{objectType: "grid"},
+		Services: services,
+		Members:  members,
+		Mode:     mode}
+
+	return conn.callFunction(grids[0].Ref, "restartservices", args, nil)
+}
+
+// GetPendingRestartStatus returns the number of members of the grid per
+// restart state, NeededRestart counts the members with pending changes
+func (objMgr *ObjectManager) GetPendingRestartStatus() (*GridServiceRestartStatus, error) {
+	var res []GridServiceRestartStatus
+
+	status := NewGridServiceRestartStatus(GridServiceRestartStatus{})
+	err := objMgr.getObject(status, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// GetGridTimeSettings returns the time zone and the NTP settings of the grid
+func (objMgr *ObjectManager) GetGridTimeSettings() (*Grid, error) {
+	var res []Grid
+
+	grid := NewGrid(Grid{})
+	grid.returnFields = append(grid.returnFields, "time_zone")
+	err := objMgr.getObject(grid, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// CheckClockSkew returns how far the local clock is ahead of the clock of
+// the Grid Master. A skew beyond maxSkew, which may break the session
+// cookies and the validation of certificates, is logged and returned as a
+// *ClockSkewError.
+func (objMgr *ObjectManager) CheckClockSkew(maxSkew time.Duration) (time.Duration, error) {
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return 0, err
+	}
+
+	serverTime, err := conn.ServerTime()
+	if err != nil {
+		return 0, err
+	}
+
+	skew := time.Since(serverTime)
+	if skew > maxSkew || -skew > maxSkew {
+		err := &ClockSkewError{Skew: skew, MaxSkew: maxSkew}
+		log.Printf("Warning: %s", err)
+		return skew, err
+	}
+
+	return skew, nil
+}
@@ -0,0 +1,127 @@
+package ibclient
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type serverTimeRequestor struct {
+	fakeReportingRequestor
+	serverTime time.Time
+}
+
+func (hr *serverTimeRequestor) ServerTime(req *http.Request) (time.Time, error) {
+	hr.reqs = append(hr.reqs, req)
+	return hr.serverTime, nil
+}
+
+var _ = Describe("Object Manager Grid", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+
+	Describe("Restart Services", func() {
+		requestor := &fakeReportingRequestor{
+			res: [][]byte{
+				[]byte(`[{"_ref": "grid/b25lLmNsdXN0ZXIkMA:Infoblox", "name": "Infoblox"}]`),
+				[]byte(`{}`),
+			},
+		}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should call restartservices on the grid", func() {
+			err := objMgr.RestartServices([]string{"DHCP"}, []string{"infoblox.localdomain"}, "SIMULTANEOUS")
+			Expect(err).To(BeNil())
+
+			req := requestor.reqs[1]
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.String()).To(Equal(
+				"https://172.22.18.66:443/wapi/v2.11/grid/b25lLmNsdXN0ZXIkMA:Infoblox?_function=restartservices"))
+			Expect(requestor.body[1]).To(MatchJSON(`{"services": ["DHCP"],
+				"members": ["infoblox.localdomain"], "mode": "SIMULTANEOUS"}`))
+		})
+
+		It("should fail without panicking when the connector is not a Connector", func() {
+			objMgr := NewObjectManager(&fakeMultiConnector{}, cmpType, tenantID)
+
+			err := objMgr.RestartServices(nil, nil, "")
+			Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+			_, err = objMgr.CheckClockSkew(time.Minute)
+			Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+		})
+	})
+
+	Describe("Get Pending Restart Status", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"grid:servicerestart:status": []GridServiceRestartStatus{{
+					Ref:           "grid:servicerestart:status/ZG5zLnJlc3RhcnQ:Infoblox",
+					NeededRestart: 2, NoRestart: 1}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the restart status of the grid", func() {
+			status, err := objMgr.GetPendingRestartStatus()
+			Expect(err).To(BeNil())
+			Expect(status.NeededRestart).To(Equal(2))
+			Expect(status.NoRestart).To(Equal(1))
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("needed_restart"))
+		})
+	})
+
+	Describe("Get Grid Time Settings", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"grid": []Grid{{Ref: "grid/b25lLmNsdXN0ZXIkMA:Infoblox", TimeZone: "(UTC) Coordinated Universal Time",
+					NTPSetting: &NTPSetting{EnableNTP: true}}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should return the time zone and the NTP settings", func() {
+			grid, err := objMgr.GetGridTimeSettings()
+			Expect(err).To(BeNil())
+			Expect(grid.TimeZone).To(Equal("(UTC) Coordinated Universal Time"))
+			Expect(grid.NTPSetting.EnableNTP).To(BeTrue())
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("time_zone"))
+		})
+	})
+
+	Describe("Check Clock Skew", func() {
+		newObjMgr := func(serverTime time.Time) *ObjectManager {
+			requestor := &serverTimeRequestor{serverTime: serverTime}
+			wrb := &WapiRequestBuilder{}
+			wrb.Init(hostConfig)
+			conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+			return NewObjectManager(conn, cmpType, tenantID)
+		}
+
+		It("should accept a skew within the limit", func() {
+			skew, err := newObjMgr(time.Now().Add(-time.Second)).CheckClockSkew(time.Minute)
+			Expect(err).To(BeNil())
+			Expect(skew).To(BeNumerically("~", time.Second, time.Second))
+		})
+
+		It("should report a skew beyond the limit", func() {
+			skew, err := newObjMgr(time.Now().Add(10 * time.Minute)).CheckClockSkew(time.Minute)
+			Expect(skew).To(BeNumerically("<", -9*time.Minute))
+			skewErr, ok := err.(*ClockSkewError)
+			Expect(ok).To(BeTrue())
+			Expect(skewErr.MaxSkew).To(Equal(time.Minute))
+		})
+
+		It("should fail when the requestor does not report the server time", func() {
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: &fakeReportingRequestor{}}
+			_, err := NewObjectManager(conn, cmpType, tenantID).CheckClockSkew(time.Minute)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
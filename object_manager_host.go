@@ -0,0 +1,236 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+var hostRecordReturnFields = []string{"aliases", "comment", "extattrs", "ipv4addrs", "ipv6addrs",
+	"name", "network_view", "view", "zone"}
+
+// hostRecordUpdate replaces the lists of a host record it holds, an empty
+// list removes all the entries
+type hostRecordUpdate struct {
+	IBBase    `json:"-"`
+	Ipv4Addrs *[]HostRecordIpv4Addr `json:"ipv4addrs,omitempty"`
+	Ipv6Addrs *[]HostRecordIpv6Addr `json:"ipv6addrs,omitempty"`
+	Aliases   *[]string             `json:"aliases,omitempty"`
+}
+
+func newHostRecordUpdate() *hostRecordUpdate {
+	return &hostRecordUpdate{IBBase: IBBase{objectType: "record:host"}}
+}
+
+// CreateHostRecordWithAddresses creates a host record with several IPv4
+// and IPv6 addresses and aliases. An address may be a next available
+// address function, e.g. "func:nextavailableip:10.0.0.0/24,default".
+func (objMgr *ObjectManager) CreateHostRecordWithAddresses(enabledns bool, recordName string, netview string, dnsview string,
+	ipv4Addrs []HostRecordIpv4Addr, ipv6Addrs []HostRecordIpv6Addr, aliases []string, opts RecordOptions) (*HostRecord, error) {
+	if len(ipv4Addrs) == 0 && len(ipv6Addrs) == 0 {
+		return nil, fmt.Errorf("host record '%s' requires at least one address", recordName)
+	}
+	if enabledns {
+		if err := objMgr.checkRecordConflict("record:host", recordName, dnsview); err != nil {
+			return nil, err
+		}
+	}
+
+	enableDNS := new(bool)
+	*enableDNS = enabledns
+	recordHost := NewHostRecord(HostRecord{
+		Name:        recordName,
+		EnableDns:   enableDNS,
+		NetworkView: netview,
+		View:        dnsview,
+		Ipv4Addrs:   hostIpv4AddrsForUpdate(ipv4Addrs),
+		Ipv6Addrs:   hostIpv6AddrsForUpdate(ipv6Addrs),
+		Aliases:     aliases,
+		Comment:     opts.Comment,
+		Ea:          objMgr.recordEA(opts, false)})
+	recordHost.Ttl, recordHost.UseTtl = opts.ttl()
+
+	ref, err := objMgr.createObject(recordHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.getHostRecordWithAddresses(ref)
+}
+
+func (objMgr *ObjectManager) getHostRecordWithAddresses(ref string) (*HostRecord, error) {
+	recordHost := NewHostRecord(HostRecord{})
+	recordHost.returnFields = hostRecordReturnFields
+	err := objMgr.readObject(recordHost, ref, &recordHost)
+	return recordHost, err
+}
+
+// hostIpv4AddrsForUpdate keeps the writable fields of the addresses
+func hostIpv4AddrsForUpdate(addrs []HostRecordIpv4Addr) []HostRecordIpv4Addr {
+	res := make([]HostRecordIpv4Addr, 0, len(addrs))
+	for _, a := range addrs {
+		res = append(res, HostRecordIpv4Addr{Ipv4Addr: a.Ipv4Addr, Mac: a.Mac, EnableDhcp: a.EnableDhcp})
+	}
+
+	return res
+}
+
+// hostIpv6AddrsForUpdate keeps the writable fields of the addresses
+func hostIpv6AddrsForUpdate(addrs []HostRecordIpv6Addr) []HostRecordIpv6Addr {
+	res := make([]HostRecordIpv6Addr, 0, len(addrs))
+	for _, a := range addrs {
+		res = append(res, HostRecordIpv6Addr{Ipv6Addr: a.Ipv6Addr, Duid: a.Duid, EnableDhcp: a.EnableDhcp})
+	}
+
+	return res
+}
+
+// isIPv6Addr tells whether ipAddr, or the network of a next available
+// address function, is IPv6
+func isIPv6Addr(ipAddr string) bool {
+	return strings.Contains(strings.TrimPrefix(ipAddr, "func:nextavailableip:"), ":")
+}
+
+// AddIPToHostRecord adds ipAddr to the addresses of the host record
+// referenced by ref, keeping the existing ones. macOrDuid is the MAC
+// address of an IPv4 address or the DUID of an IPv6 address, required when
+// enableDhcp is set. Adding an address the record already has is a no-op.
+func (objMgr *ObjectManager) AddIPToHostRecord(ref string, ipAddr string, macOrDuid string, enableDhcp bool) (*HostRecord, error) {
+	recordHost, err := objMgr.getHostRecordWithAddresses(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	update := newHostRecordUpdate()
+	dhcp := new(bool)
+	*dhcp = enableDhcp
+	if isIPv6Addr(ipAddr) {
+		for _, a := range recordHost.Ipv6Addrs {
+			if a.Ipv6Addr == ipAddr {
+				return recordHost, nil
+			}
+		}
+		addrs := append(hostIpv6AddrsForUpdate(recordHost.Ipv6Addrs),
+			HostRecordIpv6Addr{Ipv6Addr: ipAddr, Duid: macOrDuid, EnableDhcp: dhcp})
+		update.Ipv6Addrs = &addrs
+	} else {
+		for _, a := range recordHost.Ipv4Addrs {
+			if a.Ipv4Addr == ipAddr {
+				return recordHost, nil
+			}
+		}
+		addrs := append(hostIpv4AddrsForUpdate(recordHost.Ipv4Addrs),
+			HostRecordIpv4Addr{Ipv4Addr: ipAddr, Mac: macOrDuid, EnableDhcp: dhcp})
+		update.Ipv4Addrs = &addrs
+	}
+
+	newRef, err := objMgr.updateObject(update, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.getHostRecordWithAddresses(newRef)
+}
+
+// RemoveIPFromHostRecord removes ipAddr from the addresses of the host
+// record referenced by ref, keeping the other ones. The last address of a
+// record cannot be removed, the record has to be deleted instead.
+func (objMgr *ObjectManager) RemoveIPFromHostRecord(ref string, ipAddr string) (*HostRecord, error) {
+	recordHost, err := objMgr.getHostRecordWithAddresses(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	update := newHostRecordUpdate()
+	found := false
+	if isIPv6Addr(ipAddr) {
+		var addrs []HostRecordIpv6Addr
+		for _, a := range recordHost.Ipv6Addrs {
+			if a.Ipv6Addr == ipAddr {
+				found = true
+			} else {
+				addrs = append(addrs, a)
+			}
+		}
+		addrs = hostIpv6AddrsForUpdate(addrs)
+		update.Ipv6Addrs = &addrs
+	} else {
+		var addrs []HostRecordIpv4Addr
+		for _, a := range recordHost.Ipv4Addrs {
+			if a.Ipv4Addr == ipAddr {
+				found = true
+			} else {
+				addrs = append(addrs, a)
+			}
+		}
+		addrs = hostIpv4AddrsForUpdate(addrs)
+		update.Ipv4Addrs = &addrs
+	}
+
+	if !found {
+		return recordHost, nil
+	}
+	if len(recordHost.Ipv4Addrs)+len(recordHost.Ipv6Addrs) == 1 {
+		return nil, fmt.Errorf("cannot remove '%s', the last address of host record '%s'", ipAddr, recordHost.Name)
+	}
+
+	newRef, err := objMgr.updateObject(update, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.getHostRecordWithAddresses(newRef)
+}
+
+// UpdateHostRecordAliases replaces the aliases of the host record
+// referenced by ref, an empty list removes them all
+func (objMgr *ObjectManager) UpdateHostRecordAliases(ref string, aliases []string) (*HostRecord, error) {
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	update := newHostRecordUpdate()
+	update.Aliases = &aliases
+
+	newRef, err := objMgr.updateObject(update, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.getHostRecordWithAddresses(newRef)
+}
+
+// GetHostRecords returns the host records of names in the DNS view view,
+// all views if empty, keyed by name. The host records are searched with a
+// single request, the names without a host record are not in the map.
+func (objMgr *ObjectManager) GetHostRecords(names []string, view string) (map[string]HostRecord, error) {
+	res := make(map[string]HostRecord)
+
+	var searched []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			searched = append(searched, name)
+		}
+	}
+	if len(searched) == 0 {
+		return res, nil
+	}
+
+	b := NewMultiRequestBuilder()
+	hosts := make([][]HostRecord, len(searched))
+	for i, name := range searched {
+		b.AddRead(NewHostRecord(HostRecord{Name: name, View: view}), "", &hosts[i])
+	}
+	if _, err := objMgr.ExecuteMultiRequest(b); err != nil {
+		return nil, err
+	}
+
+	for i, name := range searched {
+		if len(hosts[i]) > 0 {
+			res[name] = hosts[i][0]
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,171 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Host Records", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostRef := "record:host/ZG5zLmhvc3Q:web.example.com/default"
+	requestBody := func(obj IBObject) string {
+		var body map[string]interface{}
+		js, _ := json.Marshal(obj)
+		json.Unmarshal(js, &body)
+		delete(body, "_ref")
+		delete(body, "extattrs")
+		js, _ = json.Marshal(body)
+		return string(js)
+	}
+
+	Describe("Create Host Record With Addresses", func() {
+		conn := &fakeMultiConnector{
+			createRefs: []string{hostRef},
+			getResults: map[string]interface{}{
+				hostRef: HostRecord{Ref: hostRef, Name: "web.example.com",
+					Ipv4Addrs: []HostRecordIpv4Addr{{Ipv4Addr: "10.0.0.10"}, {Ipv4Addr: "10.0.0.11"}},
+					Ipv6Addrs: []HostRecordIpv6Addr{{Ipv6Addr: "2001:db8::10"}},
+					Aliases:   []string{"www.example.com"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should send every address and alias and return the created record", func() {
+			dhcp := true
+			host, err := objMgr.CreateHostRecordWithAddresses(true, "web.example.com", "default", "default",
+				[]HostRecordIpv4Addr{
+					{Ipv4Addr: "10.0.0.10", Mac: "11:22:33:44:55:66", EnableDhcp: &dhcp},
+					{Ipv4Addr: "func:nextavailableip:10.0.0.0/24,default"}},
+				[]HostRecordIpv6Addr{{Ipv6Addr: "2001:db8::10"}},
+				[]string{"www.example.com"}, RecordOptions{})
+			Expect(err).To(BeNil())
+			Expect(host.Ref).To(Equal(hostRef))
+			Expect(host.Ipv4Addrs).To(HaveLen(2))
+			Expect(host.Aliases).To(Equal([]string{"www.example.com"}))
+
+			Expect(requestBody(conn.createObjs[0])).To(MatchJSON(`{"name": "web.example.com",
+				"configure_for_dns": true, "network_view": "default", "view": "default",
+				"ipv4addrs": [
+					{"ipv4addr": "10.0.0.10", "mac": "11:22:33:44:55:66", "configure_for_dhcp": true},
+					{"ipv4addr": "func:nextavailableip:10.0.0.0/24,default"}],
+				"ipv6addrs": [{"ipv6addr": "2001:db8::10"}],
+				"aliases": ["www.example.com"]}`))
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("ipv6addrs"))
+		})
+
+		It("should require an address", func() {
+			_, err := objMgr.CreateHostRecordWithAddresses(true, "web.example.com", "default", "default",
+				nil, nil, nil, RecordOptions{})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Add and Remove IP of Host Record", func() {
+		existing := HostRecord{Ref: hostRef, Name: "web.example.com",
+			Ipv4Addrs: []HostRecordIpv4Addr{{
+				Ref:      "record:host_ipv4addr/ZG5zLmhvc3RfYWRkcmVzcw:10.0.0.10/web.example.com/default",
+				Ipv4Addr: "10.0.0.10", Mac: "11:22:33:44:55:66", Host: "web.example.com"}},
+			Ipv6Addrs: []HostRecordIpv6Addr{{Ipv6Addr: "2001:db8::10"}}}
+
+		It("should keep the existing addresses when adding one", func() {
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{hostRef: existing}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.AddIPToHostRecord(hostRef, "10.0.0.11", "aa:bb:cc:dd:ee:ff", true)
+			Expect(err).To(BeNil())
+			Expect(conn.updateRefs).To(Equal([]string{hostRef}))
+			Expect(requestBody(conn.updateObjs[0])).To(MatchJSON(`{"ipv4addrs": [
+				{"ipv4addr": "10.0.0.10", "mac": "11:22:33:44:55:66"},
+				{"ipv4addr": "10.0.0.11", "mac": "aa:bb:cc:dd:ee:ff", "configure_for_dhcp": true}]}`))
+		})
+
+		It("should add an IPv6 address to the IPv6 addresses only", func() {
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{hostRef: existing}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.AddIPToHostRecord(hostRef, "2001:db8::11", "", false)
+			Expect(err).To(BeNil())
+			Expect(requestBody(conn.updateObjs[0])).To(MatchJSON(`{"ipv6addrs": [
+				{"ipv6addr": "2001:db8::10"},
+				{"ipv6addr": "2001:db8::11", "configure_for_dhcp": false}]}`))
+		})
+
+		It("should not update the record when it already has the address", func() {
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{hostRef: existing}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			host, err := objMgr.AddIPToHostRecord(hostRef, "10.0.0.10", "", false)
+			Expect(err).To(BeNil())
+			Expect(host.Ref).To(Equal(hostRef))
+			Expect(conn.updateObjs).To(BeEmpty())
+		})
+
+		It("should remove the address and clear the emptied list", func() {
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{hostRef: existing}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.RemoveIPFromHostRecord(hostRef, "10.0.0.10")
+			Expect(err).To(BeNil())
+			Expect(requestBody(conn.updateObjs[0])).To(MatchJSON(`{"ipv4addrs": []}`))
+		})
+
+		It("should refuse to remove the last address", func() {
+			last := HostRecord{Ref: hostRef, Name: "web.example.com",
+				Ipv4Addrs: []HostRecordIpv4Addr{{Ipv4Addr: "10.0.0.10"}}}
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{hostRef: last}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.RemoveIPFromHostRecord(hostRef, "10.0.0.10")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.updateObjs).To(BeEmpty())
+		})
+	})
+
+	Describe("Update Host Record Aliases", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should send an empty list to remove the aliases", func() {
+			_, err := objMgr.UpdateHostRecordAliases(hostRef, nil)
+			Expect(err).To(BeNil())
+			Expect(requestBody(conn.updateObjs[0])).To(MatchJSON(`{"aliases": []}`))
+		})
+	})
+
+	Describe("Get Host Records", func() {
+		It("should search the host records of the names with a single request", func() {
+			requestor := &multiRequestor{res: []byte(`[
+				[{"_ref": "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLndlYg:web.example.com/default",
+					"name": "web.example.com", "view": "default"}],
+				[]]`)}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			hosts, err := objMgr.GetHostRecords([]string{"web.example.com", "db.example.com", "web.example.com"}, "default")
+			Expect(err).To(BeNil())
+			Expect(hosts).To(HaveLen(1))
+			Expect(hosts["web.example.com"].Ref).To(Equal(
+				"record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLndlYg:web.example.com/default"))
+
+			Expect(requestor.body).To(HaveLen(2))
+			Expect(requestor.body[1]["method"]).To(Equal("GET"))
+			Expect(requestor.body[1]["object"]).To(Equal("record:host"))
+			Expect(requestor.body[1]["data"]).To(Equal(map[string]interface{}{
+				"name": "db.example.com", "view": "default"}))
+		})
+
+		It("should not send a request without names", func() {
+			requestor := &multiRequestor{}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			hosts, err := objMgr.GetHostRecords(nil, "default")
+			Expect(err).To(BeNil())
+			Expect(hosts).To(BeEmpty())
+			Expect(requestor.body).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package ibclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// IdempotencyKeyEA is the string EA holding the idempotency key of the
+// objects created by an ObjectManager returned by WithIdempotencyKey. The
+// EA must be defined on the grid.
+const IdempotencyKeyEA = "Idempotency Key"
+
+// WithIdempotencyKey returns a copy of the ObjectManager stamping key,
+// qualified by the type and the fields of each object, as the
+// IdempotencyKeyEA of the objects it creates. Before creating an object,
+// it looks for the object already holding its key and returns its ref
+// instead, so that a retried operation does not create duplicates while
+// the other objects created with key are still created. The check is best
+// effort: the search and the create are separate requests, so concurrent
+// operations with the same key may both create the object.
+func (objMgr *ObjectManager) WithIdempotencyKey(key string) *ObjectManager {
+	res := *objMgr
+	res.idempotencyKey = key
+
+	return &res
+}
+
+// eaField returns the Ea field of obj, which is not valid if obj has no
+// EAs
+func eaField(obj IBObject) reflect.Value {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	field := v.FieldByName("Ea")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(EA{}) {
+		return reflect.Value{}
+	}
+
+	return field
+}
+
+// stampIdempotencyKey sets the idempotency key EA on the EAs of obj
+func stampIdempotencyKey(obj IBObject, key string) error {
+	field := eaField(obj)
+	if !field.IsValid() {
+		return fmt.Errorf("'%s' objects have no EAs to hold the idempotency key", obj.ObjectType())
+	}
+
+	if field.IsNil() {
+		field.Set(reflect.ValueOf(make(EA)))
+	}
+	field.Interface().(EA)[IdempotencyKeyEA] = key
+
+	return nil
+}
+
+// volatileEAs are the EAs computed at the time of the operation, e.g. an
+// expiry, which differ each time a retried operation creates its objects
+var volatileEAs = []string{ReservationExpiresEA}
+
+// objectIdempotencyKey returns key qualified by the type and the fields of
+// obj, which tells obj from the other objects created with key. The
+// volatileEAs are not part of the fields.
+func objectIdempotencyKey(obj IBObject, key string) (string, error) {
+	js, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]interface{}
+	if err = json.Unmarshal(js, &fields); err != nil {
+		return "", err
+	}
+	if ea, ok := fields["extattrs"].(map[string]interface{}); ok {
+		for _, name := range volatileEAs {
+			delete(ea, name)
+		}
+	}
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(obj.ObjectType()+"\n"), body...))
+
+	return key + ":" + hex.EncodeToString(sum[:8]), nil
+}
+
+// createObject creates obj or, when the ObjectManager has an idempotency
+// key, returns the ref of the object already holding the key of obj
+func (objMgr *ObjectManager) createObject(obj IBObject) (string, error) {
+	if err := objMgr.validateObjectEA(obj); err != nil {
+		return "", err
+	}
+	if objMgr.idempotencyKey == "" {
+		return objMgr.createAllocated(obj)
+	}
+
+	key, err := objectIdempotencyKey(obj, objMgr.idempotencyKey)
+	if err != nil {
+		return "", err
+	}
+
+	var res []map[string]interface{}
+	search := &IBBase{
+		objectType: obj.ObjectType(),
+		eaSearch:   EASearch{IdempotencyKeyEA: key}}
+	if err := objMgr.connector.GetObject(search, "", &res); err != nil {
+		return "", err
+	}
+	if len(res) > 0 {
+		if ref, ok := res[0]["_ref"].(string); ok && ref != "" {
+			return ref, nil
+		}
+	}
+
+	if err := stampIdempotencyKey(obj, key); err != nil {
+		return "", err
+	}
+	return objMgr.createAllocated(obj)
+}
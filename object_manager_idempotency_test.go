@@ -0,0 +1,74 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Idempotency Keys", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	serverRef := "dtc:server/ZG5zLmlkbnNfc2VydmVyJHdlYjE:web1"
+
+	It("should stamp the key of the object on the objects created", func() {
+		conn := &fakeMultiConnector{createRefs: []string{serverRef}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID).WithIdempotencyKey("op-1234")
+
+		server, err := objMgr.CreateDtcServer(DtcServer{Name: "web1", Host: "10.0.0.10"})
+		Expect(err).To(BeNil())
+		Expect(server.Ref).To(Equal(serverRef))
+		Expect(conn.getObjs[0].ObjectType()).To(Equal("dtc:server"))
+
+		key := conn.getObjs[0].EaSearch()[IdempotencyKeyEA].(string)
+		Expect(key).To(HavePrefix("op-1234:"))
+		Expect(conn.createObjs[0].(*DtcServer).Ea).To(Equal(EA{IdempotencyKeyEA: key}))
+	})
+
+	It("should give each object created with the key its own key", func() {
+		conn := &fakeMultiConnector{createRefs: []string{serverRef, serverRef, serverRef}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID).WithIdempotencyKey("op-1234")
+
+		_, err := objMgr.CreateDtcServer(DtcServer{Name: "web1", Host: "10.0.0.10"})
+		Expect(err).To(BeNil())
+		_, err = objMgr.CreateDtcServer(DtcServer{Name: "web2", Host: "10.0.0.11"})
+		Expect(err).To(BeNil())
+		_, err = objMgr.CreateDtcServer(DtcServer{Name: "web1", Host: "10.0.0.10"})
+		Expect(err).To(BeNil())
+
+		keys := []interface{}{}
+		for _, obj := range conn.getObjs {
+			keys = append(keys, obj.EaSearch()[IdempotencyKeyEA])
+		}
+		Expect(keys[0]).NotTo(Equal(keys[1]))
+		Expect(keys[2]).To(Equal(keys[0]))
+	})
+
+	It("should return the object already holding the key", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"dtc:server": []map[string]interface{}{{"_ref": serverRef, "name": "web1"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID).WithIdempotencyKey("op-1234")
+
+		server, err := objMgr.CreateDtcServer(DtcServer{Name: "web1", Host: "10.0.0.10"})
+		Expect(err).To(BeNil())
+		Expect(server.Ref).To(Equal(serverRef))
+		Expect(conn.createObjs).To(BeEmpty())
+	})
+
+	It("should not search without a key", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, err := objMgr.CreateDtcServer(DtcServer{Name: "web1", Host: "10.0.0.10"})
+		Expect(err).To(BeNil())
+		Expect(conn.getObjs).To(BeEmpty())
+		Expect(conn.createObjs[0].(*DtcServer).Ea).ToNot(HaveKey(IdempotencyKeyEA))
+	})
+
+	It("should refuse objects without EAs", func() {
+		err := stampIdempotencyKey(&gridRestartServices{IBBase: IBBase{objectType: "grid"}}, "op-1234")
+		Expect(err).To(MatchError("'grid' objects have no EAs to hold the idempotency key"))
+	})
+})
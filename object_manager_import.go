@@ -0,0 +1,259 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// ImportState describes the networks, host records and DNS records to
+// import into the grid, e.g. converted from the state of an infrastructure
+// as code tool. Its JSON form is read by ParseImportState.
+type ImportState struct {
+	Networks     []ImportNetwork     `json:"networks,omitempty"`
+	Hosts        []ImportHost        `json:"hosts,omitempty"`
+	ARecords     []ImportARecord     `json:"a_records,omitempty"`
+	CNAMERecords []ImportCNAMERecord `json:"cname_records,omitempty"`
+}
+
+// ImportNetwork is an IPv4 or IPv6 network to import
+type ImportNetwork struct {
+	NetworkView string `json:"network_view"`
+	Cidr        string `json:"network"`
+	Name        string `json:"name,omitempty"`
+	Template    string `json:"template,omitempty"`
+}
+
+// ImportHost is a host record to import. DNS is enabled unless EnableDns
+// is false.
+type ImportHost struct {
+	Name        string               `json:"name"`
+	NetworkView string               `json:"network_view,omitempty"`
+	View        string               `json:"view,omitempty"`
+	EnableDns   *bool                `json:"configure_for_dns,omitempty"`
+	Ipv4Addrs   []HostRecordIpv4Addr `json:"ipv4addrs,omitempty"`
+	Ipv6Addrs   []HostRecordIpv6Addr `json:"ipv6addrs,omitempty"`
+	Aliases     []string             `json:"aliases,omitempty"`
+	Comment     string               `json:"comment,omitempty"`
+	Ea          EA                   `json:"extattrs,omitempty"`
+}
+
+// ImportARecord is an A record to import
+type ImportARecord struct {
+	Name     string `json:"name"`
+	View     string `json:"view,omitempty"`
+	Ipv4Addr string `json:"ipv4addr"`
+	Comment  string `json:"comment,omitempty"`
+	Ea       EA     `json:"extattrs,omitempty"`
+}
+
+// ImportCNAMERecord is a CNAME record to import
+type ImportCNAMERecord struct {
+	Name      string `json:"name"`
+	View      string `json:"view,omitempty"`
+	Canonical string `json:"canonical"`
+	Comment   string `json:"comment,omitempty"`
+	Ea        EA     `json:"extattrs,omitempty"`
+}
+
+// ParseImportState parses the JSON description of an ImportState
+func ParseImportState(data []byte) (*ImportState, error) {
+	var state ImportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("cannot parse import state: %s", err)
+	}
+
+	return &state, nil
+}
+
+// LoadImportState reads the ImportState of the JSON file path
+func LoadImportState(path string) (*ImportState, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseImportState(data)
+}
+
+// Actions of the objects of an ImportReport
+const (
+	ImportCreated  = "created"
+	ImportExisting = "existing"
+	ImportFailed   = "failed"
+)
+
+// ImportResult is the outcome of the import of an object: its ref and
+// ImportCreated or ImportExisting, or ImportFailed and the error
+type ImportResult struct {
+	ObjectType string
+	Name       string
+	Ref        string
+	Action     string
+	Err        error
+}
+
+// ImportReport lists the outcome of the import of each object, in the
+// order they were imported
+type ImportReport struct {
+	Results []ImportResult
+}
+
+// Count returns the number of objects of the report with action
+func (r *ImportReport) Count(action string) int {
+	count := 0
+	for _, res := range r.Results {
+		if res.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *ImportReport) add(objectType string, name string, ref string, created bool, err error) {
+	res := ImportResult{ObjectType: objectType, Name: name, Ref: ref, Action: ImportExisting}
+	if err != nil {
+		res.Action = ImportFailed
+		res.Err = err
+	} else if created {
+		res.Action = ImportCreated
+	}
+	r.Results = append(r.Results, res)
+}
+
+// EnsureNetwork returns the IPv4 or IPv6 network cidr of the network view
+// netview, creating it with name and template if it does not exist. It
+// returns true when the network was created.
+func (objMgr *ObjectManager) EnsureNetwork(netview string, cidr string, name string, template string) (*Network, bool, error) {
+	if isIPv6CIDR(cidr) {
+		network, err := objMgr.withDefaultReturnFields().GetIPv6Network(netview, cidr, nil)
+		if err != nil || network != nil {
+			return network, false, err
+		}
+		network, err = objMgr.CreateIPv6Network(netview, cidr, name)
+		return network, err == nil, err
+	}
+
+	network, err := objMgr.withDefaultReturnFields().GetNetwork(netview, cidr, nil)
+	if err != nil || network != nil {
+		return network, false, err
+	}
+	network, err = objMgr.CreateNetworkWithTemplate(netview, cidr, name, template)
+	return network, err == nil, err
+}
+
+// EnsureHostRecord returns the host record recordName of the DNS view
+// dnsview, creating it as CreateHostRecordWithAddresses does if it does not
+// exist. An existing host record is not changed. It returns true when the
+// host record was created.
+func (objMgr *ObjectManager) EnsureHostRecord(enabledns bool, recordName string, netview string, dnsview string,
+	ipv4Addrs []HostRecordIpv4Addr, ipv6Addrs []HostRecordIpv6Addr, aliases []string, opts RecordOptions) (*HostRecord, bool, error) {
+	var res []HostRecord
+
+	recordHost := NewHostRecord(HostRecord{Name: recordName, View: dnsview})
+	if err := objMgr.readObject(recordHost, "", &res); err != nil {
+		return nil, false, err
+	}
+	if len(res) > 0 {
+		return &res[0], false, nil
+	}
+
+	host, err := objMgr.CreateHostRecordWithAddresses(enabledns, recordName, netview, dnsview,
+		ipv4Addrs, ipv6Addrs, aliases, opts)
+	return host, err == nil, err
+}
+
+// EnsureARecord returns the A record recordname of ipAddr in the DNS view
+// dnsview, creating it if it does not exist. It returns true when the A
+// record was created.
+func (objMgr *ObjectManager) EnsureARecord(dnsview string, recordname string, ipAddr string, opts RecordOptions) (*RecordA, bool, error) {
+	var res []RecordA
+
+	recordA := NewRecordA(RecordA{Name: recordname, View: dnsview, Ipv4Addr: ipAddr})
+	if err := objMgr.readObject(recordA, "", &res); err != nil {
+		return nil, false, err
+	}
+	if len(res) > 0 {
+		return &res[0], false, nil
+	}
+
+	recordA, err := objMgr.CreateARecordWithOptions("", dnsview, recordname, "", ipAddr, opts)
+	return recordA, err == nil, err
+}
+
+// EnsureCNAMERecord returns the CNAME record recordname of the DNS view
+// dnsview, creating it if it does not exist. An existing CNAME record with
+// another canonical name is an error. It returns true when the CNAME
+// record was created.
+func (objMgr *ObjectManager) EnsureCNAMERecord(canonical string, recordname string, dnsview string, opts RecordOptions) (*RecordCNAME, bool, error) {
+	var res []RecordCNAME
+
+	recordCNAME := NewRecordCNAME(RecordCNAME{Name: recordname, View: dnsview})
+	if err := objMgr.readObject(recordCNAME, "", &res); err != nil {
+		return nil, false, err
+	}
+	if len(res) > 0 {
+		if res[0].Canonical != canonical {
+			return nil, false, fmt.Errorf("CNAME record '%s' points to '%s' instead of '%s'",
+				recordname, res[0].Canonical, canonical)
+		}
+		return &res[0], false, nil
+	}
+
+	recordCNAME, err := objMgr.CreateCNAMERecordWithOptions(canonical, recordname, dnsview, opts)
+	return recordCNAME, err == nil, err
+}
+
+// Import ensures the objects of state exist, networks first, then host
+// records, A records and CNAME records, so that the hosts and records can
+// use the networks. The objects which already exist are left unchanged.
+// The import goes on after a failure, the report lists the outcome of each
+// object and an error is returned when some of them failed.
+func (objMgr *ObjectManager) Import(state *ImportState) (*ImportReport, error) {
+	report := &ImportReport{}
+
+	for _, n := range state.Networks {
+		network, created, err := objMgr.EnsureNetwork(n.NetworkView, n.Cidr, n.Name, n.Template)
+		ref := ""
+		if network != nil {
+			ref = network.Ref
+		}
+		report.add("network", n.Cidr, ref, created, err)
+	}
+
+	for _, h := range state.Hosts {
+		enableDNS := h.EnableDns == nil || *h.EnableDns
+		host, created, err := objMgr.EnsureHostRecord(enableDNS, h.Name, h.NetworkView, h.View,
+			h.Ipv4Addrs, h.Ipv6Addrs, h.Aliases, RecordOptions{Comment: h.Comment, Ea: h.Ea})
+		ref := ""
+		if host != nil {
+			ref = host.Ref
+		}
+		report.add("record:host", h.Name, ref, created, err)
+	}
+
+	for _, a := range state.ARecords {
+		recordA, created, err := objMgr.EnsureARecord(a.View, a.Name, a.Ipv4Addr,
+			RecordOptions{Comment: a.Comment, Ea: a.Ea})
+		ref := ""
+		if recordA != nil {
+			ref = recordA.Ref
+		}
+		report.add("record:a", a.Name, ref, created, err)
+	}
+
+	for _, c := range state.CNAMERecords {
+		recordCNAME, created, err := objMgr.EnsureCNAMERecord(c.Canonical, c.Name, c.View,
+			RecordOptions{Comment: c.Comment, Ea: c.Ea})
+		ref := ""
+		if recordCNAME != nil {
+			ref = recordCNAME.Ref
+		}
+		report.add("record:cname", c.Name, ref, created, err)
+	}
+
+	if failed := report.Count(ImportFailed); failed > 0 {
+		return report, fmt.Errorf("%d of %d objects failed to import", failed, len(report.Results))
+	}
+	return report, nil
+}
@@ -0,0 +1,61 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager import", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	networkRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLndlYg:web.example.com/default"
+	aRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsYXBpLDEwLjAuMC42:api.example.com/default"
+
+	state := []byte(`{
+		"networks": [{"network_view": "default", "network": "10.0.0.0/24", "name": "web"}],
+		"hosts": [{"name": "web.example.com", "view": "default", "network_view": "default",
+			"ipv4addrs": [{"ipv4addr": "10.0.0.5", "mac": "00:00:00:00:00:05"}]}],
+		"a_records": [{"name": "api.example.com", "view": "default", "ipv4addr": "10.0.0.6"}],
+		"cname_records": [{"name": "www.example.com", "view": "default", "canonical": "web.example.com"}]
+	}`)
+
+	It("should ensure the objects of the state exist and report them", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"network":      []Network{{Ref: networkRef, NetviewName: "default", Cidr: "10.0.0.0/24"}},
+				hostRef:        HostRecord{Ref: hostRef, Name: "web.example.com"},
+				"record:cname": []RecordCNAME{{Name: "www.example.com", Canonical: "old.example.com"}},
+			},
+			createRefs: []string{hostRef, aRef},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		importState, err := ParseImportState(state)
+		Expect(err).To(BeNil())
+		report, err := objMgr.Import(importState)
+		Expect(err).To(MatchError("1 of 4 objects failed to import"))
+
+		Expect(report.Results).To(HaveLen(4))
+		Expect(report.Results[0]).To(Equal(ImportResult{ObjectType: "network", Name: "10.0.0.0/24",
+			Ref: networkRef, Action: ImportExisting}))
+		Expect(report.Results[1]).To(Equal(ImportResult{ObjectType: "record:host", Name: "web.example.com",
+			Ref: hostRef, Action: ImportCreated}))
+		Expect(report.Results[2]).To(Equal(ImportResult{ObjectType: "record:a", Name: "api.example.com",
+			Ref: aRef, Action: ImportCreated}))
+		Expect(report.Results[3].Action).To(Equal(ImportFailed))
+		Expect(report.Results[3].Err).To(MatchError(
+			"CNAME record 'www.example.com' points to 'old.example.com' instead of 'web.example.com'"))
+		Expect(report.Count(ImportCreated)).To(Equal(2))
+
+		Expect(conn.createObjs).To(HaveLen(2))
+		host := conn.createObjs[0].(*HostRecord)
+		Expect(*host.EnableDns).To(BeTrue())
+		Expect(host.Ipv4Addrs[0].Mac).To(Equal("00:00:00:00:00:05"))
+	})
+
+	It("should not parse an invalid state", func() {
+		_, err := ParseImportState([]byte(`{"networks": {}}`))
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,105 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+const (
+	// IPAddressUsed is the status of an address used by an object, or seen
+	// on the network
+	IPAddressUsed = "USED"
+	// IPAddressUnused is the status of an address free to allocate
+	IPAddressUnused = "UNUSED"
+)
+
+// GetIPv4Addresses returns the addresses of the network cidr of netview
+// with the given status, IPAddressUsed or IPAddressUnused, or all of them
+// if status is empty. Conflicting addresses have IsConflict set.
+func (objMgr *ObjectManager) GetIPv4Addresses(netview string, cidr string, status string) ([]IPv4Address, error) {
+	var res []IPv4Address
+
+	addr := NewIPv4Address(IPv4Address{
+		NetviewName: netview,
+		Network:     cidr,
+		Status:      status})
+	addr.returnFields = append(addr.returnFields, "conflict_types", "is_conflict")
+
+	err := objMgr.getObjectPaged(addr, 0, &res)
+	return res, err
+}
+
+// GetIPv6Addresses returns the addresses of the IPv6 network cidr of
+// netview with the given status, or all of them if status is empty
+func (objMgr *ObjectManager) GetIPv6Addresses(netview string, cidr string, status string) ([]IPv6Address, error) {
+	var res []IPv6Address
+
+	addr := NewIPv6Address(IPv6Address{
+		NetviewName: netview,
+		Network:     cidr,
+		Status:      status})
+
+	err := objMgr.getObjectPaged(addr, 0, &res)
+	return res, err
+}
+
+// GetIPv6Address returns the IPAM status of the IPv6 address ipAddr in the
+// network view and the objects using it
+func (objMgr *ObjectManager) GetIPv6Address(netview string, ipAddr string) (*IPv6Address, error) {
+	var res []IPv6Address
+
+	addr := NewIPv6Address(IPv6Address{
+		NetviewName: netview,
+		IPAddress:   ipAddr})
+
+	err := objMgr.getObject(addr, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// nextAvailableIPs holds the arguments of the next_available_ip function
+// of a network
+type nextAvailableIPs struct {
+	IBBase  `json:"-"`
+	Num     int      `json:"num"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// GetNextAvailableIPs returns the num next available addresses of the
+// network cidr of netview, skipping the addresses of exclude. The addresses
+// are not reserved; allocate them with AllocateIP.
+func (objMgr *ObjectManager) GetNextAvailableIPs(netview string, cidr string, num int, exclude []string) ([]string, error) {
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return nil, err
+	}
+
+	var network *Network
+	if isIPv6CIDR(cidr) {
+		network, err = objMgr.withDefaultReturnFields().GetIPv6Network(netview, cidr, nil)
+	} else {
+		network, err = objMgr.withDefaultReturnFields().GetNetwork(netview, cidr, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if network == nil {
+		return nil, fmt.Errorf("network '%s' not found in network view '%s'", cidr, netview)
+	}
+
+	if num <= 0 {
+		num = 1
+	}
+	args := &nextAvailableIPs{Num: num, Exclude: exclude}
+
+	var res struct {
+		Ips []string `json:"ips"`
+	}
+	if err = conn.callFunction(network.Ref, "next_available_ip", args, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Ips, nil
+}
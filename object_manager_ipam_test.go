@@ -0,0 +1,95 @@
+package ibclient
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager IPAM", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	Describe("Get IP Addresses", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"ipv4address": []IPv4Address{
+					{IPAddress: "10.0.0.1", Status: IPAddressUsed, Types: []string{"FA"}, IsConflict: true,
+						Objects: []string{"fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.1/default"}},
+				},
+				"ipv6address": []IPv6Address{
+					{IPAddress: "2001:db8::1", Status: IPAddressUsed, Duid: "00:01:00:01"},
+				},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should search the addresses of the network with the status", func() {
+			addrs, err := objMgr.GetIPv4Addresses("default", "10.0.0.0/24", IPAddressUsed)
+			Expect(err).To(BeNil())
+			Expect(addrs[0].Types).To(Equal([]string{"FA"}))
+			Expect(addrs[0].IsConflict).To(BeTrue())
+
+			search := conn.getObjs[0].(*IPv4Address)
+			Expect(search.Network).To(Equal("10.0.0.0/24"))
+			Expect(search.Status).To(Equal("USED"))
+			Expect(search.ReturnFields()).To(ContainElement("is_conflict"))
+		})
+
+		It("should look an IPv6 address up in ipv6address", func() {
+			addr, err := objMgr.GetIPv6Address("default", "2001:db8::1")
+			Expect(err).To(BeNil())
+			Expect(addr.Duid).To(Equal("00:01:00:01"))
+			Expect(conn.getObjs[1].ObjectType()).To(Equal("ipv6address"))
+
+			addrs, err := objMgr.GetIPv6Addresses("default", "2001:db8::/64", "")
+			Expect(err).To(BeNil())
+			Expect(len(addrs)).To(Equal(1))
+		})
+	})
+
+	Describe("Get Next Available IPs", func() {
+		hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+
+		It("should call next_available_ip on the network with the excluded addresses", func() {
+			requestor := &fakeReportingRequestor{
+				res: [][]byte{
+					[]byte(`[{"_ref": "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default",
+						"network": "10.0.0.0/24", "network_view": "default"}]`),
+					[]byte(`{"ips": ["10.0.0.3", "10.0.0.5"]}`),
+				},
+			}
+			wrb := &WapiRequestBuilder{}
+			wrb.Init(hostConfig)
+			conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			ips, err := objMgr.GetNextAvailableIPs("default", "10.0.0.0/24", 2, []string{"10.0.0.4"})
+			Expect(err).To(BeNil())
+			Expect(ips).To(Equal([]string{"10.0.0.3", "10.0.0.5"}))
+
+			Expect(requestor.reqs[1].URL.String()).To(Equal("https://172.22.18.66:443/wapi/v2.11/" +
+				"network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default?_function=next_available_ip"))
+			Expect(requestor.body[1]).To(MatchJSON(`{"num": 2, "exclude": ["10.0.0.4"]}`))
+		})
+
+		It("should fail when the network does not exist", func() {
+			requestor := &fakeReportingRequestor{res: [][]byte{[]byte(`[]`), []byte(`[]`)}}
+			conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.GetNextAvailableIPs("default", "10.0.0.0/24", 1, nil)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("should fail without panicking when the connector is not a Connector", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.GetNextAvailableIPs("default", "10.0.0.0/24", 1, nil)
+			Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+			Expect(conn.getObjs).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,241 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+func (objMgr *ObjectManager) CreateIPv6Network(netview string, cidr string, name string) (*Network, error) {
+	if objMgr.CheckNetworkOverlap {
+		if err := objMgr.checkNetworkOverlap(netview, cidr); err != nil {
+			return nil, err
+		}
+	}
+
+	network := NewIPv6Network(Network{
+		NetviewName: netview,
+		Cidr:        cidr,
+		Ea:          objMgr.getBasicEA(true)})
+
+	if name != "" {
+		network.Ea["Network Name"] = name
+	}
+	ref, err := objMgr.createObject(network)
+	if err != nil {
+		return nil, err
+	}
+	network.Ref = ref
+
+	return network, err
+}
+
+func (objMgr *ObjectManager) CreateIPv6NetworkContainer(netview string, cidr string) (*NetworkContainer, error) {
+	container := NewIPv6NetworkContainer(NetworkContainer{
+		NetviewName: netview,
+		Cidr:        cidr,
+		Ea:          objMgr.getBasicEA(true)})
+
+	ref, err := objMgr.createObject(container)
+	container.Ref = ref
+
+	return container, err
+}
+
+func (objMgr *ObjectManager) GetIPv6Network(netview string, cidr string, ea EA) (*Network, error) {
+	var res []Network
+
+	network := NewIPv6Network(Network{
+		NetviewName: netview})
+
+	if cidr != "" {
+		network.Cidr = cidr
+	}
+
+	if ea != nil && len(ea) > 0 {
+		network.eaSearch = EASearch(ea)
+	}
+
+	err := objMgr.getObject(network, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateIPv6NetworkOptions replaces the DHCPv6 options of the IPv6
+// network referenced by ref
+func (objMgr *ObjectManager) UpdateIPv6NetworkOptions(ref string, options []DhcpOption) (*Network, error) {
+	network := NewIPv6Network(Network{Options: options})
+	network.returnFields = append(network.returnFields, "options")
+
+	newRef, err := objMgr.updateObject(network, ref)
+	network.Ref = newRef
+	return network, err
+}
+
+func (objMgr *ObjectManager) GetIPv6NetworkContainer(netview string, cidr string) (*NetworkContainer, error) {
+	var res []NetworkContainer
+
+	nwcontainer := NewIPv6NetworkContainer(NetworkContainer{
+		NetviewName: netview,
+		Cidr:        cidr})
+
+	err := objMgr.getObject(nwcontainer, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+func (objMgr *ObjectManager) AllocateIPv6Network(netview string, cidr string, prefixLen uint, name string) (network *Network, err error) {
+	network = nil
+
+	if err = validatePrefixLen(cidr, prefixLen); err != nil {
+		return
+	}
+
+	networkReq := NewIPv6Network(Network{
+		NetviewName: netview,
+		Cidr:        fmt.Sprintf("func:nextavailablenetwork:%s,%s,%d", cidr, netview, prefixLen),
+		Ea:          objMgr.getBasicEA(true)})
+	if name != "" {
+		networkReq.Ea["Network Name"] = name
+	}
+
+	ref, err := objMgr.createObject(networkReq)
+	if err != nil {
+		err = objMgr.allocationError(netview, cidr, prefixLen, err)
+		return
+	}
+	if len(ref) > 0 {
+		network = BuildIPv6NetworkFromRef(ref)
+	}
+
+	return
+}
+
+// AllocateIPv6 reserves ipAddr, or the next available address of cidr if
+// ipAddr is empty, for the DHCPv6 client with the given DUID
+func (objMgr *ObjectManager) AllocateIPv6(netview string, cidr string, ipAddr string, duid string, name string, vmID string, vmName string) (*FixedAddress, error) {
+	if len(duid) == 0 {
+		duid = MACADDR_ZERO
+	}
+
+	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+	fixedAddr := NewIPv6FixedAddress(FixedAddress{
+		NetviewName: netview,
+		Cidr:        cidr,
+		Duid:        duid,
+		Name:        name,
+		Ea:          ea})
+
+	if ipAddr == "" {
+		fixedAddr.IPv6Address = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		fixedAddr.IPv6Address = ipAddr
+	}
+
+	ref, err := objMgr.createObject(fixedAddr)
+	fixedAddr.Ref = ref
+	fixedAddr.IPv6Address = GetIPAddressFromRef(ref)
+
+	return fixedAddr, err
+}
+
+func (objMgr *ObjectManager) GetIPv6FixedAddress(netview string, cidr string, ipAddr string, duid string) (*FixedAddress, error) {
+	var res []FixedAddress
+
+	fixedAddr := NewIPv6FixedAddress(FixedAddress{
+		NetviewName: netview,
+		Cidr:        cidr,
+		IPv6Address: ipAddr})
+
+	if duid != "" {
+		fixedAddr.Duid = duid
+	}
+
+	err := objMgr.getObject(fixedAddr, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+func (objMgr *ObjectManager) ReleaseIPv6(netview string, cidr string, ipAddr string, duid string) (string, error) {
+	fixAddress, _ := objMgr.withDefaultReturnFields().GetIPv6FixedAddress(netview, cidr, ipAddr, duid)
+	if fixAddress == nil {
+		return "", nil
+	}
+	return objMgr.deleteObject(fixAddress.Ref)
+}
+
+func (objMgr *ObjectManager) CreateAAAARecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordAAAA, error) {
+	return objMgr.CreateAAAARecordWithOptions(netview, dnsview, recordname, cidr, ipAddr,
+		RecordOptions{VmID: vmID, VmName: vmName})
+}
+
+func (objMgr *ObjectManager) CreateAAAARecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordAAAA, error) {
+	if err := objMgr.checkRecordConflict("record:aaaa", recordname, dnsview); err != nil {
+		return nil, err
+	}
+
+	ea := objMgr.recordEA(opts, false)
+
+	recordAAAA := NewRecordAAAA(RecordAAAA{
+		View:    dnsview,
+		Name:    recordname,
+		Comment: opts.Comment,
+		Ea:      ea})
+	recordAAAA.Ttl, recordAAAA.UseTtl = opts.ttl()
+
+	if ipAddr == "" {
+		recordAAAA.Ipv6Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		recordAAAA.Ipv6Addr = ipAddr
+	}
+	ref, err := objMgr.createObject(recordAAAA)
+	recordAAAA.Ref = ref
+	return recordAAAA, err
+}
+
+func (objMgr *ObjectManager) GetAAAARecordByRef(ref string) (*RecordAAAA, error) {
+	recordAAAA := NewRecordAAAA(RecordAAAA{})
+	recordAAAA.returnFields = append(recordAAAA.returnFields, "creation_time")
+	err := objMgr.getObject(recordAAAA, ref, &recordAAAA)
+	return recordAAAA, err
+}
+
+func (objMgr *ObjectManager) DeleteAAAARecord(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+func (objMgr *ObjectManager) CreateIPv6PTRRecord(netview string, dnsview string, recordname string, cidr string, ipAddr string, vmID string, vmName string) (*RecordPTR, error) {
+	return objMgr.CreateIPv6PTRRecordWithOptions(netview, dnsview, recordname, cidr, ipAddr,
+		RecordOptions{VmID: vmID, VmName: vmName})
+}
+
+func (objMgr *ObjectManager) CreateIPv6PTRRecordWithOptions(netview string, dnsview string, recordname string, cidr string, ipAddr string, opts RecordOptions) (*RecordPTR, error) {
+
+	ea := objMgr.recordEA(opts, false)
+
+	recordPTR := NewIPv6RecordPTR(RecordPTR{
+		View:     dnsview,
+		PtrdName: recordname,
+		Comment:  opts.Comment,
+		Ea:       ea})
+	recordPTR.Ttl, recordPTR.UseTtl = opts.ttl()
+
+	if ipAddr == "" {
+		recordPTR.Ipv6Addr = fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netview)
+	} else {
+		recordPTR.Ipv6Addr = ipAddr
+	}
+	ref, err := objMgr.createObject(recordPTR)
+	recordPTR.Ref = ref
+	return recordPTR, err
+}
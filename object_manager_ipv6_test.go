@@ -0,0 +1,125 @@
+package ibclient
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager IPv6", func() {
+
+	Describe("Create IPv6 Network", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		netviewName := "default_view"
+		cidr := "2001:db8:abcd:12::/64"
+		networkName := "private-net"
+		fakeRefReturn := "ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6YWJjZDoxMjo6LzY0LzA:2001%3Adb8%3Aabcd%3A12%3A%3A/64/default_view"
+		nwFakeConnector := &fakeConnector{
+			createObjectObj: NewIPv6Network(Network{NetviewName: netviewName, Cidr: cidr}),
+			resultObject:    NewIPv6Network(Network{NetviewName: netviewName, Cidr: cidr, Ref: fakeRefReturn}),
+			fakeRefReturn:   fakeRefReturn,
+		}
+
+		objMgr := NewObjectManager(nwFakeConnector, cmpType, tenantID)
+		nwFakeConnector.createObjectObj.(*Network).Ea = objMgr.getBasicEA(true)
+		nwFakeConnector.createObjectObj.(*Network).Ea["Network Name"] = networkName
+		nwFakeConnector.resultObject.(*Network).Ea = objMgr.getBasicEA(true)
+		nwFakeConnector.resultObject.(*Network).Ea["Network Name"] = networkName
+
+		var actualNetwork *Network
+		var err error
+		It("should pass expected IPv6 Network Object to CreateObject", func() {
+			actualNetwork, err = objMgr.CreateIPv6Network(netviewName, cidr, networkName)
+		})
+		It("should return expected IPv6 Network Object", func() {
+			Expect(actualNetwork).To(Equal(nwFakeConnector.resultObject))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("Allocate Next Available IPv6", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		netviewName := "private"
+		cidr := "2001:db8::/64"
+		ipAddr := fmt.Sprintf("func:nextavailableip:%s,%s", cidr, netviewName)
+		duid := "00:01:00:01:1c:39:cf:88:08:00:27:fe:8f:95"
+		name := "testvm"
+		fakeRefReturn := "ipv6fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:2001%3Adb8%3A%3A5/private"
+
+		aniFakeConnector := &fakeConnector{
+			createObjectObj: NewIPv6FixedAddress(FixedAddress{
+				NetviewName: netviewName,
+				Cidr:        cidr,
+				IPv6Address: ipAddr,
+				Duid:        duid,
+				Name:        name,
+				Ea:          EA{},
+			}),
+			resultObject: NewIPv6FixedAddress(FixedAddress{
+				NetviewName: netviewName,
+				Cidr:        cidr,
+				IPv6Address: "2001:db8::5",
+				Duid:        duid,
+				Ref:         fakeRefReturn,
+				Name:        name,
+				Ea:          EA{},
+			}),
+			fakeRefReturn: fakeRefReturn,
+		}
+
+		objMgr := NewObjectManager(aniFakeConnector, cmpType, tenantID)
+
+		var actualIP *FixedAddress
+		var err error
+		It("should pass expected IPv6 Fixed Address Object to CreateObject", func() {
+			actualIP, err = objMgr.AllocateIPv6(netviewName, cidr, "", duid, name, "", "")
+		})
+		It("should return expected IPv6 Fixed Address Object", func() {
+			Expect(actualIP).To(Equal(aniFakeConnector.resultObject))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("Allocate specific AAAA Record", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		netviewName := "private"
+		dnsView := "default"
+		cidr := "2001:db8::/64"
+		ipAddr := "2001:db8::20"
+		recordName := "test"
+		fakeRefReturn := "record:aaaa/ZG5zLmJpbmRfYWFhYSQ:test/default"
+
+		aaaaFakeConnector := &fakeConnector{
+			createObjectObj: NewRecordAAAA(RecordAAAA{
+				Name:     recordName,
+				View:     dnsView,
+				Ipv6Addr: ipAddr,
+				Ea:       EA{},
+			}),
+			resultObject: NewRecordAAAA(RecordAAAA{
+				Name:     recordName,
+				View:     dnsView,
+				Ipv6Addr: ipAddr,
+				Ref:      fakeRefReturn,
+				Ea:       EA{},
+			}),
+			fakeRefReturn: fakeRefReturn,
+		}
+
+		objMgr := NewObjectManager(aaaaFakeConnector, cmpType, tenantID)
+
+		var actualRecord *RecordAAAA
+		var err error
+		It("should pass expected AAAA record Object to CreateObject", func() {
+			actualRecord, err = objMgr.CreateAAAARecord(netviewName, dnsView, recordName, cidr, ipAddr, "", "")
+		})
+		It("should return expected AAAA record Object", func() {
+			Expect(actualRecord).To(Equal(aaaaFakeConnector.resultObject))
+			Expect(err).To(BeNil())
+		})
+	})
+})
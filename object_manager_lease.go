@@ -0,0 +1,63 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LeaseActive is the binding state of a lease held by a client
+const LeaseActive = "ACTIVE"
+
+// LeaseFilter selects the leases returned by GetLeases, an empty field
+// matches all leases
+type LeaseFilter struct {
+	NetviewName string
+	Address     string
+	// Network is the CIDR of the network of the leases
+	Network string
+	// Mac is the hardware address of the client of IPv4 leases
+	Mac string
+}
+
+// GetLeases returns the DHCP leases matching filter
+func (objMgr *ObjectManager) GetLeases(filter LeaseFilter) ([]Lease, error) {
+	var res []Lease
+
+	lease := NewLease(Lease{
+		NetviewName: filter.NetviewName,
+		Address:     filter.Address,
+		Network:     filter.Network,
+		Hardware:    filter.Mac})
+	err := objMgr.getObject(lease, "", &res)
+
+	return res, err
+}
+
+// GetActiveLease returns the lease held by a client on the address ipAddr
+// of netview, nil if the address is not leased. It lets decommission
+// workflows check the address is no longer in use before ReleaseIP.
+func (objMgr *ObjectManager) GetActiveLease(netview string, ipAddr string) (*Lease, error) {
+	res, err := objMgr.GetLeases(LeaseFilter{NetviewName: netview, Address: ipAddr})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		if res[i].BindingState == LeaseActive {
+			return &res[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ClearLease discards the lease referenced by ref on the member serving
+// it, making the address available again whether or not the client
+// released it
+func (objMgr *ObjectManager) ClearLease(ref string) (string, error) {
+	if !strings.HasPrefix(ref, "lease/") {
+		return "", fmt.Errorf("'%s' is not a lease", ref)
+	}
+
+	return objMgr.deleteObject(ref)
+}
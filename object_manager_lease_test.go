@@ -0,0 +1,77 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager DHCP leases", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	leaseRef := "lease/ZG5zLmxlYXNlJDQvMTAuMC4wLjEwLzAvNjM:10.0.0.10/default"
+
+	Describe("Get Leases", func() {
+		It("should search the leases of the network by MAC address", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"lease": []Lease{{Ref: leaseRef, Address: "10.0.0.10", BindingState: "ACTIVE"}}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			leases, err := objMgr.GetLeases(LeaseFilter{NetviewName: "default", Network: "10.0.0.0/24", Mac: "11:22:33:44:55:66"})
+			Expect(err).To(BeNil())
+			Expect(leases).To(HaveLen(1))
+			Expect(leases[0].Ref).To(Equal(leaseRef))
+			js, _ := json.Marshal(conn.getObjs[0])
+			Expect(js).To(MatchJSON(`{"network_view": "default", "network": "10.0.0.0/24", "hardware": "11:22:33:44:55:66"}`))
+		})
+	})
+
+	Describe("Get Active Lease", func() {
+		It("should return the active lease of the address", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"lease": []Lease{
+						{Ref: "lease/ZG5zLmxlYXNlJDQvMTAuMC4wLjEwLzAvNjI:10.0.0.10/default", Address: "10.0.0.10", BindingState: "FREE"},
+						{Ref: leaseRef, Address: "10.0.0.10", BindingState: "ACTIVE"}}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			lease, err := objMgr.GetActiveLease("default", "10.0.0.10")
+			Expect(err).To(BeNil())
+			Expect(lease.Ref).To(Equal(leaseRef))
+		})
+
+		It("should return nil if the address is not leased", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"lease": []Lease{{Ref: leaseRef, Address: "10.0.0.10", BindingState: "EXPIRED"}}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			lease, err := objMgr.GetActiveLease("default", "10.0.0.10")
+			Expect(err).To(BeNil())
+			Expect(lease).To(BeNil())
+		})
+	})
+
+	Describe("Clear Lease", func() {
+		It("should delete the lease", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			ref, err := objMgr.ClearLease(leaseRef)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(leaseRef))
+			Expect(conn.deleteRefs).To(Equal([]string{leaseRef}))
+		})
+
+		It("should not delete an object other than a lease", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.ClearLease("fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.10/default")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.deleteRefs).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,144 @@
+package ibclient
+
+import (
+	"fmt"
+	"net"
+	"sort"
+)
+
+// LookupAnswer is what the grid knows about a name or an address: the
+// host records, A and AAAA records and fixed addresses found, and the
+// names and the addresses of all of them
+type LookupAnswer struct {
+	Hosts          []HostRecord
+	ARecords       []RecordA
+	AAAARecords    []RecordAAAA
+	FixedAddresses []FixedAddress
+	// Names and IPs are sorted and without duplicates
+	Names []string
+	IPs   []string
+}
+
+// consolidate sets the names and the addresses of the answer
+func (a *LookupAnswer) consolidate() {
+	names := make(map[string]bool)
+	ips := make(map[string]bool)
+
+	for _, h := range a.Hosts {
+		names[h.Name] = true
+		for _, addr := range h.Ipv4Addrs {
+			ips[addr.Ipv4Addr] = true
+		}
+		for _, addr := range h.Ipv6Addrs {
+			ips[addr.Ipv6Addr] = true
+		}
+	}
+	for _, r := range a.ARecords {
+		names[r.Name] = true
+		ips[r.Ipv4Addr] = true
+	}
+	for _, r := range a.AAAARecords {
+		names[r.Name] = true
+		ips[r.Ipv6Addr] = true
+	}
+	for _, fa := range a.FixedAddresses {
+		ips[fa.IPAddress] = true
+		ips[fa.IPv6Address] = true
+	}
+
+	a.Names = nil
+	for name := range names {
+		if name != "" {
+			a.Names = append(a.Names, name)
+		}
+	}
+	sort.Strings(a.Names)
+	a.IPs = nil
+	for ip := range ips {
+		if ip != "" {
+			a.IPs = append(a.IPs, ip)
+		}
+	}
+	sort.Strings(a.IPs)
+}
+
+// LookupByName returns the host, A and AAAA records of fqdn in the DNS
+// view view, and the fixed addresses of their addresses
+func (objMgr *ObjectManager) LookupByName(fqdn string, view string) (*LookupAnswer, error) {
+	answer := &LookupAnswer{}
+
+	host := NewHostRecord(HostRecord{Name: fqdn, View: view})
+	host.returnFields = append(host.returnFields, "ipv6addrs")
+	if err := objMgr.readObject(host, "", &answer.Hosts); err != nil {
+		return nil, err
+	}
+	if err := objMgr.readObject(NewRecordA(RecordA{Name: fqdn, View: view}), "", &answer.ARecords); err != nil {
+		return nil, err
+	}
+	if err := objMgr.readObject(NewRecordAAAA(RecordAAAA{Name: fqdn, View: view}), "", &answer.AAAARecords); err != nil {
+		return nil, err
+	}
+
+	// the fixed addresses do not have a DNS name, they are found by the
+	// addresses of the records
+	answer.consolidate()
+	for _, ip := range answer.IPs {
+		fixedAddrs, err := objMgr.lookupFixedAddresses(ip, "")
+		if err != nil {
+			return nil, err
+		}
+		answer.FixedAddresses = append(answer.FixedAddresses, fixedAddrs...)
+	}
+	answer.consolidate()
+
+	return answer, nil
+}
+
+// LookupByIP returns the host, A or AAAA records and fixed addresses of
+// ip, the fixed addresses of the network view netview only if not empty
+func (objMgr *ObjectManager) LookupByIP(ip string, netview string) (*LookupAnswer, error) {
+	if net.ParseIP(ip) == nil {
+		return nil, fmt.Errorf("'%s' is not an IP address", ip)
+	}
+	answer := &LookupAnswer{}
+
+	host := NewHostRecord(HostRecord{})
+	host.returnFields = append(host.returnFields, "ipv6addrs")
+	if isIPv6Addr(ip) {
+		host.Ipv6Addr = ip
+		err := objMgr.readObject(NewRecordAAAA(RecordAAAA{Ipv6Addr: ip}), "", &answer.AAAARecords)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		host.Ipv4Addr = ip
+		if err := objMgr.readObject(NewRecordA(RecordA{Ipv4Addr: ip}), "", &answer.ARecords); err != nil {
+			return nil, err
+		}
+	}
+	if err := objMgr.readObject(host, "", &answer.Hosts); err != nil {
+		return nil, err
+	}
+
+	fixedAddrs, err := objMgr.lookupFixedAddresses(ip, netview)
+	if err != nil {
+		return nil, err
+	}
+	answer.FixedAddresses = fixedAddrs
+	answer.consolidate()
+
+	return answer, nil
+}
+
+// lookupFixedAddresses returns the IPv4 or IPv6 fixed addresses of ip
+func (objMgr *ObjectManager) lookupFixedAddresses(ip string, netview string) ([]FixedAddress, error) {
+	var res []FixedAddress
+
+	fixedAddr := NewFixedAddress(FixedAddress{NetviewName: netview, IPAddress: ip})
+	if isIPv6Addr(ip) {
+		fixedAddr = NewIPv6FixedAddress(FixedAddress{NetviewName: netview, IPv6Address: ip})
+	}
+	err := objMgr.readObject(fixedAddr, "", &res)
+
+	return res, err
+}
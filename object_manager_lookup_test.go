@@ -0,0 +1,66 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager lookups", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLndlYg:web.example.com/default"
+	aRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsd2ViLDEwLjAuMC41:web.example.com/default"
+	fixedAddrRef := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3MkMTAuMC4wLjUuMC4u:10.0.0.5/default"
+
+	It("should consolidate what is known about a name", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:host": []HostRecord{{Ref: hostRef, Name: "web.example.com",
+					Ipv4Addrs: []HostRecordIpv4Addr{{Ipv4Addr: "10.0.0.5"}},
+					Ipv6Addrs: []HostRecordIpv6Addr{{Ipv6Addr: "2001:db8::5"}}}},
+				"record:a":     []RecordA{{Ref: aRef, Name: "web.example.com", Ipv4Addr: "10.0.0.5"}},
+				"fixedaddress": []FixedAddress{{Ref: fixedAddrRef, IPAddress: "10.0.0.5", Mac: "00:00:00:00:00:05"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		answer, err := objMgr.LookupByName("web.example.com", "default")
+		Expect(err).To(BeNil())
+		Expect(answer.Hosts[0].Ref).To(Equal(hostRef))
+		Expect(answer.ARecords[0].Ref).To(Equal(aRef))
+		Expect(answer.AAAARecords).To(BeEmpty())
+		Expect(answer.FixedAddresses[0].Ref).To(Equal(fixedAddrRef))
+		Expect(answer.Names).To(Equal([]string{"web.example.com"}))
+		Expect(answer.IPs).To(Equal([]string{"10.0.0.5", "2001:db8::5"}))
+
+		js, _ := json.Marshal(conn.getObjs[0])
+		Expect(js).To(MatchJSON(`{"name": "web.example.com", "view": "default"}`))
+		Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("ipv6addrs"))
+		// the fixed addresses of both addresses are searched
+		Expect(conn.getObjs[3].ObjectType()).To(Equal("fixedaddress"))
+		Expect(conn.getObjs[4].ObjectType()).To(Equal("ipv6fixedaddress"))
+	})
+
+	It("should consolidate what is known about an address", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:aaaa":      []RecordAAAA{{Name: "web.example.com", Ipv6Addr: "2001:db8::5"}},
+				"ipv6fixedaddress": []FixedAddress{{IPv6Address: "2001:db8::5", Duid: "00:01"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		answer, err := objMgr.LookupByIP("2001:db8::5", "default")
+		Expect(err).To(BeNil())
+		Expect(answer.AAAARecords).To(HaveLen(1))
+		Expect(answer.FixedAddresses).To(HaveLen(1))
+		Expect(answer.Names).To(Equal([]string{"web.example.com"}))
+		Expect(answer.IPs).To(Equal([]string{"2001:db8::5"}))
+
+		js, _ := json.Marshal(conn.getObjs[1])
+		Expect(js).To(MatchJSON(`{"ipv6addr": "2001:db8::5"}`))
+		js, _ = json.Marshal(conn.getObjs[2])
+		Expect(js).To(MatchJSON(`{"network_view": "default", "ipv6addr": "2001:db8::5"}`))
+
+		_, err = objMgr.LookupByIP("web.example.com", "default")
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,194 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+// MemberFilter selects the members returned by GetMembers, an empty field
+// matches all members
+type MemberFilter struct {
+	HostName string
+	// Platform is "PHYSICAL", "VNIOS", "CLOUD" or "VIRTUAL"
+	Platform string
+	// EnabledService is a service, e.g. "DNS" or "DHCP", which must not be
+	// inactive on the nodes of the member
+	EnabledService string
+}
+
+// serviceEnabled returns whether service is enabled on a node of member
+func serviceEnabled(member *Member, service string) bool {
+	for _, node := range member.Nodeinfo {
+		for _, s := range node.ServiceStatus {
+			if s.Service == service && s.Status != "INACTIVE" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// GetMembers returns the members matching filter. The host name and the
+// platform are searched by the grid, the enabled service is matched on the
+// service status of the nodes of those members.
+func (objMgr *ObjectManager) GetMembers(filter MemberFilter) ([]Member, error) {
+	var res []Member
+
+	memberObj := NewMember(Member{HostName: filter.HostName, PLATFORM: filter.Platform})
+	if filter.Platform != "" {
+		memberObj.returnFields = append(memberObj.returnFields, "platform")
+	}
+	err := objMgr.getObject(memberObj, "", &res)
+	if err != nil || filter.EnabledService == "" {
+		return res, err
+	}
+
+	var members []Member
+	for i := range res {
+		if serviceEnabled(&res[i], filter.EnabledService) {
+			members = append(members, res[i])
+		}
+	}
+
+	return members, nil
+}
+
+// GetMember returns the member named hostName, nil if there is none
+func (objMgr *ObjectManager) GetMember(hostName string) (*Member, error) {
+	res, err := objMgr.GetMembers(MemberFilter{HostName: hostName})
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// SetMemberDnsEnabled enables or disables the DNS service of the member
+// named hostName, then restarts it if restart is true so the change
+// becomes live
+func (objMgr *ObjectManager) SetMemberDnsEnabled(hostName string, enable bool, restart bool) (*MemberDns, error) {
+	var res []MemberDns
+
+	memberDns := NewMemberDns(MemberDns{HostName: hostName})
+	memberDns.returnFields = append(memberDns.returnFields, "enable_dns")
+	err := objMgr.readObject(memberDns, "", &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("DNS properties of member '%s' not found", hostName)
+	}
+
+	updateDns := NewMemberDns(MemberDns{EnableDns: &enable})
+	updateDns.Ref, err = objMgr.updateObject(updateDns, res[0].Ref)
+	if err != nil {
+		return nil, err
+	}
+	updateDns.HostName = hostName
+
+	if restart {
+		err = objMgr.RestartServices([]string{"DNS"}, []string{hostName}, "")
+	}
+	return updateDns, err
+}
+
+// SetMemberDhcpEnabled enables or disables the DHCP service of the member
+// named hostName, then restarts it if restart is true so the change
+// becomes live
+func (objMgr *ObjectManager) SetMemberDhcpEnabled(hostName string, enable bool, restart bool) (*MemberDhcpProperties, error) {
+	var res []MemberDhcpProperties
+
+	dhcpProperties := NewMemberDhcpProperties(MemberDhcpProperties{HostName: hostName})
+	err := objMgr.readObject(dhcpProperties, "", &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("DHCP properties of member '%s' not found", hostName)
+	}
+
+	updateDhcp := NewMemberDhcpProperties(MemberDhcpProperties{EnableDhcp: &enable})
+	updateDhcp.Ref, err = objMgr.updateObject(updateDhcp, res[0].Ref)
+	if err != nil {
+		return nil, err
+	}
+	updateDhcp.HostName = hostName
+
+	if restart {
+		err = objMgr.RestartServices([]string{"DHCP"}, []string{hostName}, "")
+	}
+	return updateDhcp, err
+}
+
+// memberDnsClearCache holds the arguments of the clear_dns_cache function
+// of the DNS properties of a member
+type memberDnsClearCache struct {
+	IBBase        `json:"-"`
+	Domain        string `json:"domain,omitempty"`
+	View          string `json:"view,omitempty"`
+	ClearFullTree bool   `json:"clear_full_tree,omitempty"`
+}
+
+// clearDnsCache clears the DNS cache of the member whose DNS properties
+// are referenced by ref
+func (objMgr *ObjectManager) clearDnsCache(ref string, domain string, view string, fullTree bool) error {
+	args := &memberDnsClearCache{
+		IBBase:        IBBase{objectType: "member:dns"},
+		Domain:        domain,
+		View:          view,
+		ClearFullTree: fullTree && domain != ""}
+
+	conn, err := objMgr.wapiConnector()
+	if err != nil {
+		return err
+	}
+	return conn.callFunction(ref, "clear_dns_cache", args, nil)
+}
+
+// ClearMemberDnsCache clears the DNS cache of the member named hostName,
+// so it resolves the names again instead of answering from the cache. The
+// whole cache is cleared when domain is empty, otherwise the entries of
+// domain, and those of its subdomains if fullTree is true. view limits the
+// clearing to a DNS view, all views when empty.
+func (objMgr *ObjectManager) ClearMemberDnsCache(hostName string, domain string, view string, fullTree bool) error {
+	var res []MemberDns
+
+	memberDns := NewMemberDns(MemberDns{HostName: hostName})
+	err := objMgr.readObject(memberDns, "", &res)
+	if err != nil {
+		return err
+	}
+	if len(res) == 0 {
+		return fmt.Errorf("DNS properties of member '%s' not found", hostName)
+	}
+
+	return objMgr.clearDnsCache(res[0].Ref, domain, view, fullTree)
+}
+
+// ClearRecursiveDnsCaches clears, as ClearMemberDnsCache, the DNS cache of
+// all the members allowing recursive queries, and returns their names. It
+// stops at the first member whose cache cannot be cleared and returns the
+// names of the members cleared before it.
+func (objMgr *ObjectManager) ClearRecursiveDnsCaches(domain string, view string, fullTree bool) ([]string, error) {
+	var res []MemberDns
+
+	memberDns := NewMemberDns(MemberDns{})
+	memberDns.returnFields = append(memberDns.returnFields, "allow_recursive_query")
+	err := objMgr.readObject(memberDns, "", &res)
+	if err != nil {
+		return nil, err
+	}
+
+	var cleared []string
+	for _, m := range res {
+		if m.AllowRecursiveQuery == nil || !*m.AllowRecursiveQuery {
+			continue
+		}
+		if err = objMgr.clearDnsCache(m.Ref, domain, view, fullTree); err != nil {
+			return cleared, fmt.Errorf("cannot clear the DNS cache of member '%s': %s", m.HostName, err)
+		}
+		cleared = append(cleared, m.HostName)
+	}
+
+	return cleared, nil
+}
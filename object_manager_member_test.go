@@ -0,0 +1,189 @@
+package ibclient
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Members", func() {
+	cmpType := "Heka"
+	tenantID := "0123"
+	dnsMember := Member{
+		Ref:      "member/b25lLnZpcnR1YWxfbm9kZSQx:dns.localdomain",
+		HostName: "dns.localdomain",
+		PLATFORM: "VNIOS",
+		Nodeinfo: []NodeInfo{{ServiceStatus: []ServiceStatus{
+			{Service: "DNS", Status: "WORKING"},
+			{Service: "DHCP", Status: "INACTIVE"}}}},
+	}
+	dhcpMember := Member{
+		Ref:      "member/b25lLnZpcnR1YWxfbm9kZSQy:dhcp.localdomain",
+		HostName: "dhcp.localdomain",
+		PLATFORM: "VNIOS",
+		Nodeinfo: []NodeInfo{{ServiceStatus: []ServiceStatus{
+			{Service: "DNS", Status: "INACTIVE"},
+			{Service: "DHCP", Status: "WORKING"}}}},
+	}
+
+	Describe("GetMembers", func() {
+		It("should search the platform and match the enabled service", func() {
+			getObjectObj := NewMember(Member{PLATFORM: "VNIOS"})
+			getObjectObj.returnFields = append(getObjectObj.returnFields, "platform")
+			conn := &fakeConnector{
+				getObjectObj: getObjectObj,
+				getObjectRef: "",
+				resultObject: []Member{dnsMember, dhcpMember},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			members, err := objMgr.GetMembers(MemberFilter{Platform: "VNIOS", EnabledService: "DHCP"})
+			Expect(err).To(BeNil())
+			Expect(members).To(Equal([]Member{dhcpMember}))
+		})
+	})
+
+	Describe("GetMember", func() {
+		It("should return the member with the host name", func() {
+			conn := &fakeConnector{
+				getObjectObj: NewMember(Member{HostName: "dns.localdomain"}),
+				getObjectRef: "",
+				resultObject: []Member{dnsMember},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			member, err := objMgr.GetMember("dns.localdomain")
+			Expect(err).To(BeNil())
+			Expect(*member).To(Equal(dnsMember))
+		})
+
+		It("should return nil for an unknown host name", func() {
+			conn := &fakeConnector{
+				getObjectObj: NewMember(Member{HostName: "unknown.localdomain"}),
+				getObjectRef: "",
+				resultObject: []Member{},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			member, err := objMgr.GetMember("unknown.localdomain")
+			Expect(err).To(BeNil())
+			Expect(member).To(BeNil())
+		})
+	})
+
+	Describe("SetMemberDnsEnabled", func() {
+		dnsRef := "member:dns/ZG5zLm1lbWJlcl9kbnNfcHJvcGVydGllcyQw:dns.localdomain"
+		hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+		requestor := &fakeReportingRequestor{
+			res: [][]byte{
+				[]byte(`[{"_ref": "` + dnsRef + `", "host_name": "dns.localdomain", "enable_dns": false}]`),
+				[]byte(`"` + dnsRef + `"`),
+				[]byte(`[{"_ref": "grid/b25lLmNsdXN0ZXIkMA:Infoblox", "name": "Infoblox"}]`),
+				[]byte(`{}`),
+			},
+		}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should enable DNS on the member and restart it", func() {
+			memberDns, err := objMgr.SetMemberDnsEnabled("dns.localdomain", true, true)
+			Expect(err).To(BeNil())
+			Expect(memberDns.Ref).To(Equal(dnsRef))
+			Expect(*memberDns.EnableDns).To(BeTrue())
+
+			Expect(requestor.reqs[1].Method).To(Equal("PUT"))
+			Expect(requestor.body[1]).To(MatchJSON(`{"enable_dns": true}`))
+			Expect(requestor.reqs[3].URL.String()).To(Equal(
+				"https://172.22.18.66:443/wapi/v2.11/grid/b25lLmNsdXN0ZXIkMA:Infoblox?_function=restartservices"))
+			Expect(requestor.body[3]).To(MatchJSON(`{"services": ["DNS"], "members": ["dns.localdomain"]}`))
+		})
+	})
+
+	Describe("SetMemberDhcpEnabled", func() {
+		dhcpRef := "member:dhcpproperties/ZG5zLm1lbWJlcl9kaGNwX3Byb3BlcnRpZXMkMA:dhcp.localdomain"
+
+		It("should disable DHCP on the member", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"member:dhcpproperties": []MemberDhcpProperties{{Ref: dhcpRef, HostName: "dhcp.localdomain"}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			dhcpProperties, err := objMgr.SetMemberDhcpEnabled("dhcp.localdomain", false, false)
+			Expect(err).To(BeNil())
+			Expect(dhcpProperties.Ref).To(Equal(dhcpRef))
+			Expect(conn.updateRefs).To(Equal([]string{dhcpRef}))
+			Expect(*conn.updateObjs[0].(*MemberDhcpProperties).EnableDhcp).To(BeFalse())
+		})
+
+		It("should return an error for an unknown member", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.SetMemberDhcpEnabled("unknown.localdomain", true, false)
+			Expect(err).To(MatchError("DHCP properties of member 'unknown.localdomain' not found"))
+			Expect(conn.updateObjs).To(BeEmpty())
+		})
+	})
+
+	Describe("ClearMemberDnsCache", func() {
+		dnsRef := "member:dns/ZG5zLm1lbWJlcl9kbnNfcHJvcGVydGllcyQw:dns.localdomain"
+		hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+
+		It("should clear the cache of a domain on the member", func() {
+			requestor := &fakeReportingRequestor{
+				res: [][]byte{
+					[]byte(`[{"_ref": "` + dnsRef + `", "host_name": "dns.localdomain"}]`),
+					[]byte(`{}`),
+				},
+			}
+			wrb := &WapiRequestBuilder{}
+			wrb.Init(hostConfig)
+			conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			err := objMgr.ClearMemberDnsCache("dns.localdomain", "example.com", "default", true)
+			Expect(err).To(BeNil())
+			Expect(requestor.reqs[1].Method).To(Equal("POST"))
+			Expect(requestor.reqs[1].URL.String()).To(Equal(
+				"https://172.22.18.66:443/wapi/v2.11/" + dnsRef + "?_function=clear_dns_cache"))
+			Expect(requestor.body[1]).To(MatchJSON(`{"domain": "example.com", "view": "default", "clear_full_tree": true}`))
+		})
+
+		It("should fail without panicking when the connector is not a Connector", func() {
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{
+				"member:dns": []MemberDns{{Ref: dnsRef, HostName: "dns.localdomain"}}}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			err := objMgr.ClearMemberDnsCache("dns.localdomain", "", "", false)
+			Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+		})
+
+		It("should clear the whole cache of the recursive members", func() {
+			requestor := &fakeReportingRequestor{
+				res: [][]byte{
+					[]byte(`[{"_ref": "member:dns/ZG5zLm1lbWJlcl9kbnNfcHJvcGVydGllcyQw:gm.localdomain",
+						"host_name": "gm.localdomain", "allow_recursive_query": false},
+						{"_ref": "` + dnsRef + `", "host_name": "dns.localdomain", "allow_recursive_query": true}]`),
+					[]byte(`{}`),
+				},
+			}
+			wrb := &WapiRequestBuilder{}
+			wrb.Init(hostConfig)
+			conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			cleared, err := objMgr.ClearRecursiveDnsCaches("", "", true)
+			Expect(err).To(BeNil())
+			Expect(cleared).To(Equal([]string{"dns.localdomain"}))
+			Expect(requestor.reqs[0].URL.Query().Get("_return_fields")).To(ContainSubstring("allow_recursive_query"))
+			Expect(requestor.reqs).To(HaveLen(2))
+			Expect(requestor.reqs[1].URL.Path).To(Equal("/wapi/v2.11/" + dnsRef))
+			Expect(requestor.body[1]).To(MatchJSON(`{}`))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package ibclient
+
+// ListNetworkViews returns the network views matching ea, all of them if
+// ea is empty, with the number of their networks and network containers
+// and the utilization of their IPv4 networks, weighted by their size. The
+// networks of all the views are counted together, with a request per
+// network type whatever the number of views.
+func (objMgr *ObjectManager) ListNetworkViews(ea EA) ([]NetworkViewSummary, error) {
+	var netviews []NetworkView
+
+	netview := NewNetworkView(NetworkView{})
+	if len(ea) > 0 {
+		netview.eaSearch = EASearch(ea)
+	}
+	if err := objMgr.getObjectPaged(netview, DefaultPageSize, &netviews); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]NetworkViewSummary, len(netviews))
+	index := make(map[string]*NetworkViewSummary, len(netviews))
+	for i := range netviews {
+		summaries[i].NetworkView = netviews[i]
+		index[netviews[i].Name] = &summaries[i]
+	}
+	if len(summaries) == 0 {
+		return summaries, nil
+	}
+
+	var networks, ipv6Networks []Network
+	var containers, ipv6Containers []NetworkContainer
+
+	network := NewNetwork(Network{})
+	network.returnFields = []string{"network", "network_view", "utilization"}
+	if err := objMgr.readObjectPaged(network, DefaultPageSize, &networks); err != nil {
+		return nil, err
+	}
+	ipv6Network := NewIPv6Network(Network{})
+	ipv6Network.returnFields = []string{"network_view"}
+	if err := objMgr.readObjectPaged(ipv6Network, DefaultPageSize, &ipv6Networks); err != nil {
+		return nil, err
+	}
+	container := NewNetworkContainer(NetworkContainer{})
+	container.returnFields = []string{"network_view"}
+	if err := objMgr.readObjectPaged(container, DefaultPageSize, &containers); err != nil {
+		return nil, err
+	}
+	ipv6Container := NewIPv6NetworkContainer(NetworkContainer{})
+	ipv6Container.returnFields = []string{"network_view"}
+	if err := objMgr.readObjectPaged(ipv6Container, DefaultPageSize, &ipv6Containers); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]float64)
+	total := make(map[string]float64)
+	for _, n := range networks {
+		s, ok := index[n.NetviewName]
+		if !ok {
+			continue
+		}
+		s.Networks++
+		if ipNet, err := parseCIDR(n.Cidr); err == nil {
+			ones, bits := ipNet.Mask.Size()
+			size := float64(uint64(1) << uint(bits-ones))
+			used[n.NetviewName] += size * float64(n.Utilization) / 100
+			total[n.NetviewName] += size
+		}
+	}
+	for _, n := range ipv6Networks {
+		if s, ok := index[n.NetviewName]; ok {
+			s.IPv6Networks++
+		}
+	}
+	for _, c := range containers {
+		if s, ok := index[c.NetviewName]; ok {
+			s.NetworkContainers++
+		}
+	}
+	for _, c := range ipv6Containers {
+		if s, ok := index[c.NetviewName]; ok {
+			s.IPv6NetworkContainers++
+		}
+	}
+	for name, s := range index {
+		if total[name] > 0 {
+			s.Utilization = uint32(used[name]*100/total[name] + 0.5)
+		}
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,65 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager network view summaries", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	It("should count the networks and containers of the views", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"networkview": []NetworkView{
+					{Ref: "networkview/ZG5zLm5ldHdvcmtfdmlldyQx:tenant1/false", Name: "tenant1"},
+					{Ref: "networkview/ZG5zLm5ldHdvcmtfdmlldyQy:tenant2/false", Name: "tenant2"}},
+				"network": []Network{
+					{NetviewName: "tenant1", Cidr: "10.0.0.0/24", Utilization: 50},
+					{NetviewName: "tenant1", Cidr: "10.0.1.0/23", Utilization: 20},
+					{NetviewName: "default", Cidr: "192.168.0.0/24", Utilization: 100}},
+				"ipv6network": []Network{
+					{NetviewName: "tenant2", Cidr: "2001:db8::/64"}},
+				"networkcontainer": []NetworkContainer{
+					{NetviewName: "tenant1", Cidr: "10.0.0.0/16"},
+					{NetviewName: "tenant2", Cidr: "10.1.0.0/16"}},
+				"ipv6networkcontainer": []NetworkContainer{
+					{NetviewName: "tenant2", Cidr: "2001:db8::/48"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		summaries, err := objMgr.ListNetworkViews(EA{"Tenant ID": "acme"})
+		Expect(err).To(BeNil())
+		Expect(summaries).To(HaveLen(2))
+
+		Expect(summaries[0].Name).To(Equal("tenant1"))
+		Expect(summaries[0].Networks).To(Equal(2))
+		Expect(summaries[0].NetworkContainers).To(Equal(1))
+		Expect(summaries[0].IPv6Networks).To(Equal(0))
+		// (256 * 50% + 512 * 20%) / 768
+		Expect(summaries[0].Utilization).To(Equal(uint32(30)))
+
+		Expect(summaries[1].Name).To(Equal("tenant2"))
+		Expect(summaries[1].Networks).To(Equal(0))
+		Expect(summaries[1].NetworkContainers).To(Equal(1))
+		Expect(summaries[1].IPv6Networks).To(Equal(1))
+		Expect(summaries[1].IPv6NetworkContainers).To(Equal(1))
+		Expect(summaries[1].Utilization).To(Equal(uint32(0)))
+
+		Expect(conn.getObjs[0].EaSearch()).To(Equal(EASearch{"Tenant ID": "acme"}))
+		js, _ := json.Marshal(conn.getObjs[1])
+		Expect(js).To(MatchJSON(`{}`))
+	})
+
+	It("should not count the networks without a matching view", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		summaries, err := objMgr.ListNetworkViews(nil)
+		Expect(err).To(BeNil())
+		Expect(summaries).To(BeEmpty())
+		Expect(conn.getObjs).To(HaveLen(1))
+	})
+})
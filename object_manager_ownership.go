@@ -0,0 +1,99 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ownershipEAs are the EAs identifying the tenant owning an object, which
+// TransferOwnership rewrites
+var ownershipEAs = []string{"Tenant ID", "CMP Type"}
+
+// OwnershipChange is an object whose ownership EAs were rewritten by
+// TransferOwnership, with their previous values
+type OwnershipChange struct {
+	Ref   string
+	OldEA EA
+}
+
+// OwnershipTransfer is the audit summary of a TransferOwnership
+type OwnershipTransfer struct {
+	NewEA EA
+	// Transferred are the objects whose ownership EAs were rewritten
+	Transferred []OwnershipChange
+	// Unchanged are the refs of the objects already owned as in NewEA
+	Unchanged []string
+}
+
+// objectEA holds the EAs of an object of any type
+type objectEA struct {
+	IBBase `json:"-"`
+	Ea     EA `json:"extattrs"`
+}
+
+// validateOwnershipEA checks ea only sets non-empty ownership EAs
+func validateOwnershipEA(ea EA) error {
+	if len(ea) == 0 {
+		return fmt.Errorf("no ownership EA given, expected %s", strings.Join(ownershipEAs, " or "))
+	}
+	for k, v := range ea {
+		owned := false
+		for _, o := range ownershipEAs {
+			owned = owned || k == o
+		}
+		if !owned {
+			return fmt.Errorf("'%s' is not an ownership EA, expected %s", k, strings.Join(ownershipEAs, " or "))
+		}
+		if s, ok := v.(string); !ok || s == "" {
+			return fmt.Errorf("ownership EA '%s' must be a non-empty string", k)
+		}
+	}
+
+	return nil
+}
+
+// TransferOwnership rewrites the Tenant ID and CMP Type EAs of the objects
+// in refs to the values in newTenantEA, e.g. when tenants are merged or
+// renamed. The EAs of every object are read first, so that the returned
+// summary records the previous owner of the objects transferred. The
+// objects are updated with BulkUpdateEA, whose *BulkUpdateError lists the
+// objects which were not transferred.
+func (objMgr *ObjectManager) TransferOwnership(refs []string, newTenantEA EA) (*OwnershipTransfer, error) {
+	if err := validateOwnershipEA(newTenantEA); err != nil {
+		return nil, err
+	}
+
+	transfer := &OwnershipTransfer{NewEA: newTenantEA}
+	oldEAs := make(map[string]EA)
+	var toTransfer []string
+	for _, ref := range refs {
+		obj := &objectEA{IBBase: IBBase{
+			objectType:   strings.SplitN(ref, "/", 2)[0],
+			returnFields: []string{"extattrs"}}}
+		if err := objMgr.connector.GetObject(obj, ref, obj); err != nil {
+			return nil, err
+		}
+
+		oldEA := make(EA)
+		changed := false
+		for k, v := range newTenantEA {
+			if old, ok := obj.Ea[k]; ok {
+				oldEA[k] = old
+			}
+			changed = changed || obj.Ea[k] != v
+		}
+		if !changed {
+			transfer.Unchanged = append(transfer.Unchanged, ref)
+			continue
+		}
+		oldEAs[ref] = oldEA
+		toTransfer = append(toTransfer, ref)
+	}
+
+	updated, err := objMgr.BulkUpdateEA(toTransfer, newTenantEA, nil)
+	for _, ref := range updated {
+		transfer.Transferred = append(transfer.Transferred, OwnershipChange{Ref: ref, OldEA: oldEAs[ref]})
+	}
+
+	return transfer, err
+}
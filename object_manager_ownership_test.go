@@ -0,0 +1,49 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager ownership transfer", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+	networkRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLmhvc3Q:host.example.com/default"
+
+	newConnector := func(requestor *fakeReportingRequestor) *Connector {
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		return &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+	}
+
+	It("should rewrite the tenant of the objects and record the previous one", func() {
+		requestor := &fakeReportingRequestor{res: [][]byte{
+			[]byte(`{"_ref": "` + networkRef + `", "extattrs": {"Tenant ID": {"value": "acme"}, "CMP Type": {"value": "Docker"}}}`),
+			[]byte(`{"_ref": "` + hostRef + `", "extattrs": {"Tenant ID": {"value": "globex"}}}`),
+			[]byte(`[]`)}}
+		objMgr := NewObjectManager(newConnector(requestor), cmpType, tenantID)
+
+		transfer, err := objMgr.TransferOwnership([]string{networkRef, hostRef}, EA{"Tenant ID": "globex"})
+		Expect(err).To(BeNil())
+		Expect(transfer.Transferred).To(Equal([]OwnershipChange{{Ref: networkRef, OldEA: EA{"Tenant ID": "acme"}}}))
+		Expect(transfer.Unchanged).To(Equal([]string{hostRef}))
+
+		Expect(requestor.reqs[0].URL.Path).To(Equal("/wapi/v2.11/" + networkRef))
+		Expect(requestor.reqs[0].URL.Query().Get("_return_fields")).To(Equal("extattrs"))
+		Expect(requestor.body[2]).To(MatchJSON(`[{"method": "PUT", "object": "` + networkRef + `",
+			"data": {"extattrs+": {"Tenant ID": {"value": "globex"}}}, "discard": true}]`))
+	})
+
+	It("should only rewrite the ownership EAs", func() {
+		requestor := &fakeReportingRequestor{}
+		objMgr := NewObjectManager(newConnector(requestor), cmpType, tenantID)
+
+		_, err := objMgr.TransferOwnership([]string{networkRef}, EA{"Tenant ID": "globex", "Site": "east"})
+		Expect(err).To(MatchError("'Site' is not an ownership EA, expected Tenant ID or CMP Type"))
+		_, err = objMgr.TransferOwnership([]string{networkRef}, EA{"CMP Type": ""})
+		Expect(err).NotTo(BeNil())
+		Expect(requestor.reqs).To(BeEmpty())
+	})
+})
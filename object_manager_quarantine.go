@@ -0,0 +1,110 @@
+package ibclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuarantineDeletedAtEA is the integer EA holding the Unix time at which
+// an object was quarantined instead of being deleted
+const QuarantineDeletedAtEA = "Deleted At"
+
+// quarantineTypes are the object types which can be disabled, so
+// quarantined instead of being deleted
+var quarantineTypes = []string{
+	"network", "ipv6network", "range", "ipv6range", "fixedaddress", "ipv6fixedaddress",
+	"record:a", "record:aaaa", "record:cname", "record:host", "record:mx", "record:ptr", "record:srv", "record:txt",
+	"zone_auth", "zone_delegated", "zone_forward", "dtc:lbdn", "dtc:server"}
+
+// quarantineUpdate disables or enables an object, adding or removing
+// QuarantineDeletedAtEA
+type quarantineUpdate struct {
+	IBBase  `json:"-"`
+	Disable bool                   `json:"disable"`
+	Add     EA                     `json:"extattrs+,omitempty"`
+	Remove  map[string]interface{} `json:"extattrs-,omitempty"`
+}
+
+// quarantined is an object found by PurgeQuarantined
+type quarantined struct {
+	Ref string `json:"_ref"`
+	Ea  EA     `json:"extattrs"`
+}
+
+func canQuarantine(objType string) bool {
+	for _, t := range quarantineTypes {
+		if t == objType {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteObject deletes the object referenced by ref, or quarantines it if
+// Quarantine is true and the object can be disabled
+func (objMgr *ObjectManager) deleteObject(ref string) (string, error) {
+	objType := strings.SplitN(ref, "/", 2)[0]
+	if !objMgr.Quarantine || !canQuarantine(objType) {
+		return objMgr.connector.DeleteObject(ref)
+	}
+
+	update := &quarantineUpdate{
+		IBBase:  IBBase{objectType: objType},
+		Disable: true,
+		Add:     EA{QuarantineDeletedAtEA: int(time.Now().Unix())}}
+
+	return objMgr.updateObject(update, ref)
+}
+
+// RestoreQuarantined enables again the object referenced by ref, which was
+// quarantined instead of being deleted, and removes its
+// QuarantineDeletedAtEA EA
+func (objMgr *ObjectManager) RestoreQuarantined(ref string) (string, error) {
+	objType := strings.SplitN(ref, "/", 2)[0]
+	if !canQuarantine(objType) {
+		return "", fmt.Errorf("objects of type '%s' cannot be quarantined", objType)
+	}
+
+	update := &quarantineUpdate{
+		IBBase:  IBBase{objectType: objType},
+		Disable: false,
+		Remove:  map[string]interface{}{QuarantineDeletedAtEA: map[string]interface{}{}}}
+
+	return objMgr.updateObject(update, ref)
+}
+
+// PurgeQuarantined deletes the objects of the tenant quarantined for more
+// than olderThan and returns their refs
+func (objMgr *ObjectManager) PurgeQuarantined(olderThan time.Duration) ([]string, error) {
+	cutoff := int(time.Now().Add(-olderThan).Unix())
+	eaSearch := EA{QuarantineDeletedAtEA + "<": cutoff}
+	if !objMgr.OmitCloudAttrs {
+		eaSearch["Tenant ID"] = objMgr.tenantID
+	}
+
+	var purged []string
+	for _, objType := range quarantineTypes {
+		var res []quarantined
+
+		obj := NewSearchObject(objType, nil, eaSearch, []string{"extattrs"})
+		if err := objMgr.readObjectPaged(obj, DefaultPageSize, &res); err != nil {
+			return purged, err
+		}
+
+		for _, q := range res {
+			deletedAt, ok := q.Ea[QuarantineDeletedAtEA].(int)
+			if !ok || deletedAt >= cutoff {
+				continue
+			}
+
+			ref, err := objMgr.connector.DeleteObject(q.Ref)
+			if err != nil {
+				return purged, err
+			}
+			purged = append(purged, ref)
+		}
+	}
+
+	return purged, nil
+}
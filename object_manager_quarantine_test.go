@@ -0,0 +1,79 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager quarantine", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLmhvc3Q:host.example.com/default"
+	containerRef := "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/8/default"
+
+	It("should disable the objects instead of deleting them", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.Quarantine = true
+
+		before := int(time.Now().Unix())
+		ref, err := objMgr.DeleteHostRecord(hostRef)
+		Expect(err).To(BeNil())
+		Expect(ref).To(Equal(hostRef))
+		Expect(conn.deleteRefs).To(BeEmpty())
+		Expect(conn.updateRefs).To(Equal([]string{hostRef}))
+
+		update := conn.updateObjs[0].(*quarantineUpdate)
+		Expect(update.Disable).To(BeTrue())
+		Expect(update.Add[QuarantineDeletedAtEA]).To(BeNumerically(">=", before))
+	})
+
+	It("should delete the objects which cannot be disabled", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.Quarantine = true
+
+		_, err := objMgr.DeleteNetworkContainer(containerRef, DeleteNetworkCascade)
+		Expect(err).To(BeNil())
+		Expect(conn.deleteRefs).To(Equal([]string{containerRef}))
+	})
+
+	It("should restore the quarantined objects", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, err := objMgr.RestoreQuarantined(hostRef)
+		Expect(err).To(BeNil())
+		js, _ := json.Marshal(conn.updateObjs[0])
+		Expect(js).To(MatchJSON(`{"disable": false, "extattrs-": {"Deleted At": {}}}`))
+
+		_, err = objMgr.RestoreQuarantined(containerRef)
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should purge the objects quarantined for longer than the window", func() {
+		now := int(time.Now().Unix())
+		oldRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsb2xk:old.example.com/default"
+		newRef := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsbmV3:new.example.com/default"
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:a": []quarantined{
+					{Ref: oldRef, Ea: EA{QuarantineDeletedAtEA: now - 7200}},
+					{Ref: newRef, Ea: EA{QuarantineDeletedAtEA: now}}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = false
+
+		purged, err := objMgr.PurgeQuarantined(time.Hour)
+		Expect(err).To(BeNil())
+		Expect(purged).To(Equal([]string{oldRef}))
+		Expect(conn.deleteRefs).To(Equal([]string{oldRef}))
+
+		Expect(conn.getObjs).To(HaveLen(len(quarantineTypes)))
+		eaSearch := conn.getObjs[0].EaSearch()
+		Expect(eaSearch["Tenant ID"]).To(Equal(tenantID))
+		Expect(eaSearch[QuarantineDeletedAtEA+"<"]).To(BeNumerically("~", now-3600, 5))
+	})
+})
@@ -0,0 +1,159 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+// setRangeServerAssociation derives the server association type from the
+// member or failover association given for the range
+func setRangeServerAssociation(rng *Range) {
+	if rng.ServerAssociationType != "" {
+		return
+	}
+
+	if rng.Member != nil {
+		rng.ServerAssociationType = "MEMBER"
+	} else if rng.FailoverAssociation != "" {
+		rng.ServerAssociationType = "FAILOVER"
+	}
+}
+
+// CreateRange creates a DHCP range served by Member or by the failover
+// association named in FailoverAssociation
+func (objMgr *ObjectManager) CreateRange(rng Range) (*Range, error) {
+	newRange := NewRange(rng)
+	setRangeServerAssociation(newRange)
+
+	newRange.Ea = objMgr.getBasicEA(true)
+	for k, v := range rng.Ea {
+		newRange.Ea[k] = v
+	}
+
+	ref, err := objMgr.createObject(newRange)
+	newRange.Ref = ref
+
+	return newRange, err
+}
+
+func (objMgr *ObjectManager) GetRange(netview string, startAddr string, endAddr string) (*Range, error) {
+	var res []Range
+
+	rng := NewRange(Range{
+		NetviewName: netview,
+		StartAddr:   startAddr,
+		EndAddr:     endAddr})
+
+	err := objMgr.getObject(rng, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+func (objMgr *ObjectManager) GetRangeByRef(ref string) (*Range, error) {
+	rng := NewRange(Range{})
+	err := objMgr.getObject(rng, ref, &rng)
+	return rng, err
+}
+
+// UpdateRange updates the range referenced by ref. The network view and
+// network of a range cannot be changed and are ignored.
+func (objMgr *ObjectManager) UpdateRange(ref string, rng Range) (*Range, error) {
+	updateRange := NewRange(rng)
+	updateRange.Ref = ""
+	updateRange.NetviewName = ""
+	updateRange.Network = ""
+	setRangeServerAssociation(updateRange)
+
+	newRef, err := objMgr.updateObject(updateRange, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetRangeByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteRange(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateIPv6Range creates a DHCPv6 range of addresses, delegated prefixes
+// or both, as given by AddressType
+func (objMgr *ObjectManager) CreateIPv6Range(rng IPv6Range) (*IPv6Range, error) {
+	newRange := NewIPv6Range(rng)
+	if newRange.AddressType == "" {
+		newRange.AddressType = AddressTypeAddress
+	}
+	if !newRange.AddressType.IsValid() {
+		return nil, fmt.Errorf("wrong value for address_type passed %s", newRange.AddressType)
+	}
+	if newRange.AddressType != AddressTypeAddress &&
+		(newRange.Ipv6PrefixBits == 0 || newRange.Ipv6StartPrefix == "" || newRange.Ipv6EndPrefix == "") {
+		return nil, fmt.Errorf("IPv6 range of address type %s requires the prefix bits and the start and end prefixes",
+			newRange.AddressType)
+	}
+	if newRange.Member != nil && newRange.ServerAssociationType == "" {
+		newRange.ServerAssociationType = "MEMBER"
+	}
+
+	newRange.Ea = objMgr.getBasicEA(true)
+	for k, v := range rng.Ea {
+		newRange.Ea[k] = v
+	}
+
+	ref, err := objMgr.createObject(newRange)
+	newRange.Ref = ref
+
+	return newRange, err
+}
+
+func (objMgr *ObjectManager) GetIPv6RangeByRef(ref string) (*IPv6Range, error) {
+	rng := NewIPv6Range(IPv6Range{})
+	err := objMgr.getObject(rng, ref, &rng)
+	return rng, err
+}
+
+// GetIPv6PrefixRanges returns the prefix delegation ranges of the IPv6
+// network cidr
+func (objMgr *ObjectManager) GetIPv6PrefixRanges(netview string, cidr string) ([]IPv6Range, error) {
+	var res []IPv6Range
+
+	rng := NewIPv6Range(IPv6Range{
+		NetviewName: netview,
+		Network:     cidr})
+	err := objMgr.getObject(rng, "", &res)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixRanges := make([]IPv6Range, 0, len(res))
+	for _, r := range res {
+		if r.AddressType != "" && r.AddressType != AddressTypeAddress {
+			prefixRanges = append(prefixRanges, r)
+		}
+	}
+
+	return prefixRanges, nil
+}
+
+// UpdateIPv6Range updates the IPv6 range referenced by ref. The network
+// view and network of a range cannot be changed and are ignored.
+func (objMgr *ObjectManager) UpdateIPv6Range(ref string, rng IPv6Range) (*IPv6Range, error) {
+	updateRange := NewIPv6Range(rng)
+	updateRange.Ref = ""
+	updateRange.NetviewName = ""
+	updateRange.Network = ""
+
+	newRef, err := objMgr.updateObject(updateRange, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetIPv6RangeByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteIPv6Range(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
@@ -0,0 +1,152 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Ranges", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	rangeRef := "range/ZG5zLmRoY3BfcmFuZ2UkMTAuMC4wLjEwMA:10.0.0.100/10.0.0.200/default"
+
+	Describe("Create Range", func() {
+		It("should assign the range to the member", func() {
+			conn := &fakeMultiConnector{createRefs: []string{rangeRef}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			rng, err := objMgr.CreateRange(Range{
+				NetviewName: "default",
+				Network:     "10.0.0.0/24",
+				StartAddr:   "10.0.0.100",
+				EndAddr:     "10.0.0.200",
+				Member:      &DhcpMember{Name: "dhcp1.example.com"},
+				Options:     []DhcpOption{{Name: "routers", Value: "10.0.0.1"}},
+			})
+			Expect(err).To(BeNil())
+			Expect(rng.Ref).To(Equal(rangeRef))
+
+			created := conn.createObjs[0].(*Range)
+			Expect(created.ServerAssociationType).To(Equal("MEMBER"))
+			Expect(created.Options).To(Equal([]DhcpOption{{Name: "routers", Value: "10.0.0.1"}}))
+		})
+
+		It("should assign the range to the failover association", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.CreateRange(Range{
+				StartAddr:           "10.0.0.100",
+				EndAddr:             "10.0.0.200",
+				FailoverAssociation: "dhcp-failover",
+			})
+			Expect(err).To(BeNil())
+			Expect(conn.createObjs[0].(*Range).ServerAssociationType).To(Equal("FAILOVER"))
+		})
+	})
+
+	Describe("Get Range", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"range": []Range{{Ref: rangeRef, StartAddr: "10.0.0.100", EndAddr: "10.0.0.200"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should search by network view and addresses", func() {
+			rng, err := objMgr.GetRange("default", "10.0.0.100", "10.0.0.200")
+			Expect(err).To(BeNil())
+			Expect(rng.Ref).To(Equal(rangeRef))
+
+			search := conn.getObjs[0].(*Range)
+			Expect(search.NetviewName).To(Equal("default"))
+			Expect(search.StartAddr).To(Equal("10.0.0.100"))
+			Expect(search.EndAddr).To(Equal("10.0.0.200"))
+		})
+	})
+
+	Describe("Update and Delete Range", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				rangeRef: Range{Ref: rangeRef, EndAddr: "10.0.0.250"},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should update the range without its network and delete it", func() {
+			rng, err := objMgr.UpdateRange(rangeRef, Range{Network: "10.0.0.0/24", EndAddr: "10.0.0.250"})
+			Expect(err).To(BeNil())
+			Expect(rng.EndAddr).To(Equal("10.0.0.250"))
+			Expect(conn.updateObjs[0].(*Range).Network).To(BeEmpty())
+
+			_, err = objMgr.DeleteRange(rangeRef)
+			Expect(err).To(BeNil())
+			Expect(conn.deleteRefs).To(Equal([]string{rangeRef}))
+		})
+	})
+
+	Describe("IPv6 prefix delegation", func() {
+		v6RangeRef := "ipv6range/ZG5zLmRoY3BfcmFuZ2UkMjAwMTpkYjg6OjEwMDA:2001:db8::/default"
+
+		It("should create a prefix delegation range served by the member", func() {
+			conn := &fakeMultiConnector{createRefs: []string{v6RangeRef}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			rng, err := objMgr.CreateIPv6Range(IPv6Range{
+				NetviewName:     "default",
+				Network:         "2001:db8::/48",
+				AddressType:     "PREFIX",
+				Ipv6PrefixBits:  56,
+				Ipv6StartPrefix: "2001:db8:0:100::",
+				Ipv6EndPrefix:   "2001:db8:0:ff00::",
+				Member:          &DhcpMember{Name: "dhcp1.example.com", Ipv6Addr: "2001:db8::53"},
+			})
+			Expect(err).To(BeNil())
+			Expect(rng.Ref).To(Equal(v6RangeRef))
+
+			created := conn.createObjs[0].(*IPv6Range)
+			Expect(created.ObjectType()).To(Equal("ipv6range"))
+			Expect(created.ServerAssociationType).To(Equal("MEMBER"))
+		})
+
+		It("should require the prefixes of a prefix delegation range", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.CreateIPv6Range(IPv6Range{NetviewName: "default", Network: "2001:db8::/48", AddressType: "BOTH"})
+			Expect(err).NotTo(BeNil())
+			Expect(conn.createObjs).To(BeEmpty())
+		})
+
+		It("should only return the prefix delegation ranges of the network", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"ipv6range": []IPv6Range{
+						{Ref: "ipv6range/a", AddressType: "ADDRESS"},
+						{Ref: v6RangeRef, AddressType: "PREFIX", Ipv6PrefixBits: 56},
+					},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			ranges, err := objMgr.GetIPv6PrefixRanges("default", "2001:db8::/48")
+			Expect(err).To(BeNil())
+			Expect(ranges).To(HaveLen(1))
+			Expect(ranges[0].Ipv6PrefixBits).To(Equal(uint32(56)))
+			Expect(conn.getObjs[0].(*IPv6Range).Network).To(Equal("2001:db8::/48"))
+		})
+
+		It("should replace the DHCPv6 options of the network", func() {
+			netRef := "ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi80OC8w:2001%3Adb8%3A%3A/48/default"
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			options := []DhcpOption{{Name: "dhcp6.name-servers", Num: 23, Value: "2001:db8::53"}}
+			network, err := objMgr.UpdateIPv6NetworkOptions(netRef, options)
+			Expect(err).To(BeNil())
+			Expect(network.Ref).To(Equal(netRef))
+			Expect(conn.updateObjs[0].ObjectType()).To(Equal("ipv6network"))
+			Expect(conn.updateObjs[0].(*Network).Options).To(Equal(options))
+		})
+	})
+})
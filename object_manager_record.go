@@ -0,0 +1,171 @@
+package ibclient
+
+func (objMgr *ObjectManager) CreateTXTRecord(recordname string, text string, dnsview string, opts RecordOptions) (*RecordTXT, error) {
+	if err := objMgr.checkRecordConflict("record:txt", recordname, dnsview); err != nil {
+		return nil, err
+	}
+
+	recordTXT := NewRecordTXT(RecordTXT{
+		View:    dnsview,
+		Name:    recordname,
+		Text:    text,
+		Comment: opts.Comment,
+		Ea:      objMgr.recordEA(opts, false)})
+	recordTXT.Ttl, recordTXT.UseTtl = opts.ttl()
+
+	ref, err := objMgr.createObject(recordTXT)
+	recordTXT.Ref = ref
+	return recordTXT, err
+}
+
+func (objMgr *ObjectManager) GetTXTRecordByRef(ref string) (*RecordTXT, error) {
+	recordTXT := NewRecordTXT(RecordTXT{})
+	recordTXT.returnFields = append(recordTXT.returnFields, "creation_time")
+	err := objMgr.getObject(recordTXT, ref, &recordTXT)
+	return recordTXT, err
+}
+
+// UpdateTXTRecord changes the name, text, TTL, comment and EAs of the TXT
+// record referenced by ref. Empty values leave the record unchanged.
+func (objMgr *ObjectManager) UpdateTXTRecord(ref string, recordname string, text string, opts RecordOptions) (*RecordTXT, error) {
+	updateRecordTXT := NewRecordTXT(RecordTXT{
+		Name:    recordname,
+		Text:    text,
+		Comment: opts.Comment,
+		Ea:      objMgr.recordEA(opts, true)})
+	updateRecordTXT.Ttl, updateRecordTXT.UseTtl = opts.ttl()
+
+	newRef, err := objMgr.updateObject(updateRecordTXT, ref)
+	updateRecordTXT.Ref = newRef
+	return updateRecordTXT, err
+}
+
+func (objMgr *ObjectManager) DeleteTXTRecord(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+func (objMgr *ObjectManager) CreateMXRecord(recordname string, mailExchanger string, preference uint32, dnsview string, opts RecordOptions) (*RecordMX, error) {
+	recordMX := NewRecordMX(RecordMX{
+		View:          dnsview,
+		Name:          recordname,
+		MailExchanger: mailExchanger,
+		Preference:    &preference,
+		Comment:       opts.Comment,
+		Ea:            objMgr.recordEA(opts, false)})
+	recordMX.Ttl, recordMX.UseTtl = opts.ttl()
+
+	ref, err := objMgr.createObject(recordMX)
+	recordMX.Ref = ref
+	return recordMX, err
+}
+
+func (objMgr *ObjectManager) GetMXRecordByRef(ref string) (*RecordMX, error) {
+	recordMX := NewRecordMX(RecordMX{})
+	recordMX.returnFields = append(recordMX.returnFields, "creation_time")
+	err := objMgr.getObject(recordMX, ref, &recordMX)
+	return recordMX, err
+}
+
+// UpdateMXRecord changes the name, mail exchanger, preference, TTL, comment
+// and EAs of the MX record referenced by ref. Empty values and a nil
+// preference leave the record unchanged.
+func (objMgr *ObjectManager) UpdateMXRecord(ref string, recordname string, mailExchanger string, preference *uint32, opts RecordOptions) (*RecordMX, error) {
+	updateRecordMX := NewRecordMX(RecordMX{
+		Name:          recordname,
+		MailExchanger: mailExchanger,
+		Preference:    preference,
+		Comment:       opts.Comment,
+		Ea:            objMgr.recordEA(opts, true)})
+	updateRecordMX.Ttl, updateRecordMX.UseTtl = opts.ttl()
+
+	newRef, err := objMgr.updateObject(updateRecordMX, ref)
+	updateRecordMX.Ref = newRef
+	return updateRecordMX, err
+}
+
+func (objMgr *ObjectManager) DeleteMXRecord(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+func (objMgr *ObjectManager) CreateSRVRecord(recordname string, target string, priority uint32, weight uint32, port uint32, dnsview string, opts RecordOptions) (*RecordSRV, error) {
+	recordSRV := NewRecordSRV(RecordSRV{
+		View:     dnsview,
+		Name:     recordname,
+		Target:   target,
+		Priority: &priority,
+		Weight:   &weight,
+		Port:     &port,
+		Comment:  opts.Comment,
+		Ea:       objMgr.recordEA(opts, false)})
+	recordSRV.Ttl, recordSRV.UseTtl = opts.ttl()
+
+	ref, err := objMgr.createObject(recordSRV)
+	recordSRV.Ref = ref
+	return recordSRV, err
+}
+
+func (objMgr *ObjectManager) GetSRVRecordByRef(ref string) (*RecordSRV, error) {
+	recordSRV := NewRecordSRV(RecordSRV{})
+	recordSRV.returnFields = append(recordSRV.returnFields, "creation_time")
+	err := objMgr.getObject(recordSRV, ref, &recordSRV)
+	return recordSRV, err
+}
+
+// UpdateSRVRecord changes the name, target, priority, weight, port, TTL,
+// comment and EAs of the SRV record referenced by ref. Empty values and nil
+// numbers leave the record unchanged.
+func (objMgr *ObjectManager) UpdateSRVRecord(ref string, recordname string, target string, priority *uint32, weight *uint32, port *uint32, opts RecordOptions) (*RecordSRV, error) {
+	updateRecordSRV := NewRecordSRV(RecordSRV{
+		Name:     recordname,
+		Target:   target,
+		Priority: priority,
+		Weight:   weight,
+		Port:     port,
+		Comment:  opts.Comment,
+		Ea:       objMgr.recordEA(opts, true)})
+	updateRecordSRV.Ttl, updateRecordSRV.UseTtl = opts.ttl()
+
+	newRef, err := objMgr.updateObject(updateRecordSRV, ref)
+	updateRecordSRV.Ref = newRef
+	return updateRecordSRV, err
+}
+
+func (objMgr *ObjectManager) DeleteSRVRecord(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateNSRecord delegates zone to the name server nameserver, with the
+// glue addresses given
+func (objMgr *ObjectManager) CreateNSRecord(zone string, nameserver string, addresses []NsAddress, dnsview string) (*RecordNS, error) {
+	recordNS := NewRecordNS(RecordNS{
+		View:       dnsview,
+		Name:       zone,
+		Nameserver: nameserver,
+		Addresses:  addresses})
+
+	ref, err := objMgr.createObject(recordNS)
+	recordNS.Ref = ref
+	return recordNS, err
+}
+
+func (objMgr *ObjectManager) GetNSRecordByRef(ref string) (*RecordNS, error) {
+	recordNS := NewRecordNS(RecordNS{})
+	err := objMgr.getObject(recordNS, ref, &recordNS)
+	return recordNS, err
+}
+
+// UpdateNSRecord changes the name server and the glue addresses of the NS
+// record referenced by ref. Empty values leave the record unchanged.
+func (objMgr *ObjectManager) UpdateNSRecord(ref string, nameserver string, addresses []NsAddress) (*RecordNS, error) {
+	updateRecordNS := NewRecordNS(RecordNS{
+		Nameserver: nameserver,
+		Addresses:  addresses})
+
+	newRef, err := objMgr.updateObject(updateRecordNS, ref)
+	updateRecordNS.Ref = newRef
+	return updateRecordNS, err
+}
+
+func (objMgr *ObjectManager) DeleteNSRecord(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
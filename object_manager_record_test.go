@@ -0,0 +1,137 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Records", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	requestBody := func(obj IBObject) string {
+		var body map[string]interface{}
+		js, _ := json.Marshal(obj)
+		json.Unmarshal(js, &body)
+		delete(body, "_ref")
+		js, _ = json.Marshal(body)
+		return string(js)
+	}
+
+	Describe("TXT records", func() {
+		txtRef := "record:txt/ZG5zLmJpbmRfdHh0:owner.example.com/default"
+		conn := &fakeMultiConnector{
+			createRefs: []string{txtRef},
+			getResults: map[string]interface{}{
+				txtRef: RecordTXT{Ref: txtRef, Name: "owner.example.com", Text: "heritage=external-dns"},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create, get and delete the record", func() {
+			ttl := uint32(60)
+			rec, err := objMgr.CreateTXTRecord("owner.example.com", "heritage=external-dns", "default",
+				RecordOptions{Ttl: &ttl, Ea: EA{"Owner": "external-dns"}})
+			Expect(err).To(BeNil())
+			Expect(rec.Ref).To(Equal(txtRef))
+			Expect(rec.Ttl).To(Equal(uint32(60)))
+			Expect(rec.Ea).To(Equal(EA{"Owner": "external-dns"}))
+
+			rec, err = objMgr.GetTXTRecordByRef(txtRef)
+			Expect(err).To(BeNil())
+			Expect(rec.Text).To(Equal("heritage=external-dns"))
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("creation_time"))
+
+			ref, err := objMgr.DeleteTXTRecord(txtRef)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(txtRef))
+		})
+	})
+
+	Describe("MX records", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should send a zero preference on create and omit a nil one on update", func() {
+			_, err := objMgr.CreateMXRecord("example.com", "mail.example.com", 0, "default", RecordOptions{})
+			Expect(err).To(BeNil())
+			Expect(requestBody(conn.createObjs[0])).To(MatchJSON(`{"name": "example.com", "mail_exchanger": "mail.example.com",
+				"preference": 0, "view": "default"}`))
+
+			_, err = objMgr.UpdateMXRecord("record:mx/ZG5zLmJpbmRfbXg:example.com/default", "", "mx2.example.com", nil, RecordOptions{})
+			Expect(err).To(BeNil())
+			Expect(requestBody(conn.updateObjs[0])).To(MatchJSON(`{"mail_exchanger": "mx2.example.com"}`))
+		})
+	})
+
+	Describe("SRV records", func() {
+		srvRef := "record:srv/ZG5zLmJpbmRfc3J2:_ldap._tcp.example.com/default"
+		conn := &fakeMultiConnector{
+			createRefs: []string{srvRef},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the record with its priority, weight and port", func() {
+			rec, err := objMgr.CreateSRVRecord("_ldap._tcp.example.com", "ldap.example.com", 10, 0, 389, "default",
+				RecordOptions{Comment: "directory"})
+			Expect(err).To(BeNil())
+			Expect(rec.Ref).To(Equal(srvRef))
+
+			Expect(requestBody(conn.createObjs[0])).To(MatchJSON(`{"name": "_ldap._tcp.example.com", "target": "ldap.example.com",
+				"priority": 10, "weight": 0, "port": 389, "view": "default", "comment": "directory"}`))
+		})
+
+		It("should only update the fields given", func() {
+			port := uint32(636)
+			rec, err := objMgr.UpdateSRVRecord(srvRef, "", "", nil, nil, &port, RecordOptions{})
+			Expect(err).To(BeNil())
+			Expect(rec.Ref).To(Equal(srvRef))
+
+			Expect(requestBody(conn.updateObjs[0])).To(MatchJSON(`{"port": 636}`))
+		})
+	})
+
+	Describe("Cloud EAs", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = false
+		cloudEA := EA{"Cloud API Owned": Bool(true), "CMP Type": cmpType, "Tenant ID": tenantID, "VM ID": "vm-1"}
+
+		It("should send the cloud EAs when creating TXT, MX and SRV records", func() {
+			opts := RecordOptions{VmID: "vm-1"}
+			_, err := objMgr.CreateTXTRecord("owner.example.com", "heritage=external-dns", "default", opts)
+			Expect(err).To(BeNil())
+			_, err = objMgr.CreateMXRecord("example.com", "mail.example.com", 10, "default", opts)
+			Expect(err).To(BeNil())
+			_, err = objMgr.CreateSRVRecord("_ldap._tcp.example.com", "ldap.example.com", 10, 0, 389, "default", opts)
+			Expect(err).To(BeNil())
+
+			Expect(conn.createObjs).To(HaveLen(3))
+			Expect(conn.createObjs[0].(*RecordTXT).Ea).To(Equal(cloudEA))
+			Expect(conn.createObjs[1].(*RecordMX).Ea).To(Equal(cloudEA))
+			Expect(conn.createObjs[2].(*RecordSRV).Ea).To(Equal(cloudEA))
+		})
+
+		It("should only send the EAs given when updating them", func() {
+			_, err := objMgr.UpdateTXTRecord("record:txt/ZG5zLmJpbmRfdHh0:owner.example.com/default", "", "v=spf1 -all", RecordOptions{})
+			Expect(err).To(BeNil())
+			Expect(conn.updateObjs[0].(*RecordTXT).Ea).To(BeNil())
+		})
+	})
+
+	Describe("NS records", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the record with its glue addresses", func() {
+			rec, err := objMgr.CreateNSRecord("sub.example.com", "ns1.sub.example.com",
+				[]NsAddress{{Address: "10.0.0.53"}}, "default")
+			Expect(err).To(BeNil())
+			Expect(rec.Ref).To(Equal("record:ns/ZG5zLmZha2U:1"))
+
+			Expect(requestBody(conn.createObjs[0])).To(MatchJSON(`{"name": "sub.example.com", "nameserver": "ns1.sub.example.com",
+				"addresses": [{"address": "10.0.0.53"}], "view": "default"}`))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package ibclient
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ReservationExpiresEA is the integer EA holding the Unix time at which a
+// reservation made by ReserveIP expires
+const ReservationExpiresEA = "Reservation Expires"
+
+// eaRemoval removes the EAs it holds from an object
+type eaRemoval struct {
+	IBBase `json:"-"`
+	Remove map[string]interface{} `json:"extattrs-"`
+}
+
+// ReserveIP allocates ipAddr, or the next available address of cidr if
+// ipAddr is empty, like AllocateIP, but only for ttl. The reservation is
+// kept by ConfirmReservation, otherwise ReapExpiredReservations releases
+// it once expired, e.g. when the VM it was allocated for failed to start.
+func (objMgr *ObjectManager) ReserveIP(netview string, cidr string, ipAddr string, macAddress string, name string,
+	vmID string, vmName string, ttl time.Duration) (*FixedAddress, error) {
+	ea := objMgr.getBasicVMEA(true, vmID, vmName)
+	ea[ReservationExpiresEA] = int(time.Now().Add(ttl).Unix())
+
+	return objMgr.allocateFixedAddress(netview, cidr, ipAddr, macAddress, name, ea)
+}
+
+// ConfirmReservation keeps the address reserved by ReserveIP and
+// referenced by ref, it is no longer released when the reservation expires
+func (objMgr *ObjectManager) ConfirmReservation(ref string) (string, error) {
+	removal := &eaRemoval{
+		IBBase: IBBase{objectType: "fixedaddress"},
+		Remove: map[string]interface{}{ReservationExpiresEA: map[string]interface{}{}}}
+
+	return objMgr.updateObject(removal, ref)
+}
+
+// ReapExpiredReservations releases the expired reservations of the tenant
+// in netview and returns the refs of the released fixed addresses. With
+// Quarantine, the fixed addresses are quarantined rather than deleted.
+func (objMgr *ObjectManager) ReapExpiredReservations(netview string) ([]string, error) {
+	var res []FixedAddress
+
+	now := int(time.Now().Unix())
+	eaSearch := EASearch{ReservationExpiresEA + "<": now}
+	if !objMgr.OmitCloudAttrs {
+		eaSearch["Tenant ID"] = objMgr.tenantID
+	}
+	fixedAddr := NewFixedAddress(FixedAddress{NetviewName: netview})
+	fixedAddr.eaSearch = eaSearch
+
+	if err := objMgr.readObjectPaged(fixedAddr, 0, &res); err != nil {
+		return nil, err
+	}
+
+	var released []string
+	for _, fa := range res {
+		expires, ok := fa.Ea[ReservationExpiresEA].(int)
+		if !ok || expires >= now {
+			continue
+		}
+		if _, quarantined := fa.Ea[QuarantineDeletedAtEA]; quarantined {
+			continue
+		}
+
+		ref, err := objMgr.deleteObject(fa.Ref)
+		if err != nil {
+			return released, err
+		}
+		released = append(released, ref)
+	}
+
+	return released, nil
+}
+
+// RunReservationReaper calls ReapExpiredReservations for netview every
+// interval until ctx is done
+func (objMgr *ObjectManager) RunReservationReaper(ctx context.Context, netview string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := objMgr.ReapExpiredReservations(netview)
+			if err != nil {
+				log.Printf("Failed to reap expired reservations of network view '%s': %s", netview, err)
+			}
+			for _, ref := range released {
+				log.Printf("Released expired reservation '%s'", ref)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Reservations", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+
+	Describe("Reserve IP", func() {
+		conn := &fakeMultiConnector{
+			createRefs: []string{"fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.5/default"},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should allocate the next available address with an expiry", func() {
+			fixedAddr, err := objMgr.ReserveIP("default", "10.0.0.0/24", "", "", "vm1", "", "", 10*time.Minute)
+			Expect(err).To(BeNil())
+			Expect(fixedAddr.IPAddress).To(Equal("10.0.0.5"))
+
+			created := conn.createObjs[0].(*FixedAddress)
+			Expect(created.Mac).To(Equal(MACADDR_ZERO))
+			expires := created.Ea[ReservationExpiresEA].(int)
+			Expect(int64(expires)).To(BeNumerically("~", time.Now().Add(10*time.Minute).Unix(), 5))
+		})
+
+		It("should give a retried reservation the same idempotency key", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID).WithIdempotencyKey("deploy-42")
+
+			_, err := objMgr.ReserveIP("default", "10.0.0.0/24", "10.0.0.5", "", "vm1", "", "", 10*time.Minute)
+			Expect(err).To(BeNil())
+			_, err = objMgr.ReserveIP("default", "10.0.0.0/24", "10.0.0.5", "", "vm1", "", "", 20*time.Minute)
+			Expect(err).To(BeNil())
+
+			first := conn.createObjs[0].(*FixedAddress).Ea
+			retried := conn.createObjs[1].(*FixedAddress).Ea
+			Expect(retried[ReservationExpiresEA]).NotTo(Equal(first[ReservationExpiresEA]))
+			Expect(retried[IdempotencyKeyEA]).To(Equal(first[IdempotencyKeyEA]))
+			Expect(conn.getObjs[1].EaSearch()).To(Equal(EASearch{IdempotencyKeyEA: first[IdempotencyKeyEA]}))
+		})
+
+		It("should remove the expiry on confirmation", func() {
+			ref := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.5/default"
+			_, err := objMgr.ConfirmReservation(ref)
+			Expect(err).To(BeNil())
+			Expect(conn.updateRefs).To(Equal([]string{ref}))
+
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"extattrs-": {"Reservation Expires": {}}}`))
+		})
+	})
+
+	Describe("Reap Expired Reservations", func() {
+		expired := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.5/default"
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"fixedaddress": []FixedAddress{
+					{Ref: expired, Ea: EA{ReservationExpiresEA: int(time.Now().Add(-time.Minute).Unix())}},
+					{Ref: "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.6/default",
+						Ea: EA{ReservationExpiresEA: int(time.Now().Add(time.Hour).Unix())}},
+					{Ref: "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.7/default"},
+				},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = false
+
+		It("should delete the expired reservations of the tenant only", func() {
+			released, err := objMgr.ReapExpiredReservations("default")
+			Expect(err).To(BeNil())
+			Expect(released).To(Equal([]string{expired}))
+			Expect(conn.deleteRefs).To(Equal([]string{expired}))
+
+			search := conn.getObjs[0].EaSearch()
+			Expect(search).To(HaveKey(ReservationExpiresEA + "<"))
+			Expect(search["Tenant ID"]).To(Equal(tenantID))
+		})
+
+		It("should delete the reservations whose expiry is inherited", func() {
+			inherited := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.9/default"
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"fixedaddress": json.RawMessage(`[{"_ref": "` + inherited + `", "extattrs": {"Reservation Expires": {
+						"value": ` + strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10) + `,
+						"inheritance_source": {"_ref": "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"}}}}]`),
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			released, err := objMgr.ReapExpiredReservations("default")
+			Expect(err).To(BeNil())
+			Expect(released).To(Equal([]string{inherited}))
+		})
+
+		It("should quarantine the expired reservations with Quarantine", func() {
+			quarantined := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:10.0.0.8/default"
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"fixedaddress": []FixedAddress{
+						{Ref: expired, Ea: EA{ReservationExpiresEA: int(time.Now().Add(-time.Minute).Unix())}},
+						{Ref: quarantined, Ea: EA{ReservationExpiresEA: int(time.Now().Add(-time.Hour).Unix()),
+							QuarantineDeletedAtEA: int(time.Now().Add(-time.Minute).Unix())}},
+					},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			objMgr.Quarantine = true
+
+			released, err := objMgr.ReapExpiredReservations("default")
+			Expect(err).To(BeNil())
+			Expect(released).To(Equal([]string{expired}))
+			Expect(conn.deleteRefs).To(BeEmpty())
+			Expect(conn.updateRefs).To(Equal([]string{expired}))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package ibclient
+
+import (
+	"sync"
+)
+
+// resolveNameFields maps the object types whose name is not in the name
+// field to the field holding it
+var resolveNameFields = map[string]string{
+	"zone_auth":      "fqdn",
+	"zone_forward":   "fqdn",
+	"zone_delegated": "fqdn",
+	"zone_stub":      "fqdn",
+	"member":         "host_name",
+}
+
+// resolveViewless are the object types which do not belong to a DNS view
+var resolveViewless = map[string]bool{
+	"view":        true,
+	"networkview": true,
+	"member":      true,
+	"namedacl":    true,
+	"nsgroup":     true,
+}
+
+type refCacheKey struct {
+	objectType string
+	name       string
+	view       string
+}
+
+// refCache maps the names resolved by Resolve to their refs
+type refCache struct {
+	mu   sync.Mutex
+	refs map[refCacheKey]string
+}
+
+// Resolve returns the ref of the object of objectType named name, e.g. a
+// zone_auth by FQDN or a member by host name, in the DNS view view when the
+// type belongs to one. Resolved refs are cached by the ObjectManager; an
+// *UnresolvedError, matching ErrNotFound, is returned if no object has the
+// name.
+func (objMgr *ObjectManager) Resolve(objectType string, name string, view string) (string, error) {
+	if resolveViewless[objectType] {
+		view = ""
+	}
+	key := refCacheKey{objectType: objectType, name: name, view: view}
+
+	if objMgr.resolved != nil {
+		objMgr.resolved.mu.Lock()
+		ref, ok := objMgr.resolved.refs[key]
+		objMgr.resolved.mu.Unlock()
+		if ok {
+			return ref, nil
+		}
+	}
+
+	field, ok := resolveNameFields[objectType]
+	if !ok {
+		field = "name"
+	}
+	filters := map[string]string{field: name}
+	if view != "" {
+		filters["view"] = view
+	}
+
+	res, err := objMgr.SearchObjects(objectType, filters, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(res) == 0 {
+		return "", &UnresolvedError{ObjectType: objectType, Name: name, View: view}
+	}
+	ref, _ := res[0]["_ref"].(string)
+	if ref == "" {
+		return "", &UnresolvedError{ObjectType: objectType, Name: name, View: view}
+	}
+
+	if objMgr.resolved != nil {
+		objMgr.resolved.mu.Lock()
+		objMgr.resolved.refs[key] = ref
+		objMgr.resolved.mu.Unlock()
+	}
+
+	return ref, nil
+}
+
+// ForgetResolved drops the refs cached by Resolve, e.g. after objects were
+// deleted or renamed
+func (objMgr *ObjectManager) ForgetResolved() {
+	if objMgr.resolved != nil {
+		objMgr.resolved.mu.Lock()
+		objMgr.resolved.refs = make(map[refCacheKey]string)
+		objMgr.resolved.mu.Unlock()
+	}
+}
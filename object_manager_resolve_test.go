@@ -0,0 +1,57 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Resolve", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	zoneRef := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"
+	memberRef := "member/b25lLnZpcnR1YWxfbm9kZSQw:infoblox.localdomain"
+
+	It("should search the name field of the type and cache the ref", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"zone_auth": []map[string]interface{}{{"_ref": zoneRef, "fqdn": "example.com"}},
+				"member":    []map[string]interface{}{{"_ref": memberRef, "host_name": "infoblox.localdomain"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		ref, err := objMgr.Resolve("zone_auth", "example.com", "default")
+		Expect(err).To(BeNil())
+		Expect(ref).To(Equal(zoneRef))
+		js, _ := json.Marshal(conn.getObjs[0])
+		Expect(js).To(MatchJSON(`{"fqdn": "example.com", "view": "default"}`))
+
+		ref, err = objMgr.WithContext(nil).Resolve("zone_auth", "example.com", "default")
+		Expect(err).To(BeNil())
+		Expect(ref).To(Equal(zoneRef))
+		Expect(len(conn.getObjs)).To(Equal(1))
+
+		ref, err = objMgr.Resolve("member", "infoblox.localdomain", "default")
+		Expect(err).To(BeNil())
+		Expect(ref).To(Equal(memberRef))
+		js, _ = json.Marshal(conn.getObjs[1])
+		Expect(js).To(MatchJSON(`{"host_name": "infoblox.localdomain"}`))
+
+		objMgr.ForgetResolved()
+		_, err = objMgr.Resolve("zone_auth", "example.com", "default")
+		Expect(err).To(BeNil())
+		Expect(len(conn.getObjs)).To(Equal(3))
+	})
+
+	It("should return an error matching ErrNotFound for an unknown name", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, err := objMgr.Resolve("namedacl", "trusted", "")
+		Expect(errors.Is(err, ErrNotFound)).To(BeTrue())
+		Expect(err.Error()).To(Equal("namedacl 'trusted' not found"))
+	})
+})
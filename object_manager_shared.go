@@ -0,0 +1,278 @@
+package ibclient
+
+import (
+	"fmt"
+)
+
+// sharedRecordGroupZones sets the zones of a shared record group, an empty
+// list dissociating the group from all of its zones
+type sharedRecordGroupZones struct {
+	IBBase           `json:"-"`
+	ZoneAssociations []ZoneAssociation `json:"zone_associations"`
+}
+
+// CreateSharedRecordGroup creates a shared record group, its records are
+// published in the zones of ZoneAssociations
+func (objMgr *ObjectManager) CreateSharedRecordGroup(srg SharedRecordGroup) (*SharedRecordGroup, error) {
+	group := NewSharedRecordGroup(srg)
+	group.Ea = objMgr.viewEA(srg.Ea)
+
+	ref, err := objMgr.createObject(group)
+	group.Ref = ref
+
+	return group, err
+}
+
+func (objMgr *ObjectManager) GetSharedRecordGroupByRef(ref string) (*SharedRecordGroup, error) {
+	group := NewSharedRecordGroup(SharedRecordGroup{})
+	err := objMgr.getObject(group, ref, &group)
+	return group, err
+}
+
+func (objMgr *ObjectManager) GetSharedRecordGroupByName(name string) (*SharedRecordGroup, error) {
+	var res []SharedRecordGroup
+
+	group := NewSharedRecordGroup(SharedRecordGroup{Name: name})
+	err := objMgr.getObject(group, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateSharedRecordGroup updates the shared record group referenced by
+// ref, empty values and lists leave the group unchanged. The zones of the
+// group are changed by AssociateSharedRecordGroup and
+// DissociateSharedRecordGroup.
+func (objMgr *ObjectManager) UpdateSharedRecordGroup(ref string, srg SharedRecordGroup) (*SharedRecordGroup, error) {
+	group := NewSharedRecordGroup(srg)
+	group.Ref = ""
+
+	newRef, err := objMgr.updateObject(group, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetSharedRecordGroupByRef(newRef)
+}
+
+// DeleteSharedRecordGroup deletes the shared record group referenced by
+// ref along with its shared records
+func (objMgr *ObjectManager) DeleteSharedRecordGroup(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// setSharedRecordGroupZones replaces the zones of the shared record group
+// referenced by ref
+func (objMgr *ObjectManager) setSharedRecordGroupZones(ref string, zones []ZoneAssociation) (*SharedRecordGroup, error) {
+	update := &sharedRecordGroupZones{
+		IBBase:           IBBase{objectType: "sharedrecordgroup"},
+		ZoneAssociations: zones}
+
+	newRef, err := objMgr.updateObject(update, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetSharedRecordGroupByRef(newRef)
+}
+
+// AssociateSharedRecordGroup publishes the records of the shared record
+// group referenced by ref in the authoritative zones of zones, in addition
+// to the zones the group is already associated with
+func (objMgr *ObjectManager) AssociateSharedRecordGroup(ref string, zones []ZoneAssociation) (*SharedRecordGroup, error) {
+	group, err := objMgr.withDefaultReturnFields().GetSharedRecordGroupByRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if group.Name == "" {
+		return nil, fmt.Errorf("shared record group '%s' not found", ref)
+	}
+
+	associations := append([]ZoneAssociation{}, group.ZoneAssociations...)
+	for _, z := range zones {
+		found := false
+		for _, a := range associations {
+			if a == z {
+				found = true
+				break
+			}
+		}
+		if !found {
+			associations = append(associations, z)
+		}
+	}
+
+	return objMgr.setSharedRecordGroupZones(ref, associations)
+}
+
+// DissociateSharedRecordGroup stops publishing the records of the shared
+// record group referenced by ref in the zones of zones. A zone without a
+// view is dissociated in all the DNS views.
+func (objMgr *ObjectManager) DissociateSharedRecordGroup(ref string, zones []ZoneAssociation) (*SharedRecordGroup, error) {
+	group, err := objMgr.withDefaultReturnFields().GetSharedRecordGroupByRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if group.Name == "" {
+		return nil, fmt.Errorf("shared record group '%s' not found", ref)
+	}
+
+	associations := []ZoneAssociation{}
+	for _, a := range group.ZoneAssociations {
+		dissociated := false
+		for _, z := range zones {
+			if z.Fqdn == a.Fqdn && (z.View == "" || z.View == a.View) {
+				dissociated = true
+				break
+			}
+		}
+		if !dissociated {
+			associations = append(associations, a)
+		}
+	}
+
+	return objMgr.setSharedRecordGroupZones(ref, associations)
+}
+
+// CreateSharedRecordA creates an A record in the shared record group
+// sr.SharedRecordGroup
+func (objMgr *ObjectManager) CreateSharedRecordA(sr SharedRecordA) (*SharedRecordA, error) {
+	record := NewSharedRecordA(sr)
+	record.Ea = objMgr.recordEA(RecordOptions{Ea: sr.Ea}, false)
+
+	ref, err := objMgr.createObject(record)
+	record.Ref = ref
+
+	return record, err
+}
+
+func (objMgr *ObjectManager) GetSharedRecordAByRef(ref string) (*SharedRecordA, error) {
+	record := NewSharedRecordA(SharedRecordA{})
+	err := objMgr.getObject(record, ref, &record)
+	return record, err
+}
+
+// GetSharedRecordsA returns the A records of the shared record group named
+// group
+func (objMgr *ObjectManager) GetSharedRecordsA(group string) ([]SharedRecordA, error) {
+	var res []SharedRecordA
+
+	record := NewSharedRecordA(SharedRecordA{SharedRecordGroup: group})
+	err := objMgr.getObject(record, "", &res)
+
+	return res, err
+}
+
+// UpdateSharedRecordA updates the shared A record referenced by ref, empty
+// values leave the record unchanged
+func (objMgr *ObjectManager) UpdateSharedRecordA(ref string, sr SharedRecordA) (*SharedRecordA, error) {
+	record := NewSharedRecordA(sr)
+	record.Ref = ""
+
+	newRef, err := objMgr.updateObject(record, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetSharedRecordAByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteSharedRecordA(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateSharedRecordAAAA creates an AAAA record in the shared record group
+// sr.SharedRecordGroup
+func (objMgr *ObjectManager) CreateSharedRecordAAAA(sr SharedRecordAAAA) (*SharedRecordAAAA, error) {
+	record := NewSharedRecordAAAA(sr)
+	record.Ea = objMgr.recordEA(RecordOptions{Ea: sr.Ea}, false)
+
+	ref, err := objMgr.createObject(record)
+	record.Ref = ref
+
+	return record, err
+}
+
+func (objMgr *ObjectManager) GetSharedRecordAAAAByRef(ref string) (*SharedRecordAAAA, error) {
+	record := NewSharedRecordAAAA(SharedRecordAAAA{})
+	err := objMgr.getObject(record, ref, &record)
+	return record, err
+}
+
+// GetSharedRecordsAAAA returns the AAAA records of the shared record group
+// named group
+func (objMgr *ObjectManager) GetSharedRecordsAAAA(group string) ([]SharedRecordAAAA, error) {
+	var res []SharedRecordAAAA
+
+	record := NewSharedRecordAAAA(SharedRecordAAAA{SharedRecordGroup: group})
+	err := objMgr.getObject(record, "", &res)
+
+	return res, err
+}
+
+// UpdateSharedRecordAAAA updates the shared AAAA record referenced by ref,
+// empty values leave the record unchanged
+func (objMgr *ObjectManager) UpdateSharedRecordAAAA(ref string, sr SharedRecordAAAA) (*SharedRecordAAAA, error) {
+	record := NewSharedRecordAAAA(sr)
+	record.Ref = ""
+
+	newRef, err := objMgr.updateObject(record, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetSharedRecordAAAAByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteSharedRecordAAAA(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateSharedRecordCNAME creates a CNAME record in the shared record
+// group sr.SharedRecordGroup
+func (objMgr *ObjectManager) CreateSharedRecordCNAME(sr SharedRecordCNAME) (*SharedRecordCNAME, error) {
+	record := NewSharedRecordCNAME(sr)
+	record.Ea = objMgr.recordEA(RecordOptions{Ea: sr.Ea}, false)
+
+	ref, err := objMgr.createObject(record)
+	record.Ref = ref
+
+	return record, err
+}
+
+func (objMgr *ObjectManager) GetSharedRecordCNAMEByRef(ref string) (*SharedRecordCNAME, error) {
+	record := NewSharedRecordCNAME(SharedRecordCNAME{})
+	err := objMgr.getObject(record, ref, &record)
+	return record, err
+}
+
+// GetSharedRecordsCNAME returns the CNAME records of the shared record
+// group named group
+func (objMgr *ObjectManager) GetSharedRecordsCNAME(group string) ([]SharedRecordCNAME, error) {
+	var res []SharedRecordCNAME
+
+	record := NewSharedRecordCNAME(SharedRecordCNAME{SharedRecordGroup: group})
+	err := objMgr.getObject(record, "", &res)
+
+	return res, err
+}
+
+// UpdateSharedRecordCNAME updates the shared CNAME record referenced by
+// ref, empty values leave the record unchanged
+func (objMgr *ObjectManager) UpdateSharedRecordCNAME(ref string, sr SharedRecordCNAME) (*SharedRecordCNAME, error) {
+	record := NewSharedRecordCNAME(sr)
+	record.Ref = ""
+
+	newRef, err := objMgr.updateObject(record, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetSharedRecordCNAMEByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteSharedRecordCNAME(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
@@ -0,0 +1,75 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager shared records", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	groupRef := "sharedrecordgroup/ZG5zLnNoYXJlZF9yZWNvcmRfZ3JvdXAkd2Vi:web"
+
+	It("should create the shared records of a group", func() {
+		recordRef := "sharedrecord:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQud2ViLHd3dywxMC4wLjAuMTA:www/web"
+		conn := &fakeMultiConnector{createRefs: []string{recordRef}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = true
+
+		record, err := objMgr.CreateSharedRecordA(SharedRecordA{Name: "www", Ipv4Addr: "10.0.0.10", SharedRecordGroup: "web"})
+		Expect(err).To(BeNil())
+		Expect(record.Ref).To(Equal(recordRef))
+
+		js, _ := json.Marshal(conn.createObjs[0])
+		Expect(js).To(MatchJSON(`{"_ref": "` + recordRef + `", "name": "www", "ipv4addr": "10.0.0.10",
+			"shared_record_group": "web"}`))
+	})
+
+	It("should list the shared records of a group", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"sharedrecord:cname": []SharedRecordCNAME{{Name: "docs", Canonical: "www", SharedRecordGroup: "web"}}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		records, err := objMgr.GetSharedRecordsCNAME("web")
+		Expect(err).To(BeNil())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Canonical).To(Equal("www"))
+		js, _ := json.Marshal(conn.getObjs[0])
+		Expect(js).To(MatchJSON(`{"shared_record_group": "web"}`))
+	})
+
+	Describe("Zone associations", func() {
+		newConn := func() *fakeMultiConnector {
+			return &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					groupRef: SharedRecordGroup{Ref: groupRef, Name: "web", ZoneAssociations: []ZoneAssociation{
+						{Fqdn: "example.com", View: "default"},
+						{Fqdn: "example.com", View: "internal"}}}}}
+		}
+
+		It("should add the zones to the zones of the group", func() {
+			conn := newConn()
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.AssociateSharedRecordGroup(groupRef, []ZoneAssociation{
+				{Fqdn: "example.com", View: "default"}, {Fqdn: "example.org", View: "default"}})
+			Expect(err).To(BeNil())
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"zone_associations": [{"fqdn": "example.com", "view": "default"},
+				{"fqdn": "example.com", "view": "internal"}, {"fqdn": "example.org", "view": "default"}]}`))
+		})
+
+		It("should remove the zones of all the views", func() {
+			conn := newConn()
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.DissociateSharedRecordGroup(groupRef, []ZoneAssociation{{Fqdn: "example.com"}})
+			Expect(err).To(BeNil())
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"zone_associations": []}`))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+//go:build subscriber
+// +build subscriber
+
+package ibclient
+
+// The subscriber services objects are only available on grids licensed
+// for them, they are built with the subscriber tag:
+//
+//	go build -tags subscriber
+
+// SubscriberSiteMember is a grid member of a subscriber site
+type SubscriberSiteMember struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// NasGateway is a NAS sending RADIUS accounting to a subscriber site
+type NasGateway struct {
+	Name         string `json:"name"`
+	IpAddress    string `json:"ip_address"`
+	SharedSecret string `json:"shared_secret,omitempty"`
+	SendAck      bool   `json:"send_ack,omitempty"`
+	Comment      string `json:"comment,omitempty"`
+}
+
+// SubscriberSite represents parentalcontrol:subscribersite wapi object
+type SubscriberSite struct {
+	IBBase             `json:"-"`
+	Ref                string                 `json:"_ref,omitempty"`
+	Name               string                 `json:"name,omitempty"`
+	Comment            string                 `json:"comment,omitempty"`
+	Members            []SubscriberSiteMember `json:"members,omitempty"`
+	MaximumSubscribers uint32                 `json:"maximum_subscribers,omitempty"`
+	BlockingIpv4Vip1   string                 `json:"blocking_ipv4_vip1,omitempty"`
+	BlockingIpv4Vip2   string                 `json:"blocking_ipv4_vip2,omitempty"`
+	BlockingIpv6Vip1   string                 `json:"blocking_ipv6_vip1,omitempty"`
+	BlockingIpv6Vip2   string                 `json:"blocking_ipv6_vip2,omitempty"`
+	NasGateways        []NasGateway           `json:"nas_gateways,omitempty"`
+	NasPort            uint32                 `json:"nas_port,omitempty"`
+	Ea                 EA                     `json:"extattrs,omitempty"`
+}
+
+func NewSubscriberSite(ss SubscriberSite) *SubscriberSite {
+	res := ss
+	res.objectType = "parentalcontrol:subscribersite"
+	res.returnFields = []string{"blocking_ipv4_vip1", "blocking_ipv4_vip2", "blocking_ipv6_vip1", "blocking_ipv6_vip2",
+		"comment", "extattrs", "maximum_subscribers", "members", "name", "nas_gateways", "nas_port"}
+
+	return &res
+}
+
+// BlockingPolicy represents parentalcontrol:blockingpolicy wapi object
+type BlockingPolicy struct {
+	IBBase `json:"-"`
+	Ref    string `json:"_ref,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+func NewBlockingPolicy(bp BlockingPolicy) *BlockingPolicy {
+	res := bp
+	res.objectType = "parentalcontrol:blockingpolicy"
+	res.returnFields = []string{"name", "value"}
+
+	return &res
+}
+
+func (objMgr *ObjectManager) CreateSubscriberSite(ss SubscriberSite) (*SubscriberSite, error) {
+	site := NewSubscriberSite(ss)
+
+	ref, err := objMgr.createObject(site)
+	site.Ref = ref
+	return site, err
+}
+
+func (objMgr *ObjectManager) GetSubscriberSite(name string) (*SubscriberSite, error) {
+	var res []SubscriberSite
+
+	site := NewSubscriberSite(SubscriberSite{Name: name})
+	err := objMgr.getObject(site, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateSubscriberSite changes the subscriber site referenced by ref with
+// the non-empty fields of ss
+func (objMgr *ObjectManager) UpdateSubscriberSite(ref string, ss SubscriberSite) (*SubscriberSite, error) {
+	site := NewSubscriberSite(ss)
+	site.Ref = ""
+
+	newRef, err := objMgr.updateObject(site, ref)
+	site.Ref = newRef
+	return site, err
+}
+
+func (objMgr *ObjectManager) DeleteSubscriberSite(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+func (objMgr *ObjectManager) CreateBlockingPolicy(name string, value string) (*BlockingPolicy, error) {
+	policy := NewBlockingPolicy(BlockingPolicy{Name: name, Value: value})
+
+	ref, err := objMgr.createObject(policy)
+	policy.Ref = ref
+	return policy, err
+}
+
+func (objMgr *ObjectManager) GetBlockingPolicy(name string) (*BlockingPolicy, error) {
+	var res []BlockingPolicy
+
+	policy := NewBlockingPolicy(BlockingPolicy{Name: name})
+	err := objMgr.getObject(policy, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+func (objMgr *ObjectManager) DeleteBlockingPolicy(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
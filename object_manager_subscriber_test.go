@@ -0,0 +1,61 @@
+//go:build subscriber
+// +build subscriber
+
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Subscriber Services", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	siteRef := "parentalcontrol:subscribersite/ZG5zLnN1YnNjcmliZXJfc2l0ZSRzaXRlMQ:site1"
+
+	Describe("Subscriber sites", func() {
+		conn := &fakeMultiConnector{
+			createRefs: []string{siteRef},
+			getResults: map[string]interface{}{
+				"parentalcontrol:subscribersite": []SubscriberSite{{Ref: siteRef, Name: "site1", MaximumSubscribers: 100000}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create, get, update and delete the site", func() {
+			site, err := objMgr.CreateSubscriberSite(SubscriberSite{
+				Name:        "site1",
+				Members:     []SubscriberSiteMember{{Name: "dns1.example.com"}},
+				NasGateways: []NasGateway{{Name: "bng1", IpAddress: "10.1.0.1", SharedSecret: "s3cret"}},
+			})
+			Expect(err).To(BeNil())
+			Expect(site.Ref).To(Equal(siteRef))
+			Expect(conn.createObjs[0].ObjectType()).To(Equal("parentalcontrol:subscribersite"))
+
+			site, err = objMgr.GetSubscriberSite("site1")
+			Expect(err).To(BeNil())
+			Expect(site.MaximumSubscribers).To(Equal(uint32(100000)))
+			Expect(conn.getObjs[0].(*SubscriberSite).Name).To(Equal("site1"))
+
+			site, err = objMgr.UpdateSubscriberSite(siteRef, SubscriberSite{Ref: siteRef, Comment: "north"})
+			Expect(err).To(BeNil())
+			Expect(site.Ref).To(Equal(siteRef))
+			Expect(conn.updateObjs[0].(*SubscriberSite).Comment).To(Equal("north"))
+
+			ref, err := objMgr.DeleteSubscriberSite(siteRef)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(siteRef))
+		})
+	})
+
+	Describe("Blocking policies", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the policy", func() {
+			policy, err := objMgr.CreateBlockingPolicy("adult", "00000000000000000000000000000001")
+			Expect(err).To(BeNil())
+			Expect(policy.Ref).To(Equal("parentalcontrol:blockingpolicy/ZG5zLmZha2U:1"))
+		})
+	})
+})
@@ -0,0 +1,223 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CreateNetworkTemplate creates a network template, the networks created
+// from it with CreateNetworkWithTemplate or AllocateNetworkWithTemplate get
+// its options, range and fixed address templates and EAs
+func (objMgr *ObjectManager) CreateNetworkTemplate(nt NetworkTemplate) (*NetworkTemplate, error) {
+	template := NewNetworkTemplate(nt)
+	template.Ea = objMgr.viewEA(nt.Ea)
+
+	ref, err := objMgr.createObject(template)
+	template.Ref = ref
+
+	return template, err
+}
+
+func (objMgr *ObjectManager) GetNetworkTemplateByRef(ref string) (*NetworkTemplate, error) {
+	template := NewNetworkTemplate(NetworkTemplate{})
+	err := objMgr.getObject(template, ref, &template)
+	return template, err
+}
+
+func (objMgr *ObjectManager) GetNetworkTemplateByName(name string) (*NetworkTemplate, error) {
+	var res []NetworkTemplate
+
+	template := NewNetworkTemplate(NetworkTemplate{Name: name})
+	err := objMgr.getObject(template, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateNetworkTemplate updates the network template referenced by ref,
+// empty values and lists leave the template unchanged. The networks
+// already created from the template are not changed.
+func (objMgr *ObjectManager) UpdateNetworkTemplate(ref string, nt NetworkTemplate) (*NetworkTemplate, error) {
+	template := NewNetworkTemplate(nt)
+	template.Ref = ""
+
+	newRef, err := objMgr.updateObject(template, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetNetworkTemplateByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteNetworkTemplate(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateRangeTemplate creates a range template, referred to by name in the
+// RangeTemplates of network templates
+func (objMgr *ObjectManager) CreateRangeTemplate(rt RangeTemplate) (*RangeTemplate, error) {
+	template := NewRangeTemplate(rt)
+	template.Ea = objMgr.viewEA(rt.Ea)
+
+	ref, err := objMgr.createObject(template)
+	template.Ref = ref
+
+	return template, err
+}
+
+func (objMgr *ObjectManager) GetRangeTemplateByRef(ref string) (*RangeTemplate, error) {
+	template := NewRangeTemplate(RangeTemplate{})
+	err := objMgr.getObject(template, ref, &template)
+	return template, err
+}
+
+func (objMgr *ObjectManager) GetRangeTemplateByName(name string) (*RangeTemplate, error) {
+	var res []RangeTemplate
+
+	template := NewRangeTemplate(RangeTemplate{Name: name})
+	err := objMgr.getObject(template, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateRangeTemplate updates the range template referenced by ref, empty
+// values and lists leave the template unchanged
+func (objMgr *ObjectManager) UpdateRangeTemplate(ref string, rt RangeTemplate) (*RangeTemplate, error) {
+	template := NewRangeTemplate(rt)
+	template.Ref = ""
+
+	newRef, err := objMgr.updateObject(template, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetRangeTemplateByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteRangeTemplate(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// HostSpec is a host record created by ProvisionSubnet
+type HostSpec struct {
+	Name string
+	// IPAddress is the address of the host, the next available address of
+	// the subnet if empty
+	IPAddress string
+	Mac       string
+	// EnableDns creates the host in the default DNS view of the network
+	// view of the subnet
+	EnableDns bool
+	Ea        EA
+}
+
+// ProvisionSubnet allocates the next available network of prefix length
+// prefixLen in the IPv4 network container referenced by containerRef, from
+// the network template named template if not empty, and creates the host
+// records of hosts in it. The network and the hosts are created in a
+// single request, so either all of them are created or none.
+func (objMgr *ObjectManager) ProvisionSubnet(containerRef string, prefixLen uint, template string, hosts []HostSpec) (*Network, []HostRecord, error) {
+	if isIPv6ContainerRef(containerRef) {
+		return nil, nil, fmt.Errorf("network container '%s' is not an IPv4 network container", containerRef)
+	}
+
+	container, err := objMgr.withDefaultReturnFields().GetNetworkContainerByRef(containerRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	if container.Cidr == "" {
+		return nil, nil, fmt.Errorf("network container '%s' not found", containerRef)
+	}
+	netview := container.NetviewName
+	if err = validatePrefixLen(container.Cidr, prefixLen); err != nil {
+		return nil, nil, err
+	}
+
+	dnsView := ""
+	for _, h := range hosts {
+		if h.EnableDns {
+			if dnsView, err = objMgr.withDefaultReturnFields().GetDefaultDNSView(netview); err != nil {
+				return nil, nil, err
+			}
+			break
+		}
+	}
+
+	network := NewNetwork(Network{
+		NetviewName: netview,
+		Cidr:        fmt.Sprintf("func:nextavailablenetwork:%s,%s,%d", container.Cidr, netview, prefixLen),
+		Template:    template,
+		Ea:          objMgr.getBasicEA(true)})
+	data, err := objectData(network)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := []*RequestBody{{
+		Method:      "POST",
+		Object:      network.ObjectType(),
+		Data:        data,
+		Args:        map[string]string{"_return_fields": strings.Join(network.ReturnFields(), ",")},
+		AssignState: map[string]string{"NETWORK": "network"}}}
+
+	for _, h := range hosts {
+		enableDNS := new(bool)
+		*enableDNS = h.EnableDns
+		addr := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Ipv4Addr: h.IPAddress, Mac: h.Mac})
+		if addr.Ipv4Addr == "" {
+			addr.Ipv4Addr = "func:nextavailableip:##STATE:NETWORK:##," + netview
+		}
+		host := NewHostRecord(HostRecord{
+			Name:        h.Name,
+			EnableDns:   enableDNS,
+			NetworkView: netview,
+			View:        dnsView,
+			Ipv4Addrs:   []HostRecordIpv4Addr{*addr},
+			Ea:          objMgr.recordEA(RecordOptions{Ea: h.Ea}, false)})
+
+		if data, err = objectData(host); err != nil {
+			return nil, nil, err
+		}
+		body = append(body, &RequestBody{
+			Method:             "POST",
+			Object:             host.ObjectType(),
+			Data:               data,
+			Args:               map[string]string{"_return_fields": strings.Join(host.ReturnFields(), ",")},
+			EnableSubstitution: true})
+	}
+
+	resp, err := objMgr.multiRequest(NewMultiRequest(body))
+	if errors.Is(err, ErrUnsupportedConnector) {
+		return nil, nil, err
+	}
+	if err != nil {
+		return nil, nil, objMgr.allocationError(netview, container.Cidr, prefixLen, err)
+	}
+
+	var raw []json.RawMessage
+	if err = json.Unmarshal(resp, &raw); err != nil {
+		return nil, nil, err
+	}
+	if len(raw) != len(body) {
+		return nil, nil, fmt.Errorf("multi request returned %d results for %d operations", len(raw), len(body))
+	}
+
+	network = NewNetwork(Network{})
+	if err = json.Unmarshal(raw[0], network); err != nil {
+		return nil, nil, err
+	}
+	records := make([]HostRecord, len(hosts))
+	for i := range records {
+		if err = json.Unmarshal(raw[i+1], &records[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return network, records, nil
+}
@@ -0,0 +1,82 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager templates", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.11", Port: "443"}
+	containerRef := "networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEw:10.0.0.0/16/default"
+	networkRef := "network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default"
+	hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLndlYg:web.example.com/default"
+
+	It("should create the networks from a template", func() {
+		conn := &fakeMultiConnector{createRefs: []string{networkRef}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = true
+
+		network, err := objMgr.AllocateNetworkWithTemplate("default", "10.0.0.0/16", 24, "", "web-tier")
+		Expect(err).To(BeNil())
+		Expect(network.Cidr).To(Equal("10.0.0.0/24"))
+
+		js, _ := json.Marshal(conn.createObjs[0])
+		Expect(js).To(MatchJSON(`{"network_view": "default", "network": "func:nextavailablenetwork:10.0.0.0/16,default,24",
+			"template": "web-tier"}`))
+	})
+
+	It("should provision the network and its hosts in a single request", func() {
+		requestor := &fakeReportingRequestor{res: [][]byte{
+			[]byte(`{"_ref": "` + containerRef + `", "network": "10.0.0.0/16", "network_view": "default"}`),
+			[]byte(`[{"_ref": "` + networkRef + `", "network": "10.0.0.0/24", "network_view": "default"},
+				{"_ref": "` + hostRef + `", "name": "web.example.com", "view": "default",
+					"ipv4addrs": [{"ipv4addr": "10.0.0.1", "mac": "00:00:00:00:00:01"}]}]`)}}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		objMgr := NewObjectManager(&Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}, cmpType, tenantID)
+		objMgr.OmitCloudAttrs = true
+
+		network, hosts, err := objMgr.ProvisionSubnet(containerRef, 24, "web-tier",
+			[]HostSpec{{Name: "web.example.com", Mac: "00:00:00:00:00:01", Ea: EA{"Site": "east"}}})
+		Expect(err).To(BeNil())
+		Expect(network.Ref).To(Equal(networkRef))
+		Expect(network.Cidr).To(Equal("10.0.0.0/24"))
+		Expect(hosts).To(HaveLen(1))
+		Expect(hosts[0].Ref).To(Equal(hostRef))
+		Expect(hosts[0].Ipv4Addrs[0].Ipv4Addr).To(Equal("10.0.0.1"))
+
+		Expect(requestor.reqs[1].URL.Path).To(Equal("/wapi/v2.11/request"))
+		Expect(requestor.body[1]).To(MatchJSON(`[
+			{"method": "POST", "object": "network",
+				"data": {"network_view": "default", "network": "func:nextavailablenetwork:10.0.0.0/16,default,24", "template": "web-tier"},
+				"args": {"_return_fields": "extattrs,network,network_view"}, "assign_state": {"NETWORK": "network"}},
+			{"method": "POST", "object": "record:host",
+				"data": {"name": "web.example.com", "configure_for_dns": false, "network_view": "default",
+					"ipv4addrs": [{"ipv4addr": "func:nextavailableip:##STATE:NETWORK:##,default", "mac": "00:00:00:00:00:01"}],
+					"extattrs": {"Site": {"value": "east"}}},
+				"args": {"_return_fields": "extattrs,ipv4addrs,name,view,zone"}, "enable_substitution": true}]`))
+	})
+
+	It("should only provision IPv4 networks", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, _, err := objMgr.ProvisionSubnet("ipv6networkcontainer/ZG5zLm5ldHdvcmtfY29udGFpbmVyJDEx:2001%3Adb8%3A%3A/48/default", 64, "", nil)
+		Expect(err).NotTo(BeNil())
+		Expect(conn.getObjs).To(BeEmpty())
+	})
+
+	It("should fail without panicking when the connector cannot send multi requests", func() {
+		conn := &fakeMultiConnector{getResults: map[string]interface{}{
+			containerRef: NetworkContainer{Ref: containerRef, Cidr: "10.0.0.0/16", NetviewName: "default"}}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		_, _, err := objMgr.ProvisionSubnet(containerRef, 24, "web-tier", nil)
+		Expect(errors.Is(err, ErrUnsupportedConnector)).To(BeTrue())
+	})
+})
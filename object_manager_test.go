@@ -1,8 +1,11 @@
 package ibclient
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -58,6 +61,10 @@ func (c *fakeConnector) GetObject(obj IBObject, ref string, res interface{}) (er
 			*res.(*[]License) = c.resultObject.([]License)
 		case *HostRecord:
 			*res.(*[]HostRecord) = c.resultObject.([]HostRecord)
+		case *HostRecordIpv4Addr:
+			*res.(*[]HostRecordIpv4Addr) = c.resultObject.([]HostRecordIpv4Addr)
+		case *IPv4Address:
+			*res.(*[]IPv4Address) = c.resultObject.([]IPv4Address)
 		}
 	} else {
 		switch obj.(type) {
@@ -83,6 +90,64 @@ func (c *fakeConnector) UpdateObject(obj IBObject, ref string) (string, error) {
 	return c.fakeRefReturn, nil
 }
 
+// fakeMultiConnector serves several GetObject calls by object type or ref
+// and records the objects passed to the write methods
+type fakeMultiConnector struct {
+	getResults map[string]interface{}
+	getObjs    []IBObject
+
+	createRefs []string
+	createObjs []IBObject
+
+	updateObjs []IBObject
+	updateRefs []string
+
+	deleteRefs []string
+}
+
+func (c *fakeMultiConnector) CreateObject(obj IBObject) (string, error) {
+	c.createObjs = append(c.createObjs, obj)
+	ref := fmt.Sprintf("%s/ZG5zLmZha2U:%d", obj.ObjectType(), len(c.createObjs))
+	if len(c.createRefs) > 0 {
+		ref = c.createRefs[0]
+		c.createRefs = c.createRefs[1:]
+	}
+
+	return ref, nil
+}
+
+func (c *fakeMultiConnector) GetObject(obj IBObject, ref string, res interface{}) error {
+	c.getObjs = append(c.getObjs, obj)
+	key := ref
+	if key == "" {
+		key = obj.ObjectType()
+	}
+
+	result, ok := c.getResults[key]
+	if !ok {
+		return nil
+	}
+	js, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(js, res)
+}
+
+func (c *fakeMultiConnector) DeleteObject(ref string) (string, error) {
+	c.deleteRefs = append(c.deleteRefs, ref)
+
+	return ref, nil
+}
+
+func (c *fakeMultiConnector) UpdateObject(obj IBObject, ref string) (string, error) {
+	c.updateObjs = append(c.updateObjs, obj)
+	c.updateRefs = append(c.updateRefs, ref)
+
+	return ref, nil
+}
+
 var _ = Describe("Object Manager", func() {
 
 	Describe("Create Network View", func() {
@@ -1082,6 +1147,142 @@ var _ = Describe("Object Manager", func() {
 		})
 	})
 
+	Describe("Fixed Address by client identifier", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		clientID := "01:aa:bb:cc:dd:ee:ff"
+		fixedAddrRef := "fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3MkMTAuMC4wLjUuMC4u:10.0.0.5/default"
+
+		It("should reserve the next available address for the client identifier", func() {
+			conn := &fakeMultiConnector{createRefs: []string{fixedAddrRef}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			fixedAddr, err := objMgr.AllocateIPByClientID("default", "10.0.0.0/24", "", clientID, "printer", "", "")
+			Expect(err).To(BeNil())
+			Expect(fixedAddr.IPAddress).To(Equal("10.0.0.5"))
+
+			created := conn.createObjs[0].(*FixedAddress)
+			Expect(created.MatchClient).To(Equal("CLIENT_ID"))
+			Expect(created.ClientID).To(Equal(clientID))
+			Expect(created.Mac).To(BeEmpty())
+		})
+
+		It("should require a client identifier", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.AllocateIPByClientID("default", "10.0.0.0/24", "", "", "printer", "", "")
+			Expect(err).NotTo(BeNil())
+			_, err = objMgr.UpdateFixedAddressClientID(fixedAddrRef, "", "", "")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.createObjs).To(BeEmpty())
+			Expect(conn.updateObjs).To(BeEmpty())
+		})
+
+		It("should switch the fixed address to match the client identifier", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			fixedAddr, err := objMgr.UpdateFixedAddressClientID(fixedAddrRef, clientID, "", "")
+			Expect(err).To(BeNil())
+			Expect(fixedAddr.Ref).To(Equal(fixedAddrRef))
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"_ref": "` + fixedAddrRef + `", "match_client": "CLIENT_ID", "dhcp_client_identifier": "` + clientID + `"}`))
+		})
+
+		It("should search the fixed address by client identifier", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"fixedaddress": []FixedAddress{{Ref: fixedAddrRef, IPAddress: "10.0.0.5", ClientID: clientID}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			fixedAddr, err := objMgr.GetFixedAddressByClientID("default", clientID)
+			Expect(err).To(BeNil())
+			Expect(fixedAddr.IPAddress).To(Equal("10.0.0.5"))
+			Expect(conn.getObjs[0].(*FixedAddress).ClientID).To(Equal(clientID))
+			Expect(conn.getObjs[0].ReturnFields()).To(ContainElement("dhcp_client_identifier"))
+		})
+	})
+
+	Describe("Get Discovered Data", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		netviewName := "private"
+		ipAddr := "53.0.0.21"
+		fakeRefReturn := fmt.Sprintf("ipv4address/Li5pcHY0X2FkZHJlc3MkNTMuMC4wLjIxLzA:%s/private", ipAddr)
+		discoveredData := &DiscoveredData{
+			Os:                       "Linux 3.x",
+			NetBIOSName:              "WEB01",
+			LastDiscovered:           1565913600,
+			NetworkComponentName:     "sw-core-01",
+			NetworkComponentPortName: "GigabitEthernet1/0/12",
+		}
+
+		ipFakeConnector := &fakeConnector{
+			getObjectObj: NewIPv4Address(IPv4Address{
+				NetviewName: netviewName,
+				IPAddress:   ipAddr,
+			}),
+			getObjectRef: "",
+			resultObject: []IPv4Address{*NewIPv4Address(IPv4Address{
+				NetviewName:    netviewName,
+				IPAddress:      ipAddr,
+				Ref:            fakeRefReturn,
+				Status:         "USED",
+				DiscoveredData: discoveredData,
+			})},
+			fakeRefReturn: fakeRefReturn,
+		}
+
+		objMgr := NewObjectManager(ipFakeConnector, cmpType, tenantID)
+
+		var actualData *DiscoveredData
+		var err error
+		It("should pass expected IPv4Address Object to GetObject", func() {
+			actualData, err = objMgr.GetDiscoveredData(netviewName, ipAddr)
+		})
+		It("should return expected Discovered Data", func() {
+			Expect(actualData).To(Equal(discoveredData))
+			Expect(err).To(BeNil())
+		})
+	})
+
+	Describe("Get Host Record Ipv4Addr", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		ipAddr := "53.0.0.21"
+		fakeRefReturn := fmt.Sprintf("record:host_ipv4addr/ZG5zLmhvc3RfYWRkcmVzcyQuX2RlZmF1bHQuY29tLnRlc3QuNTMuMC4wLjIxLg:%s/test.com/default", ipAddr)
+		getObjectObj := NewHostRecordIpv4Addr(HostRecordIpv4Addr{Ipv4Addr: ipAddr})
+		getObjectObj.returnFields = []string{"discovered_data", "host", "ipv4addr", "mac", "network"}
+
+		hostFakeConnector := &fakeConnector{
+			getObjectObj: getObjectObj,
+			getObjectRef: "",
+			resultObject: []HostRecordIpv4Addr{*NewHostRecordIpv4Addr(HostRecordIpv4Addr{
+				Ipv4Addr:       ipAddr,
+				Ref:            fakeRefReturn,
+				Host:           "test.com",
+				DiscoveredData: &DiscoveredData{Os: "Windows Server 2016"},
+			})},
+			fakeRefReturn: fakeRefReturn,
+		}
+
+		objMgr := NewObjectManager(hostFakeConnector, cmpType, tenantID)
+
+		var actualAddr *HostRecordIpv4Addr
+		var err error
+		It("should pass expected Host Record Ipv4Addr Object to GetObject", func() {
+			actualAddr, err = objMgr.GetHostRecordIpv4Addr(ipAddr)
+		})
+		It("should return expected Host Record Ipv4Addr Object", func() {
+			Expect(*actualAddr).To(Equal(hostFakeConnector.resultObject.([]HostRecordIpv4Addr)[0]))
+			Expect(actualAddr.DiscoveredData.Os).To(Equal("Windows Server 2016"))
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Describe("Get Host Record Without DNS", func() {
 		cmpType := "Docker"
 		tenantID := "01234567890abcdef01234567890abcdef"
@@ -1184,6 +1385,99 @@ var _ = Describe("Object Manager", func() {
 		})
 	})
 
+	Describe("Delete Network With Policy", func() {
+		netviewName := "default_view"
+		cidr := "28.0.42.0/24"
+		networkRef := fmt.Sprintf("network/ZG5zLm5ldHdvcmskODkuMC4wLjAvMjQvMjU:%s/%s", cidr, netviewName)
+		fixedAddrRef := "fixedaddress/ZG5zLmJpbmRfY25h:28.0.42.5/default_view"
+		hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS50ZXN0:test.com/default"
+		rangeRef := "range/ZG5zLmRoY3BfcmFuZ2Uk:28.0.42.100/28.0.42.200/default_view"
+
+		newConnector := func() *fakeMultiConnector {
+			return &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"ipv4address": []IPv4Address{
+						{Objects: []string{networkRef}},
+						{Objects: []string{fixedAddrRef}},
+						{Objects: []string{hostRef}},
+						{Objects: []string{hostRef}},
+					},
+					"range": []map[string]string{{"_ref": rangeRef}},
+				},
+			}
+		}
+
+		It("should refuse to delete a network with children", func() {
+			conn := newConnector()
+			objMgr := NewObjectManager(conn, "Docker", "0123")
+			_, err := objMgr.DeleteNetworkWithPolicy(networkRef, netviewName, DeleteNetworkIfEmpty)
+			Expect(err).To(Equal(&NotEmptyError{Ref: networkRef, Children: []string{fixedAddrRef, hostRef, rangeRef}}))
+			Expect(err.(*NotEmptyError).Is(ErrNotEmpty)).To(BeTrue())
+			Expect(conn.deleteRefs).To(BeEmpty())
+		})
+
+		It("should delete children before the network on cascade", func() {
+			conn := newConnector()
+			objMgr := NewObjectManager(conn, "Docker", "0123")
+			ref, err := objMgr.DeleteNetworkWithPolicy(networkRef, netviewName, DeleteNetworkCascade)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(networkRef))
+			Expect(conn.deleteRefs).To(Equal([]string{fixedAddrRef, hostRef, rangeRef, networkRef}))
+		})
+
+		It("should delete an empty network", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, "Docker", "0123")
+			_, err := objMgr.DeleteNetworkWithPolicy(networkRef, netviewName, DeleteNetworkIfEmpty)
+			Expect(err).To(BeNil())
+			Expect(conn.deleteRefs).To(Equal([]string{networkRef}))
+		})
+
+		It("should refuse to reparent the children of a network", func() {
+			conn := newConnector()
+			objMgr := NewObjectManager(conn, "Docker", "0123")
+			_, err := objMgr.DeleteNetworkWithPolicy(networkRef, netviewName, DeleteNetworkReparent)
+			Expect(err).To(MatchError(fmt.Sprintf("cannot delete network '%s': the children of a network cannot be reparented", networkRef)))
+			Expect(conn.getObjs).To(BeEmpty())
+			Expect(conn.deleteRefs).To(BeEmpty())
+		})
+
+		It("should search the children of an IPv6 network", func() {
+			ipv6NetworkRef := "ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi82NC8w:2001%3Adb8%3A%3A/64/default_view"
+			ipv6FixedAddrRef := "ipv6fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:2001%3Adb8%3A%3A5/default_view"
+			ipv6RangeRef := "ipv6range/ZG5zLmRoY3BfcmFuZ2Uk:2001%3Adb8%3A%3A100/2001%3Adb8%3A%3A200/default_view"
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"ipv6address": []IPv6Address{{Objects: []string{ipv6NetworkRef, ipv6FixedAddrRef}}},
+					"ipv6range":   []map[string]string{{"_ref": ipv6RangeRef}},
+				},
+			}
+			objMgr := NewObjectManager(conn, "Docker", "0123")
+
+			_, err := objMgr.DeleteNetworkWithPolicy(ipv6NetworkRef, netviewName, DeleteNetworkIfEmpty)
+			Expect(err).To(Equal(&NotEmptyError{Ref: ipv6NetworkRef, Children: []string{ipv6FixedAddrRef, ipv6RangeRef}}))
+			Expect(conn.getObjs[0].(*IPv6Address).Network).To(Equal("2001:db8::/64"))
+		})
+
+		It("should page the search of the children", func() {
+			requestor := &pageRequestor{pages: [][]byte{
+				[]byte(`{"result":[{"objects":["` + fixedAddrRef + `"]}],"next_page_id":"p2"}`),
+				[]byte(`{"result":[{"objects":["` + hostRef + `"]}]}`),
+				[]byte(`{"result":[]}`),
+			}}
+			hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.5", Port: "443"}
+			wrb := &WapiRequestBuilder{}
+			wrb.Init(hostConfig)
+			objMgr := NewObjectManager(&Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}, "Docker", "0123")
+
+			children, err := objMgr.GetNetworkChildren(netviewName, cidr)
+			Expect(err).To(BeNil())
+			Expect(children).To(Equal([]string{fixedAddrRef, hostRef}))
+			Expect(requestor.reqs[0].URL.Query().Get("_paging")).To(Equal("1"))
+			Expect(requestor.reqs[1].URL.Query().Get("_page_id")).To(Equal("p2"))
+		})
+	})
+
 	Describe("Delete Fixed Address", func() {
 		cmpType := "Docker"
 		tenantID := "01234567890abcdef01234567890abcdef"
@@ -1321,6 +1615,32 @@ var _ = Describe("Object Manager", func() {
 		})
 	})
 
+	Describe("Set Device Interface Admin Status", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		intfRef := "discovery:deviceinterface/ZG5zLmRldmljZV9pbnRlcmZhY2UkMTI:Gi1%2F0%2F12/sw-core-01"
+
+		intfFakeConnector := &fakeConnector{
+			updateObjectObj: NewDiscoveryDeviceInterface(DiscoveryDeviceInterface{
+				PortConfigAdminStatus: &PortConfigAdminStatus{AdminStatus: "DOWN"},
+			}),
+			updateObjectRef: intfRef,
+			fakeRefReturn:   intfRef,
+		}
+
+		objMgr := NewObjectManager(intfFakeConnector, cmpType, tenantID)
+
+		It("should pass expected port config to UpdateObject", func() {
+			ref, err := objMgr.SetDeviceInterfaceAdminStatus(intfRef, "DOWN")
+			Expect(ref).To(Equal(intfRef))
+			Expect(err).To(BeNil())
+		})
+		It("should reject unknown admin status", func() {
+			_, err := objMgr.SetDeviceInterfaceAdminStatus(intfRef, "SHUTDOWN")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
 	Describe("BuildNetworkViewFromRef", func() {
 		netviewName := "default_view"
 		netviewRef := fmt.Sprintf("networkview/ZG5zLm5ldHdvcmtfdmlldyQyMw:%s/false", netviewName)
@@ -1346,6 +1666,23 @@ var _ = Describe("Object Manager", func() {
 		It("should failed if bad Network Ref is provided", func() {
 			Expect(BuildNetworkFromRef("network/ZG5zLm5ldHdvcmtfdmlldyQyMw")).To(BeNil())
 		})
+		It("should return expected IPv6 Network Object", func() {
+			ipv6NetworkRef := "ipv6network/ZG5zLm5ldHdvcmskMjAwMTpkYjg6Oi82NC8w:2001%3Adb8%3A%3A/64/test_view"
+			expectedIPv6Network := Network{Ref: ipv6NetworkRef, NetviewName: netviewName, Cidr: "2001:db8::/64"}
+			Expect(*BuildNetworkFromRef(ipv6NetworkRef)).To(Equal(expectedIPv6Network))
+		})
+	})
+
+	Describe("GetIPAddressFromRef", func() {
+		It("should return IPv4 address of fixed address", func() {
+			Expect(GetIPAddressFromRef("fixedaddress/ZG5zLmJpbmRfY25h:12.0.10.1/external")).To(Equal("12.0.10.1"))
+		})
+		It("should return IPv6 address of IPv6 fixed address", func() {
+			Expect(GetIPAddressFromRef("ipv6fixedaddress/ZG5zLmZpeGVkX2FkZHJlc3Mk:2001%3Adb8%3A%3A10/external")).To(Equal("2001:db8::10"))
+		})
+		It("should return empty string for other refs", func() {
+			Expect(GetIPAddressFromRef("record:host/ZG5zLmJpbmRfY25h:test.com/default")).To(Equal(""))
+		})
 	})
 
 	Describe("Get Capacity report", func() {
@@ -1378,6 +1715,30 @@ var _ = Describe("Object Manager", func() {
 		})
 	})
 
+	Describe("Get Capacity summary", func() {
+		fakeConnector := &fakeConnector{
+			getObjectObj: NewCapcityReport(CapacityReport{}),
+			getObjectRef: "",
+			resultObject: []CapacityReport{
+				*NewCapcityReport(CapacityReport{Name: "gm.example.com", MaxCapacity: 1000, TotalObjects: 300, PercentUsed: 30}),
+				*NewCapcityReport(CapacityReport{Name: "member1.example.com", MaxCapacity: 1000, TotalObjects: 700, PercentUsed: 70}),
+			},
+		}
+
+		objMgr := NewObjectManager(fakeConnector, "Heka", "0123")
+
+		It("should aggregate the reports of all members", func() {
+			summary, err := objMgr.GetCapacitySummary()
+			Expect(err).To(BeNil())
+			Expect(summary.Members).To(HaveLen(2))
+			Expect(summary.TotalObjects).To(Equal(1000))
+			Expect(summary.MaxCapacity).To(Equal(2000))
+			Expect(summary.PercentUsed).To(Equal(50))
+			Expect(summary.MaxPercentUsed).To(Equal(70))
+			Expect(summary.MaxPercentUsedBy).To(Equal("member1.example.com"))
+		})
+	})
+
 	Describe("Get upgrade status", func() {
 		cmpType := "Heka"
 		tenantID := "0123"
@@ -1506,4 +1867,300 @@ var _ = Describe("Object Manager", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Describe("GetDefaultDNSView", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+
+		It("should resolve the default DNS view of the network view once", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"networkview": []NetworkView{{
+						Name:               "prod",
+						AssociatedDnsViews: []string{"internal.prod", "default.prod"}}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			view, err := objMgr.GetDefaultDNSView("prod")
+			Expect(err).To(BeNil())
+			Expect(view).To(Equal("default.prod"))
+			Expect(conn.getObjs[0].(*NetworkView).Name).To(Equal("prod"))
+
+			view, err = objMgr.WithContext(context.Background()).GetDefaultDNSView("prod")
+			Expect(err).To(BeNil())
+			Expect(view).To(Equal("default.prod"))
+			Expect(len(conn.getObjs)).To(Equal(1))
+		})
+
+		It("should return an error for an unknown network view", func() {
+			objMgr := NewObjectManager(&fakeMultiConnector{}, cmpType, tenantID)
+
+			_, err := objMgr.GetDefaultDNSView("missing")
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Update DNS records", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+
+		// the ref is set on the object once UpdateObject returns
+		putBody := func(obj IBObject) string {
+			var body map[string]interface{}
+			js, _ := json.Marshal(obj)
+			json.Unmarshal(js, &body)
+			delete(body, "_ref")
+			js, _ = json.Marshal(body)
+			return string(js)
+		}
+		ttl := uint32(300)
+
+		It("should PUT the new address and TTL of the A record", func() {
+			ref := "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQuY29tLmV4YW1wbGUsdm0xLDEwLjAuMC4x:vm1.example.com/default"
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			recordA, err := objMgr.UpdateARecord(ref, "", "10.0.0.2", RecordOptions{Ttl: &ttl, Ea: EA{"VM Name": "vm1"}})
+			Expect(err).To(BeNil())
+			Expect(conn.updateRefs).To(Equal([]string{ref}))
+
+			Expect(putBody(conn.updateObjs[0])).To(MatchJSON(`{"ipv4addr": "10.0.0.2", "ttl": 300, "use_ttl": true, "extattrs": {"VM Name": {"value": "vm1"}}}`))
+			Expect(recordA.Ref).To(Equal(ref))
+		})
+
+		It("should PUT the new canonical name of the CNAME record", func() {
+			ref := "record:cname/ZG5zLmJpbmRfY25hbWUkLl9kZWZhdWx0LmNvbS5leGFtcGxlLndlYg:web.example.com/default"
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.UpdateCNAMERecord(ref, "lb2.example.com", "", RecordOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(putBody(conn.updateObjs[0])).To(MatchJSON(`{"canonical": "lb2.example.com"}`))
+		})
+
+		It("should PUT an IPv6 address on the PTR record", func() {
+			ref := "record:ptr/ZG5zLmJpbmRfcHRyJC5fZGVmYXVsdC5hcnBh:1.0.0.0.ip6.arpa/default"
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.UpdatePTRRecord(ref, "vm1.example.com", "2001:db8::1", RecordOptions{})
+			Expect(err).To(BeNil())
+
+			Expect(putBody(conn.updateObjs[0])).To(MatchJSON(`{"ptrdname": "vm1.example.com", "ipv6addr": "2001:db8::1"}`))
+		})
+
+		It("should PUT the new text of the TXT record", func() {
+			ref := "record:txt/ZG5zLmJpbmRfdHh0JC5fZGVmYXVsdC5jb20uZXhhbXBsZQ:example.com/default"
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.UpdateTXTRecord(ref, "", "v=spf1 -all", RecordOptions{Ttl: &ttl})
+			Expect(err).To(BeNil())
+
+			Expect(putBody(conn.updateObjs[0])).To(MatchJSON(`{"text": "v=spf1 -all", "ttl": 300, "use_ttl": true}`))
+		})
+	})
+
+	Describe("DNS record options", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+
+		It("should create an A record with TTL, comment and EAs", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			ttl := uint32(60)
+
+			recordA, err := objMgr.CreateARecordWithOptions("default", "default", "vm1.example.com", "", "10.0.0.1",
+				RecordOptions{Ttl: &ttl, Comment: "web frontend", Ea: EA{"Owner": "ops"}})
+			Expect(err).To(BeNil())
+			Expect(recordA.Ttl).To(Equal(ttl))
+			Expect(*recordA.UseTtl).To(BeTrue())
+
+			js, _ := json.Marshal(conn.createObjs[0])
+			Expect(js).To(MatchJSON(`{"_ref": "record:a/ZG5zLmZha2U:1", "ipv4addr": "10.0.0.1", "name": "vm1.example.com", "view": "default",
+				"ttl": 60, "use_ttl": true, "comment": "web frontend", "extattrs": {"Owner": {"value": "ops"}}}`))
+		})
+
+		It("should create a CNAME record without EAs by default", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			recordCNAME, err := objMgr.CreateCNAMERecordWithOptions("lb.example.com", "www.example.com", "default",
+				RecordOptions{Comment: "public alias"})
+			Expect(err).To(BeNil())
+			Expect(recordCNAME.Ea).To(BeNil())
+			Expect(recordCNAME.UseTtl).To(BeNil())
+			Expect(recordCNAME.Comment).To(Equal("public alias"))
+		})
+
+		It("should create a CNAME record with the cloud EAs as the other records", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			objMgr.OmitCloudAttrs = false
+
+			_, err := objMgr.CreateCNAMERecordWithOptions("lb.example.com", "www.example.com", "default",
+				RecordOptions{VmName: "lb1", Ea: EA{"Owner": "ops"}})
+			Expect(err).To(BeNil())
+
+			js, _ := json.Marshal(conn.createObjs[0].(*RecordCNAME).Ea)
+			Expect(js).To(MatchJSON(`{"CMP Type": {"value": "Docker"}, "Cloud API Owned": {"value": "True"},
+				"Tenant ID": {"value": "01234567890abcdef01234567890abcdef"}, "VM Name": {"value": "lb1"}, "Owner": {"value": "ops"}}`))
+		})
+
+		It("should revert an updated record to the zone TTL", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.UpdateHostRecordWithOptions("record:host/ZG5zLmhvc3Qk:vm1.example.com/default", "10.0.0.1", "",
+				RecordOptions{UseZoneTtl: true})
+			Expect(err).To(BeNil())
+
+			updated := conn.updateObjs[0].(*HostRecord)
+			Expect(*updated.UseTtl).To(BeFalse())
+			js, _ := json.Marshal(updated)
+			Expect(string(js)).To(ContainSubstring(`"use_ttl":false`))
+		})
+	})
+
+	Describe("RenameHostRecord", func() {
+		cmpType := "Docker"
+		tenantID := "01234567890abcdef01234567890abcdef"
+		hostRef := "record:host/ZG5zLmhvc3QkLl9kZWZhdWx0LmNvbS5leGFtcGxlLnZtMQ:vm1.example.com/default"
+		host := HostRecord{
+			Ref:       hostRef,
+			Name:      "vm1.example.com",
+			View:      "default",
+			Ipv4Addrs: []HostRecordIpv4Addr{{Ipv4Addr: "10.0.0.1"}},
+			Ea:        EA{"Owner": "ops"},
+		}
+
+		It("should rename the host within an existing zone", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					hostRef:     host,
+					"zone_auth": []ZoneAuth{{Fqdn: "lab.example.com", View: "default"}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			recordHost, err := objMgr.RenameHostRecord(hostRef, "vm1.lab.example.com")
+			Expect(err).To(BeNil())
+			Expect(recordHost.Ipv4Addrs).To(Equal(host.Ipv4Addrs))
+
+			zoneSearch := conn.getObjs[1].(*ZoneAuth)
+			Expect(zoneSearch.Fqdn).To(Equal("lab.example.com"))
+			Expect(zoneSearch.View).To(Equal("default"))
+
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"name": "vm1.lab.example.com"}`))
+		})
+
+		It("should refuse a name outside of the zones of the view", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{hostRef: host},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			_, err := objMgr.RenameHostRecord(hostRef, "vm1.other.org")
+			Expect(err).NotTo(BeNil())
+			Expect(conn.updateObjs).To(BeEmpty())
+
+			var searched []string
+			for _, obj := range conn.getObjs[1:] {
+				searched = append(searched, obj.(*ZoneAuth).Fqdn)
+			}
+			Expect(searched).To(Equal([]string{"other.org", "org"}))
+		})
+	})
+
+	Describe("SearchObjects", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"record:host": []map[string]interface{}{
+					{"_ref": "record:host/ZG5zLmhvc3Qk:web1.example.com/default", "name": "web1.example.com"},
+				},
+			},
+		}
+		objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+		It("should return the matching objects as maps", func() {
+			res, err := objMgr.SearchObjects("record:host", map[string]string{"zone": "example.com"}, EA{"Owner": "teamA"}, []string{"extattrs"})
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal([]map[string]interface{}{
+				{"_ref": "record:host/ZG5zLmhvc3Qk:web1.example.com/default", "name": "web1.example.com"},
+			}))
+
+			search := conn.getObjs[0].(*SearchObject)
+			Expect(search.Filters).To(Equal(map[string]interface{}{"zone": "example.com"}))
+			Expect(search.EaSearch()).To(Equal(EASearch{"Owner": "teamA"}))
+		})
+	})
+
+	Describe("ListModifiedSince", func() {
+		since := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		It("should search DNS records by creation time", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"record:a": []map[string]interface{}{{"name": "vm1.example.com", "creation_time": 1546300900}},
+				},
+			}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			res, err := objMgr.ListModifiedSince("record:a", since)
+			Expect(err).To(BeNil())
+			Expect(len(res)).To(Equal(1))
+
+			search := conn.getObjs[0].(*SearchObject)
+			Expect(search.Filters).To(Equal(map[string]interface{}{"creation_time>": since.Unix()}))
+			Expect(search.ReturnFields()).To(Equal([]string{"creation_time"}))
+		})
+
+		It("should reject object types without timestamps", func() {
+			conn := &fakeMultiConnector{}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			_, err := objMgr.ListModifiedSince("network", since)
+			Expect(err).NotTo(BeNil())
+			Expect(conn.getObjs).To(BeEmpty())
+		})
+	})
+
+	Describe("ListChangedSinceSequence", func() {
+		It("should list the objects changed after the sequence id", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					"db_objects": []DBObject{
+						{LastSequenceID: "41", Object: "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:vm1.example.com/default", ObjectType: "record:a"},
+						{LastSequenceID: "45", Object: "record:a/ZG5zLmJpbmRfYSQuX2RlZmF1bHQ:vm2.example.com/default", ObjectType: "record:a"},
+					},
+				},
+			}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			res, last, err := objMgr.ListChangedSinceSequence("record:a", "40")
+			Expect(err).To(BeNil())
+			Expect(len(res)).To(Equal(2))
+			Expect(last).To(Equal("45"))
+
+			search := conn.getObjs[0].(*SearchObject)
+			Expect(search.Filters).To(Equal(map[string]interface{}{"object_types": "record:a", "start_sequence_id": "40"}))
+			Expect(search.ReturnFields()).To(Equal([]string{"last_sequence_id", "object", "object_type", "unique_id"}))
+		})
+
+		It("should keep the sequence id when nothing changed", func() {
+			conn := &fakeMultiConnector{getResults: map[string]interface{}{"db_objects": []DBObject{}}}
+			objMgr := NewObjectManager(conn, "Docker", "01234567890abcdef01234567890abcdef")
+
+			res, last, err := objMgr.ListChangedSinceSequence("", "45")
+			Expect(err).To(BeNil())
+			Expect(res).To(BeEmpty())
+			Expect(last).To(Equal("45"))
+
+			search := conn.getObjs[0].(*SearchObject)
+			Expect(search.Filters).To(Equal(map[string]interface{}{"start_sequence_id": "45"}))
+		})
+	})
 })
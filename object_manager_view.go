@@ -0,0 +1,112 @@
+package ibclient
+
+// viewEA merges the user supplied EAs of a DNS view or an NS group over the
+// cloud EAs, which mark them as not owned by the cloud API like network
+// views
+func (objMgr *ObjectManager) viewEA(ea EA) EA {
+	res := objMgr.getBasicEA(false)
+	for k, v := range ea {
+		res[k] = v
+	}
+
+	return res
+}
+
+// CreateView creates a DNS view in the network view v.NetworkView, the
+// default network view if empty
+func (objMgr *ObjectManager) CreateView(v View) (*View, error) {
+	view := NewView(v)
+	view.Ea = objMgr.viewEA(v.Ea)
+
+	ref, err := objMgr.createObject(view)
+	view.Ref = ref
+
+	return view, err
+}
+
+func (objMgr *ObjectManager) GetViewByRef(ref string) (*View, error) {
+	view := NewView(View{})
+	err := objMgr.getObject(view, ref, &view)
+	return view, err
+}
+
+func (objMgr *ObjectManager) GetViewByName(name string) (*View, error) {
+	var res []View
+
+	view := NewView(View{Name: name})
+	err := objMgr.getObject(view, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateView updates the DNS view referenced by ref, empty values and lists
+// leave the view unchanged. The network view of a DNS view cannot be
+// changed and is ignored.
+func (objMgr *ObjectManager) UpdateView(ref string, v View) (*View, error) {
+	view := NewView(v)
+	view.Ref = ""
+	view.NetworkView = ""
+
+	newRef, err := objMgr.updateObject(view, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetViewByRef(newRef)
+}
+
+// DeleteView deletes the DNS view referenced by ref along with its zones
+func (objMgr *ObjectManager) DeleteView(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateNSGroup creates an NS group served by the grid members in
+// GridPrimary and GridSecondaries and by the external servers
+func (objMgr *ObjectManager) CreateNSGroup(ns NSGroup) (*NSGroup, error) {
+	nsGroup := NewNSGroup(ns)
+	nsGroup.Ea = objMgr.viewEA(ns.Ea)
+
+	ref, err := objMgr.createObject(nsGroup)
+	nsGroup.Ref = ref
+
+	return nsGroup, err
+}
+
+func (objMgr *ObjectManager) GetNSGroupByRef(ref string) (*NSGroup, error) {
+	nsGroup := NewNSGroup(NSGroup{})
+	err := objMgr.getObject(nsGroup, ref, &nsGroup)
+	return nsGroup, err
+}
+
+func (objMgr *ObjectManager) GetNSGroupByName(name string) (*NSGroup, error) {
+	var res []NSGroup
+
+	nsGroup := NewNSGroup(NSGroup{Name: name})
+	err := objMgr.getObject(nsGroup, "", &res)
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateNSGroup updates the NS group referenced by ref, empty values and
+// lists leave the group unchanged
+func (objMgr *ObjectManager) UpdateNSGroup(ref string, ns NSGroup) (*NSGroup, error) {
+	nsGroup := NewNSGroup(ns)
+	nsGroup.Ref = ""
+
+	newRef, err := objMgr.updateObject(nsGroup, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetNSGroupByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteNSGroup(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
@@ -0,0 +1,80 @@
+package ibclient
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager DNS Views", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	viewRef := "view/ZG5zLnZpZXckLjE:tenant1/false"
+	nsGroupRef := "nsgroup/ZG5zLm5zX2dyb3VwJHRlbmFudHM:tenants"
+
+	Describe("Create View", func() {
+		It("should create the view with its ACLs", func() {
+			conn := &fakeMultiConnector{createRefs: []string{viewRef}}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+			objMgr.OmitCloudAttrs = false
+
+			view, err := objMgr.CreateView(View{
+				Name:         "tenant1",
+				NetworkView:  "tenant1",
+				MatchClients: []AddressAC{{Address: "10.1.0.0/16", Permission: "ALLOW"}},
+				Ea:           EA{"Tenant Name": "acme"}})
+			Expect(err).To(BeNil())
+			Expect(view.Ref).To(Equal(viewRef))
+			js, _ := json.Marshal(conn.createObjs[0])
+			Expect(js).To(MatchJSON(`{"_ref": "` + viewRef + `", "name": "tenant1", "network_view": "tenant1",
+				"match_clients": [{"address": "10.1.0.0/16", "permission": "ALLOW"}],
+				"extattrs": {"Cloud API Owned": {"value": "False"}, "CMP Type": {"value": "Docker"},
+					"Tenant ID": {"value": "01234567890abcdef01234567890abcdef"}, "Tenant Name": {"value": "acme"}}}`))
+		})
+	})
+
+	Describe("Update View", func() {
+		It("should not send the network view", func() {
+			conn := &fakeMultiConnector{
+				getResults: map[string]interface{}{
+					viewRef: View{Ref: viewRef, Name: "tenant1", NetworkView: "tenant1",
+						MatchDestinations: []AddressAC{{Address: "10.1.0.53", Permission: "ALLOW"}}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			view, err := objMgr.UpdateView(viewRef, View{NetworkView: "default",
+				MatchDestinations: []AddressAC{{Address: "10.1.0.53", Permission: "ALLOW"}}})
+			Expect(err).To(BeNil())
+			Expect(view.NetworkView).To(Equal("tenant1"))
+			js, _ := json.Marshal(conn.updateObjs[0])
+			Expect(js).To(MatchJSON(`{"match_destinations": [{"address": "10.1.0.53", "permission": "ALLOW"}]}`))
+		})
+	})
+
+	Describe("NS Groups", func() {
+		It("should create and get NS groups of grid members", func() {
+			conn := &fakeMultiConnector{
+				createRefs: []string{nsGroupRef},
+				getResults: map[string]interface{}{
+					"nsgroup": []NSGroup{{Ref: nsGroupRef, Name: "tenants",
+						GridPrimary: []MemberServer{{Name: "ns1.example.com"}}}},
+				},
+			}
+			objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+			nsGroup, err := objMgr.CreateNSGroup(NSGroup{Name: "tenants",
+				GridPrimary:     []MemberServer{{Name: "ns1.example.com"}},
+				GridSecondaries: []MemberServer{{Name: "ns2.example.com", GridReplicate: true}}})
+			Expect(err).To(BeNil())
+			Expect(nsGroup.Ref).To(Equal(nsGroupRef))
+
+			nsGroup, err = objMgr.GetNSGroupByName("tenants")
+			Expect(err).To(BeNil())
+			Expect(nsGroup.GridPrimary).To(Equal([]MemberServer{{Name: "ns1.example.com"}}))
+			js, _ := json.Marshal(conn.getObjs[0])
+			Expect(js).To(MatchJSON(`{"name": "tenants"}`))
+		})
+	})
+})
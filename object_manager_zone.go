@@ -0,0 +1,176 @@
+package ibclient
+
+var zoneAuthReturnFields = []string{"comment", "extattrs", "fqdn", "grid_primary",
+	"grid_secondaries", "ns_group", "view", "zone_format"}
+
+// zoneEA merges the user supplied EAs of a zone over the cloud EAs
+func (objMgr *ObjectManager) zoneEA(ea EA) EA {
+	res := objMgr.getBasicEA(true)
+	for k, v := range ea {
+		res[k] = v
+	}
+
+	return res
+}
+
+// CreateZoneAuth creates an authoritative zone. The zone is served either by
+// the grid members in GridPrimary and GridSecondaries or by NsGroup.
+func (objMgr *ObjectManager) CreateZoneAuth(za ZoneAuth) (*ZoneAuth, error) {
+	zone := NewZoneAuth(za)
+	zone.returnFields = zoneAuthReturnFields
+	zone.Ea = objMgr.zoneEA(za.Ea)
+
+	ref, err := objMgr.createObject(zone)
+	zone.Ref = ref
+
+	return zone, err
+}
+
+func (objMgr *ObjectManager) GetZoneAuthByRef(ref string) (*ZoneAuth, error) {
+	zone := NewZoneAuth(ZoneAuth{})
+	zone.returnFields = zoneAuthReturnFields
+	err := objMgr.getObject(zone, ref, &zone)
+	return zone, err
+}
+
+func (objMgr *ObjectManager) GetZoneAuthByFQDN(fqdn string, dnsview string) (*ZoneAuth, error) {
+	var res []ZoneAuth
+
+	zone := NewZoneAuth(ZoneAuth{Fqdn: fqdn, View: dnsview})
+	zone.returnFields = zoneAuthReturnFields
+
+	err := objMgr.getObject(zone, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateZoneAuth updates the zone referenced by ref. FQDN, View and
+// ZoneFormat cannot be changed and are ignored.
+func (objMgr *ObjectManager) UpdateZoneAuth(ref string, za ZoneAuth) (*ZoneAuth, error) {
+	zone := NewZoneAuth(za)
+	zone.Ref = ""
+	zone.Fqdn = ""
+	zone.View = ""
+	zone.ZoneFormat = ""
+
+	newRef, err := objMgr.updateObject(zone, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetZoneAuthByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteZoneAuth(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateZoneForward creates a forward zone sending queries to ForwardTo,
+// or to the per member settings in ForwardingServers
+func (objMgr *ObjectManager) CreateZoneForward(zf ZoneForward) (*ZoneForward, error) {
+	zone := NewZoneForward(zf)
+	zone.Ea = objMgr.zoneEA(zf.Ea)
+
+	ref, err := objMgr.createObject(zone)
+	zone.Ref = ref
+
+	return zone, err
+}
+
+func (objMgr *ObjectManager) GetZoneForwardByRef(ref string) (*ZoneForward, error) {
+	zone := NewZoneForward(ZoneForward{})
+	err := objMgr.getObject(zone, ref, &zone)
+	return zone, err
+}
+
+func (objMgr *ObjectManager) GetZoneForwardByFQDN(fqdn string, dnsview string) (*ZoneForward, error) {
+	var res []ZoneForward
+
+	zone := NewZoneForward(ZoneForward{Fqdn: fqdn, View: dnsview})
+
+	err := objMgr.getObject(zone, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateZoneForward updates the zone referenced by ref. FQDN, View and
+// ZoneFormat cannot be changed and are ignored.
+func (objMgr *ObjectManager) UpdateZoneForward(ref string, zf ZoneForward) (*ZoneForward, error) {
+	zone := NewZoneForward(zf)
+	zone.Ref = ""
+	zone.Fqdn = ""
+	zone.View = ""
+	zone.ZoneFormat = ""
+
+	newRef, err := objMgr.updateObject(zone, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetZoneForwardByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteZoneForward(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
+
+// CreateZoneDelegated creates a delegation of a subzone to the name
+// servers in DelegateTo, or to NsGroup
+func (objMgr *ObjectManager) CreateZoneDelegated(zd ZoneDelegated) (*ZoneDelegated, error) {
+	zone := NewZoneDelegated(zd)
+	zone.Ea = objMgr.zoneEA(zd.Ea)
+
+	ref, err := objMgr.createObject(zone)
+	zone.Ref = ref
+
+	return zone, err
+}
+
+func (objMgr *ObjectManager) GetZoneDelegatedByRef(ref string) (*ZoneDelegated, error) {
+	zone := NewZoneDelegated(ZoneDelegated{})
+	err := objMgr.getObject(zone, ref, &zone)
+	return zone, err
+}
+
+func (objMgr *ObjectManager) GetZoneDelegatedByFQDN(fqdn string, dnsview string) (*ZoneDelegated, error) {
+	var res []ZoneDelegated
+
+	zone := NewZoneDelegated(ZoneDelegated{Fqdn: fqdn, View: dnsview})
+
+	err := objMgr.getObject(zone, "", &res)
+
+	if err != nil || res == nil || len(res) == 0 {
+		return nil, err
+	}
+
+	return &res[0], nil
+}
+
+// UpdateZoneDelegated updates the zone referenced by ref. FQDN, View and
+// ZoneFormat cannot be changed and are ignored.
+func (objMgr *ObjectManager) UpdateZoneDelegated(ref string, zd ZoneDelegated) (*ZoneDelegated, error) {
+	zone := NewZoneDelegated(zd)
+	zone.Ref = ""
+	zone.Fqdn = ""
+	zone.View = ""
+	zone.ZoneFormat = ""
+
+	newRef, err := objMgr.updateObject(zone, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return objMgr.withDefaultReturnFields().GetZoneDelegatedByRef(newRef)
+}
+
+func (objMgr *ObjectManager) DeleteZoneDelegated(ref string) (string, error) {
+	return objMgr.deleteObject(ref)
+}
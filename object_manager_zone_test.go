@@ -0,0 +1,117 @@
+package ibclient
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object Manager Zones", func() {
+	cmpType := "Docker"
+	tenantID := "01234567890abcdef01234567890abcdef"
+	zoneRef := "zone_auth/ZG5zLnpvbmUkLl9kZWZhdWx0LmNvbS5leGFtcGxl:example.com/default"
+
+	Describe("Create Zone Auth", func() {
+		conn := &fakeMultiConnector{createRefs: []string{zoneRef}}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the zone with its grid primary and secondaries", func() {
+			zone, err := objMgr.CreateZoneAuth(ZoneAuth{
+				Fqdn:            "example.com",
+				View:            "default",
+				GridPrimary:     []MemberServer{{Name: "gm.example.com"}},
+				GridSecondaries: []MemberServer{{Name: "m1.example.com", GridReplicate: true}},
+				Ea:              EA{"Site": "lab"},
+			})
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal(zoneRef))
+
+			created := conn.createObjs[0].(*ZoneAuth)
+			Expect(created.ObjectType()).To(Equal("zone_auth"))
+			Expect(created.GridPrimary).To(Equal([]MemberServer{{Name: "gm.example.com"}}))
+			Expect(created.GridSecondaries[0].GridReplicate).To(BeTrue())
+			Expect(created.Ea["Site"]).To(Equal("lab"))
+		})
+	})
+
+	Describe("Get Zone Auth by FQDN", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				"zone_auth": []ZoneAuth{{Ref: zoneRef, Fqdn: "example.com", View: "default", NsGroup: "internal"}},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should search by fqdn and view and return the zone", func() {
+			zone, err := objMgr.GetZoneAuthByFQDN("example.com", "default")
+			Expect(err).To(BeNil())
+			Expect(zone.NsGroup).To(Equal("internal"))
+
+			search := conn.getObjs[0].(*ZoneAuth)
+			Expect(search.Fqdn).To(Equal("example.com"))
+			Expect(search.View).To(Equal("default"))
+			Expect(search.ReturnFields()).To(ContainElement("ns_group"))
+		})
+	})
+
+	Describe("Update Zone Auth", func() {
+		conn := &fakeMultiConnector{
+			getResults: map[string]interface{}{
+				zoneRef: ZoneAuth{Ref: zoneRef, Fqdn: "example.com", NsGroup: "external"},
+			},
+		}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should drop read-only fields and return the updated zone", func() {
+			zone, err := objMgr.UpdateZoneAuth(zoneRef, ZoneAuth{Fqdn: "example.com", View: "default", NsGroup: "external"})
+			Expect(err).To(BeNil())
+			Expect(zone.NsGroup).To(Equal("external"))
+
+			Expect(conn.updateRefs).To(Equal([]string{zoneRef}))
+			updated := conn.updateObjs[0].(*ZoneAuth)
+			Expect(updated.Fqdn).To(BeEmpty())
+			Expect(updated.View).To(BeEmpty())
+			Expect(updated.NsGroup).To(Equal("external"))
+		})
+	})
+
+	Describe("Create Zone Forward", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the zone with its forwarders", func() {
+			forwardTo := []NameServer{{Address: "10.0.0.53", Name: "ns1.corp.local"}}
+			zone, err := objMgr.CreateZoneForward(ZoneForward{
+				Fqdn:           "corp.local",
+				View:           "default",
+				ForwardTo:      forwardTo,
+				ForwardersOnly: true,
+			})
+			Expect(err).To(BeNil())
+			Expect(zone.Ref).To(Equal("zone_forward/ZG5zLmZha2U:1"))
+
+			created := conn.createObjs[0].(*ZoneForward)
+			Expect(created.ForwardTo).To(Equal(forwardTo))
+			Expect(created.ForwardersOnly).To(BeTrue())
+		})
+	})
+
+	Describe("Create and Delete Zone Delegated", func() {
+		conn := &fakeMultiConnector{}
+		objMgr := NewObjectManager(conn, cmpType, tenantID)
+
+		It("should create the delegation and delete it by ref", func() {
+			zone, err := objMgr.CreateZoneDelegated(ZoneDelegated{
+				Fqdn:    "sub.example.com",
+				View:    "default",
+				NsGroup: "delegation",
+			})
+			Expect(err).To(BeNil())
+			Expect(conn.createObjs[0].ObjectType()).To(Equal("zone_delegated"))
+
+			ref, err := objMgr.DeleteZoneDelegated(zone.Ref)
+			Expect(err).To(BeNil())
+			Expect(ref).To(Equal(zone.Ref))
+			Expect(conn.deleteRefs).To(Equal([]string{zone.Ref}))
+		})
+	})
+})
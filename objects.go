@@ -3,15 +3,55 @@ package ibclient
 import (
 	"bytes"
 	"encoding/json"
-	"reflect"
+	"fmt"
+	"time"
 )
 
 const MACADDR_ZERO = "00:00:00:00:00:00"
 
 type Bool bool
 
+// UnixTime is a WAPI timestamp, sent as seconds since the epoch
+type UnixTime struct {
+	time.Time
+}
+
 type EA map[string]interface{}
 
+// EAValue is an EA value with its inheritance. The values of an
+// EAInheritance inherited from a parent object hold the ref of the parent
+// in InheritanceSource. InheritanceOperation, "INHERIT", "OVERRIDE" or
+// "DELETE", is sent when updating an inheritable EA.
+type EAValue struct {
+	Value                interface{}
+	InheritanceSource    string
+	InheritanceOperation string
+}
+
+// EAInheritance holds the EAs of an object with their inheritance, while
+// EA only holds their values
+type EAInheritance map[string]EAValue
+
+// EAString returns a STRING, ENUM, EMAIL or URL EA value
+func EAString(s string) EAValue {
+	return EAValue{Value: s}
+}
+
+// EAInt returns an INTEGER EA value
+func EAInt(i int) EAValue {
+	return EAValue{Value: i}
+}
+
+// EADate returns a DATE EA value, sent in UTC as an RFC 3339 timestamp
+func EADate(t time.Time) EAValue {
+	return EAValue{Value: t}
+}
+
+// EAList returns the value of a multi-valued EA
+func EAList(values []interface{}) EAValue {
+	return EAValue{Value: values}
+}
+
 type EASearch map[string]interface{}
 
 type EADefListValue string
@@ -20,6 +60,9 @@ type IBBase struct {
 	objectType   string
 	returnFields []string
 	eaSearch     EASearch
+	// returnFieldsPlus requests returnFields in addition to the default
+	// fields of the object type
+	returnFieldsPlus bool
 }
 
 type IBObject interface {
@@ -41,11 +84,21 @@ func (obj *IBBase) EaSearch() EASearch {
 	return obj.eaSearch
 }
 
+func (obj *IBBase) addsReturnFields() bool {
+	return obj.returnFieldsPlus
+}
+
+func (obj *IBBase) setReturnFields(returnFields []string, plus bool) {
+	obj.returnFields = returnFields
+	obj.returnFieldsPlus = plus
+}
+
 type NetworkView struct {
-	IBBase `json:"-"`
-	Ref    string `json:"_ref,omitempty"`
-	Name   string `json:"name,omitempty"`
-	Ea     EA     `json:"extattrs,omitempty"`
+	IBBase             `json:"-"`
+	Ref                string   `json:"_ref,omitempty"`
+	Name               string   `json:"name,omitempty"`
+	AssociatedDnsViews []string `json:"associated_dns_views,omitempty"`
+	Ea                 EA       `json:"extattrs,omitempty"`
 }
 
 func NewNetworkView(nv NetworkView) *NetworkView {
@@ -63,6 +116,7 @@ type UpgradeStatus struct {
 	Type             string              `json:"type"`
 	SubElementStatus []SubElementsStatus `json:"subelements_status,omitempty"`
 	UpgradeGroup     string              `json:"upgrade_group,omitempty"`
+	CurrentVersion   string              `json:"current_version,omitempty"`
 }
 
 func NewUpgradeStatus(upgradeStatus UpgradeStatus) *UpgradeStatus {
@@ -89,10 +143,26 @@ type SubElementsStatus struct {
 
 type Network struct {
 	IBBase
-	Ref         string `json:"_ref,omitempty"`
-	NetviewName string `json:"network_view,omitempty"`
-	Cidr        string `json:"network,omitempty"`
-	Ea          EA     `json:"extattrs,omitempty"`
+	Ref         string       `json:"_ref,omitempty"`
+	NetviewName string       `json:"network_view,omitempty"`
+	Cidr        string       `json:"network,omitempty"`
+	Options     []DhcpOption `json:"options,omitempty"`
+	Comment     string       `json:"comment,omitempty"`
+	Parent      string       `json:"network_container,omitempty"`
+	Utilization uint32       `json:"utilization,omitempty"`
+	Template    string       `json:"template,omitempty"`
+	Ea          EA           `json:"extattrs,omitempty"`
+
+	// Members are the grid members serving DHCP for the network
+	Members []DhcpMember `json:"members,omitempty"`
+
+	// HighWaterMark and LowWaterMark are the utilization percentages of
+	// the network above and below which a trap is sent, the Reset
+	// percentages those at which the trap is cleared
+	HighWaterMark      uint32 `json:"high_water_mark,omitempty"`
+	HighWaterMarkReset uint32 `json:"high_water_mark_reset,omitempty"`
+	LowWaterMark       uint32 `json:"low_water_mark,omitempty"`
+	LowWaterMarkReset  uint32 `json:"low_water_mark_reset,omitempty"`
 }
 
 func NewNetwork(nw Network) *Network {
@@ -103,6 +173,14 @@ func NewNetwork(nw Network) *Network {
 	return &res
 }
 
+func NewIPv6Network(nw Network) *Network {
+	res := nw
+	res.objectType = "ipv6network"
+	res.returnFields = []string{"extattrs", "network", "network_view"}
+
+	return &res
+}
+
 type ServiceStatus struct {
 	Desciption string `json:"description,omitempty"`
 	Service    string `json:"service,omitempty"`
@@ -163,13 +241,16 @@ type NodeInfo struct {
 // Member represents NIOS member
 type Member struct {
 	IBBase                   `json:"-"`
-	Ref                      string     `json:"_ref,omitempty"`
-	HostName                 string     `json:"host_name,omitempty"`
-	ConfigAddrType           string     `json:"config_addr_type,omitempty"`
-	PLATFORM                 string     `json:"platform,omitempty"`
-	ServiceTypeConfiguration string     `json:"service_type_configuration,omitempty"`
-	Nodeinfo                 []NodeInfo `json:"node_info,omitempty"`
-	TimeZone                 string     `json:"time_zone,omitempty"`
+	Ref                      string            `json:"_ref,omitempty"`
+	HostName                 string            `json:"host_name,omitempty"`
+	ConfigAddrType           string            `json:"config_addr_type,omitempty"`
+	PLATFORM                 string            `json:"platform,omitempty"`
+	ServiceTypeConfiguration string            `json:"service_type_configuration,omitempty"`
+	Nodeinfo                 []NodeInfo        `json:"node_info,omitempty"`
+	TimeZone                 string            `json:"time_zone,omitempty"`
+	AdditionalIpList         []MemberInterface `json:"additional_ip_list,omitempty"`
+	BgpAs                    []BgpAs           `json:"bgp_as,omitempty"`
+	OspfList                 []Ospf            `json:"ospf_list,omitempty"`
 }
 
 func NewMember(member Member) *Member {
@@ -180,6 +261,167 @@ func NewMember(member Member) *Member {
 	return &res
 }
 
+// InterfaceIpv4Setting is the IPv4 address of a member interface
+type InterfaceIpv4Setting struct {
+	Address    string `json:"address"`
+	SubnetMask string `json:"subnet_mask,omitempty"`
+}
+
+// InterfaceIpv6Setting is the IPv6 address of a member interface
+type InterfaceIpv6Setting struct {
+	VirtualIp  string `json:"virtual_ip"`
+	CidrPrefix uint   `json:"cidr_prefix,omitempty"`
+}
+
+// MemberInterface is an additional address of a member, such as an
+// anycast loopback address advertised through BGP or OSPF
+type MemberInterface struct {
+	Anycast            bool                  `json:"anycast"`
+	Interface          string                `json:"interface,omitempty"`
+	EnableBgp          bool                  `json:"enable_bgp"`
+	EnableOspf         bool                  `json:"enable_ospf"`
+	Comment            string                `json:"comment,omitempty"`
+	Ipv4NetworkSetting *InterfaceIpv4Setting `json:"ipv4_network_setting,omitempty"`
+	Ipv6NetworkSetting *InterfaceIpv6Setting `json:"ipv6_network_setting,omitempty"`
+}
+
+// BgpNeighbor is a BGP peer of a member
+type BgpNeighbor struct {
+	NeighborIp         string `json:"neighbor_ip"`
+	RemoteAs           uint32 `json:"remote_as"`
+	AuthenticationMode string `json:"authentication_mode,omitempty"`
+	BgpNeighborPass    string `json:"bgp_neighbor_pass,omitempty"`
+	Multihop           bool   `json:"multihop,omitempty"`
+	MultihopTtl        uint   `json:"multihop_ttl,omitempty"`
+	Comment            string `json:"comment,omitempty"`
+}
+
+// BgpAs is the BGP configuration of a member for an autonomous system
+type BgpAs struct {
+	As         uint32        `json:"as"`
+	Holddown   uint          `json:"holddown,omitempty"`
+	Keepalive  uint          `json:"keepalive,omitempty"`
+	LinkDetect bool          `json:"link_detect,omitempty"`
+	Neighbors  []BgpNeighbor `json:"neighbors,omitempty"`
+}
+
+// Ospf is the OSPF configuration of a member for an area
+type Ospf struct {
+	AreaId              string `json:"area_id"`
+	AreaType            string `json:"area_type,omitempty"`
+	Interface           string `json:"interface,omitempty"`
+	IsIpv4              bool   `json:"is_ipv4"`
+	AuthenticationType  string `json:"authentication_type,omitempty"`
+	AuthenticationKey   string `json:"authentication_key,omitempty"`
+	KeyId               uint   `json:"key_id,omitempty"`
+	AutoCalcCostEnabled bool   `json:"auto_calc_cost_enabled"`
+	Cost                uint   `json:"cost,omitempty"`
+	HelloInterval       uint   `json:"hello_interval,omitempty"`
+	DeadInterval        uint   `json:"dead_interval,omitempty"`
+	RetransmitInterval  uint   `json:"retransmit_interval,omitempty"`
+	TransmitDelay       uint   `json:"transmit_delay,omitempty"`
+	Comment             string `json:"comment,omitempty"`
+}
+
+// MemberDns represents member:dns wapi object
+type MemberDns struct {
+	IBBase           `json:"-"`
+	Ref              string   `json:"_ref,omitempty"`
+	HostName         string   `json:"host_name,omitempty"`
+	Ipv4Addr         string   `json:"ipv4addr,omitempty"`
+	Ipv6Addr         string   `json:"ipv6addr,omitempty"`
+	AdditionalIpList []string `json:"additional_ip_list,omitempty"`
+	EnableDns        *bool    `json:"enable_dns,omitempty"`
+	// AllowRecursiveQuery is set on the members resolving recursively,
+	// whose DNS cache ClearRecursiveDnsCaches clears
+	AllowRecursiveQuery *bool `json:"allow_recursive_query,omitempty"`
+	DnsClientSubnet
+}
+
+func NewMemberDns(md MemberDns) *MemberDns {
+	res := md
+	res.objectType = "member:dns"
+	res.returnFields = []string{"additional_ip_list", "host_name", "ipv4addr", "ipv6addr"}
+
+	return &res
+}
+
+// ClientSubnetDomain is a domain whose queries carry, or do not carry as
+// given by Permission "ALLOW" or "DENY", the EDNS client subnet
+type ClientSubnetDomain struct {
+	Domain     string `json:"domain"`
+	Permission string `json:"permission"`
+}
+
+// DnsClientSubnet holds the EDNS client subnet (ECS) settings of the DNS
+// service of the grid or of a member: whether the subnet of the clients is
+// forwarded to, or sent in the recursive queries to, the authoritative
+// servers, and the length of the prefix sent
+type DnsClientSubnet struct {
+	EnableClientSubnetForwarding *bool                `json:"enable_client_subnet_forwarding,omitempty"`
+	EnableClientSubnetRecursive  *bool                `json:"enable_client_subnet_recursive,omitempty"`
+	ClientSubnetIpv4PrefixLength uint32               `json:"client_subnet_ipv4_prefix_length,omitempty"`
+	ClientSubnetIpv6PrefixLength uint32               `json:"client_subnet_ipv6_prefix_length,omitempty"`
+	ClientSubnetDomains          []ClientSubnetDomain `json:"client_subnet_domains,omitempty"`
+}
+
+var dnsClientSubnetFields = []string{"client_subnet_domains", "client_subnet_ipv4_prefix_length",
+	"client_subnet_ipv6_prefix_length", "enable_client_subnet_forwarding", "enable_client_subnet_recursive"}
+
+// GridDns represents grid:dns wapi object, the DNS properties of the grid
+type GridDns struct {
+	IBBase `json:"-"`
+	Ref    string `json:"_ref,omitempty"`
+	DnsClientSubnet
+}
+
+func NewGridDns(gd GridDns) *GridDns {
+	res := gd
+	res.objectType = "grid:dns"
+	res.returnFields = append([]string{}, dnsClientSubnetFields...)
+
+	return &res
+}
+
+// MaxMindDBInfo represents grid:maxminddbinfo wapi object, the MaxMind
+// geolocation database used by the DNS topology rules of the grid
+type MaxMindDBInfo struct {
+	IBBase             `json:"-"`
+	Ref                string    `json:"_ref,omitempty"`
+	TopologyType       string    `json:"topology_type,omitempty"`
+	BinaryMajorVersion uint32    `json:"binary_major_version,omitempty"`
+	BinaryMinorVersion uint32    `json:"binary_minor_version,omitempty"`
+	BuildTime          *UnixTime `json:"build_time,omitempty"`
+	DatabaseType       string    `json:"database_type,omitempty"`
+	DeploymentTime     *UnixTime `json:"deployment_time,omitempty"`
+	Member             string    `json:"member,omitempty"`
+}
+
+func NewMaxMindDBInfo(info MaxMindDBInfo) *MaxMindDBInfo {
+	res := info
+	res.objectType = "grid:maxminddbinfo"
+	res.returnFields = []string{"binary_major_version", "binary_minor_version", "build_time",
+		"database_type", "deployment_time", "member", "topology_type"}
+
+	return &res
+}
+
+// MemberDhcpProperties represents member:dhcpproperties wapi object
+type MemberDhcpProperties struct {
+	IBBase     `json:"-"`
+	Ref        string `json:"_ref,omitempty"`
+	HostName   string `json:"host_name,omitempty"`
+	EnableDhcp *bool  `json:"enable_dhcp,omitempty"`
+}
+
+func NewMemberDhcpProperties(mdp MemberDhcpProperties) *MemberDhcpProperties {
+	res := mdp
+	res.objectType = "member:dhcpproperties"
+	res.returnFields = []string{"enable_dhcp", "host_name"}
+
+	return &res
+}
+
 // License represents license wapi object
 type License struct {
 	IBBase           `json:"-"`
@@ -194,6 +436,28 @@ type License struct {
 	Licensetype      string `json:"type,omitempty"`
 }
 
+// CACertificate is a CA certificate uploaded to the grid, its validity is
+// in Unix time
+type CACertificate struct {
+	IBBase            `json:"-"`
+	Ref               string `json:"_ref,omitempty"`
+	DistinguishedName string `json:"distinguished_name,omitempty"`
+	Issuer            string `json:"issuer,omitempty"`
+	Serial            string `json:"serial,omitempty"`
+	ValidNotBefore    int64  `json:"valid_not_before,omitempty"`
+	ValidNotAfter     int64  `json:"valid_not_after,omitempty"`
+	UsedBy            string `json:"used_by,omitempty"`
+}
+
+func NewCACertificate(cert CACertificate) *CACertificate {
+	res := cert
+	res.objectType = "cacertificate"
+	res.returnFields = []string{"distinguished_name", "issuer", "serial", "used_by",
+		"valid_not_after", "valid_not_before"}
+
+	return &res
+}
+
 func NewGridLicense(license License) *License {
 	result := license
 	result.objectType = "license:gridwide"
@@ -236,7 +500,30 @@ type CapacityReport struct {
 	TotalObjects int                      `json:"total_objects,omitempty"`
 }
 
+// CapacitySummary aggregates the capacity reports of all grid members
+type CapacitySummary struct {
+	Members          []CapacityReport
+	TotalObjects     int
+	MaxCapacity      int
+	PercentUsed      int
+	MaxPercentUsed   int
+	MaxPercentUsedBy string
+}
+
+// NetworkViewSummary is a network view with the number of its networks and
+// network containers, and the percentage of the addresses of its IPv4
+// networks in use
+type NetworkViewSummary struct {
+	NetworkView
+	Networks              int
+	NetworkContainers     int
+	IPv6Networks          int
+	IPv6NetworkContainers int
+	Utilization           uint32
+}
+
 func NewCapcityReport(capReport CapacityReport) *CapacityReport {
+
 	res := capReport
 	returnFields := []string{"name", "hardware_type", "max_capacity", "object_counts", "percent_used", "role", "total_objects"}
 	res.objectType = "capacityreport"
@@ -266,6 +553,7 @@ type Grid struct {
 	Ref        string      `json:"_ref,omitempty"`
 	Name       string      `json:"name,omitempty"`
 	NTPSetting *NTPSetting `json:"ntp_setting,omitempty"`
+	TimeZone   string      `json:"time_zone,omitempty"`
 }
 
 func NewGrid(grid Grid) *Grid {
@@ -276,11 +564,40 @@ func NewGrid(grid Grid) *Grid {
 	return &result
 }
 
+// GridServiceRestartStatus holds the number of members per restart state
+// of their services
+type GridServiceRestartStatus struct {
+	IBBase         `json:"-"`
+	Ref            string `json:"_ref,omitempty"`
+	Parent         string `json:"parent,omitempty"`
+	Grouped        string `json:"grouped,omitempty"`
+	NeededRestart  int    `json:"needed_restart,omitempty"`
+	PendingRestart int    `json:"pending_restart,omitempty"`
+	Pending        int    `json:"pending,omitempty"`
+	Restarting     int    `json:"restarting,omitempty"`
+	Processing     int    `json:"processing,omitempty"`
+	Success        int    `json:"success,omitempty"`
+	Failures       int    `json:"failures,omitempty"`
+	Timeouts       int    `json:"timeouts,omitempty"`
+	NoRestart      int    `json:"no_restart,omitempty"`
+	Finished       int    `json:"finished,omitempty"`
+}
+
+func NewGridServiceRestartStatus(status GridServiceRestartStatus) *GridServiceRestartStatus {
+	result := status
+	result.objectType = "grid:servicerestart:status"
+	result.returnFields = []string{"parent", "grouped", "needed_restart", "pending_restart", "pending",
+		"restarting", "processing", "success", "failures", "timeouts", "no_restart", "finished"}
+	return &result
+}
+
 type NetworkContainer struct {
 	IBBase      `json:"-"`
 	Ref         string `json:"_ref,omitempty"`
 	NetviewName string `json:"network_view,omitempty"`
 	Cidr        string `json:"network,omitempty"`
+	Parent      string `json:"network_container,omitempty"`
+	Comment     string `json:"comment,omitempty"`
 	Ea          EA     `json:"extattrs,omitempty"`
 }
 
@@ -292,21 +609,45 @@ func NewNetworkContainer(nc NetworkContainer) *NetworkContainer {
 	return &res
 }
 
+func NewIPv6NetworkContainer(nc NetworkContainer) *NetworkContainer {
+	res := nc
+	res.objectType = "ipv6networkcontainer"
+	res.returnFields = []string{"extattrs", "network", "network_view"}
+
+	return &res
+}
+
 type FixedAddress struct {
 	IBBase      `json:"-"`
-	Ref         string `json:"_ref,omitempty"`
-	NetviewName string `json:"network_view,omitempty"`
-	Cidr        string `json:"network,omitempty"`
-	IPAddress   string `json:"ipv4addr,omitempty"`
-	Mac         string `json:"mac,omitempty"`
-	Name        string `json:"name,omitempty"`
-	MatchClient string `json:"match_client,omitempty"`
-	Ea          EA     `json:"extattrs,omitempty"`
+	Ref         string       `json:"_ref,omitempty"`
+	NetviewName string       `json:"network_view,omitempty"`
+	Cidr        string       `json:"network,omitempty"`
+	IPAddress   string       `json:"ipv4addr,omitempty"`
+	IPv6Address string       `json:"ipv6addr,omitempty"`
+	Mac         string       `json:"mac,omitempty"`
+	Duid        string       `json:"duid,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	MatchClient string       `json:"match_client,omitempty"`
+	ClientID    string       `json:"dhcp_client_identifier,omitempty"`
+	Options     []DhcpOption `json:"options,omitempty"`
+	Ea          EA           `json:"extattrs,omitempty"`
 }
 
 /*This is a general struct to add query params used in makeRequest*/
 type QueryParams struct {
 	forceProxy bool
+	// paging requests results in pages of maxResults objects, pageID
+	// selects the page following the first one
+	paging     bool
+	maxResults int
+	pageID     string
+	// returnFieldsPlus sends the return fields as _return_fields+
+	returnFieldsPlus bool
+	// function calls the WAPI function of the object with the POST body
+	// as its arguments
+	function string
+	// schema requests the schema of the object type instead of objects
+	schema bool
 }
 
 func NewFixedAddress(fixedAddr FixedAddress) *FixedAddress {
@@ -317,6 +658,14 @@ func NewFixedAddress(fixedAddr FixedAddress) *FixedAddress {
 	return &res
 }
 
+func NewIPv6FixedAddress(fixedAddr FixedAddress) *FixedAddress {
+	res := fixedAddr
+	res.objectType = "ipv6fixedaddress"
+	res.returnFields = []string{"duid", "extattrs", "ipv6addr", "name", "network", "network_view"}
+
+	return &res
+}
+
 type EADefinition struct {
 	IBBase             `json:"-"`
 	Ref                string           `json:"_ref,omitempty"`
@@ -337,9 +686,11 @@ func NewEADefinition(eadef EADefinition) *EADefinition {
 }
 
 type UserProfile struct {
-	IBBase `json:"-"`
-	Ref    string `json:"_ref,omitempty"`
-	Name   string `json:"name,omitempty"`
+	IBBase     `json:"-"`
+	Ref        string `json:"_ref,omitempty"`
+	Name       string `json:"name,omitempty"`
+	AdminGroup string `json:"admin_group,omitempty"`
+	UserType   string `json:"user_type,omitempty"`
 }
 
 func NewUserProfile(userprofile UserProfile) *UserProfile {
@@ -351,13 +702,17 @@ func NewUserProfile(userprofile UserProfile) *UserProfile {
 }
 
 type RecordA struct {
-	IBBase   `json:"-"`
-	Ref      string `json:"_ref,omitempty"`
-	Ipv4Addr string `json:"ipv4addr,omitempty"`
-	Name     string `json:"name,omitempty"`
-	View     string `json:"view,omitempty"`
-	Zone     string `json:"zone,omitempty"`
-	Ea       EA     `json:"extattrs,omitempty"`
+	IBBase       `json:"-"`
+	Ref          string    `json:"_ref,omitempty"`
+	Ipv4Addr     string    `json:"ipv4addr,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	View         string    `json:"view,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	Ttl          uint32    `json:"ttl,omitempty"`
+	UseTtl       *bool     `json:"use_ttl,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreationTime *UnixTime `json:"creation_time,omitempty"`
+	Ea           EA        `json:"extattrs,omitempty"`
 }
 
 func NewRecordA(ra RecordA) *RecordA {
@@ -368,15 +723,42 @@ func NewRecordA(ra RecordA) *RecordA {
 	return &res
 }
 
+type RecordAAAA struct {
+	IBBase       `json:"-"`
+	Ref          string    `json:"_ref,omitempty"`
+	Ipv6Addr     string    `json:"ipv6addr,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	View         string    `json:"view,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	Ttl          uint32    `json:"ttl,omitempty"`
+	UseTtl       *bool     `json:"use_ttl,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreationTime *UnixTime `json:"creation_time,omitempty"`
+	Ea           EA        `json:"extattrs,omitempty"`
+}
+
+func NewRecordAAAA(raaaa RecordAAAA) *RecordAAAA {
+	res := raaaa
+	res.objectType = "record:aaaa"
+	res.returnFields = []string{"extattrs", "ipv6addr", "name", "view", "zone"}
+
+	return &res
+}
+
 type RecordPTR struct {
-	IBBase   `json:"-"`
-	Ref      string `json:"_ref,omitempty"`
-	Ipv4Addr string `json:"ipv4addr,omitempty"`
-	Name     string `json:"name,omitempty"`
-	PtrdName string `json:"ptrdname,omitempty"`
-	View     string `json:"view,omitempty"`
-	Zone     string `json:"zone,omitempty"`
-	Ea       EA     `json:"extattrs,omitempty"`
+	IBBase       `json:"-"`
+	Ref          string    `json:"_ref,omitempty"`
+	Ipv4Addr     string    `json:"ipv4addr,omitempty"`
+	Ipv6Addr     string    `json:"ipv6addr,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	PtrdName     string    `json:"ptrdname,omitempty"`
+	View         string    `json:"view,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	Ttl          uint32    `json:"ttl,omitempty"`
+	UseTtl       *bool     `json:"use_ttl,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreationTime *UnixTime `json:"creation_time,omitempty"`
+	Ea           EA        `json:"extattrs,omitempty"`
 }
 
 func NewRecordPTR(rptr RecordPTR) *RecordPTR {
@@ -387,14 +769,26 @@ func NewRecordPTR(rptr RecordPTR) *RecordPTR {
 	return &res
 }
 
+func NewIPv6RecordPTR(rptr RecordPTR) *RecordPTR {
+	res := rptr
+	res.objectType = "record:ptr"
+	res.returnFields = []string{"extattrs", "ipv6addr", "ptrdname", "view", "zone"}
+
+	return &res
+}
+
 type RecordCNAME struct {
-	IBBase    `json:"-"`
-	Ref       string `json:"_ref,omitempty"`
-	Canonical string `json:"canonical,omitempty"`
-	Name      string `json:"name,omitempty"`
-	View      string `json:"view,omitempty"`
-	Zone      string `json:"zone,omitempty"`
-	Ea        EA     `json:"extattrs,omitempty"`
+	IBBase       `json:"-"`
+	Ref          string    `json:"_ref,omitempty"`
+	Canonical    string    `json:"canonical,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	View         string    `json:"view,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	Ttl          uint32    `json:"ttl,omitempty"`
+	UseTtl       *bool     `json:"use_ttl,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreationTime *UnixTime `json:"creation_time,omitempty"`
+	Ea           EA        `json:"extattrs,omitempty"`
 }
 
 func NewRecordCNAME(rc RecordCNAME) *RecordCNAME {
@@ -405,13 +799,120 @@ func NewRecordCNAME(rc RecordCNAME) *RecordCNAME {
 	return &res
 }
 
+// DiscoveredData holds the network discovery details NIOS collects for
+// an address
+type DiscoveredData struct {
+	Os                              string `json:"os,omitempty"`
+	NetBIOSName                     string `json:"netbios_name,omitempty"`
+	LastDiscovered                  int    `json:"last_discovered,omitempty"`
+	FirstDiscovered                 int    `json:"first_discovered,omitempty"`
+	MacAddress                      string `json:"mac_address,omitempty"`
+	DiscoveredName                  string `json:"discovered_name,omitempty"`
+	Discoverer                      string `json:"discoverer,omitempty"`
+	DeviceVendor                    string `json:"device_vendor,omitempty"`
+	DeviceModel                     string `json:"device_model,omitempty"`
+	NetworkComponentName            string `json:"network_component_name,omitempty"`
+	NetworkComponentIp              string `json:"network_component_ip,omitempty"`
+	NetworkComponentPortName        string `json:"network_component_port_name,omitempty"`
+	NetworkComponentPortNumber      int    `json:"network_component_port_number,omitempty"`
+	NetworkComponentPortDescription string `json:"network_component_port_description,omitempty"`
+	PortVlanName                    string `json:"port_vlan_name,omitempty"`
+	PortVlanNumber                  int    `json:"port_vlan_number,omitempty"`
+}
+
+// IPv4Address represents ipv4address wapi object
+type IPv4Address struct {
+	IBBase         `json:"-"`
+	Ref            string          `json:"_ref,omitempty"`
+	IPAddress      string          `json:"ip_address,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	Types          []string        `json:"types,omitempty"`
+	Names          []string        `json:"names,omitempty"`
+	Network        string          `json:"network,omitempty"`
+	NetviewName    string          `json:"network_view,omitempty"`
+	MacAddress     string          `json:"mac_address,omitempty"`
+	Objects        []string        `json:"objects,omitempty"`
+	Usage          []string        `json:"usage,omitempty"`
+	IsConflict     bool            `json:"is_conflict,omitempty"`
+	ConflictTypes  []string        `json:"conflict_types,omitempty"`
+	Ea             EA              `json:"extattrs,omitempty"`
+	DiscoveredData *DiscoveredData `json:"discovered_data,omitempty"`
+}
+
+func NewIPv4Address(addr IPv4Address) *IPv4Address {
+	res := addr
+	res.objectType = "ipv4address"
+	res.returnFields = []string{"discovered_data", "extattrs", "ip_address", "mac_address", "names",
+		"network", "network_view", "objects", "status", "types", "usage"}
+
+	return &res
+}
+
+// IPv6Address represents ipv6address wapi object
+type IPv6Address struct {
+	IBBase        `json:"-"`
+	Ref           string   `json:"_ref,omitempty"`
+	IPAddress     string   `json:"ip_address,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Types         []string `json:"types,omitempty"`
+	Names         []string `json:"names,omitempty"`
+	Network       string   `json:"network,omitempty"`
+	NetviewName   string   `json:"network_view,omitempty"`
+	Duid          string   `json:"duid,omitempty"`
+	Objects       []string `json:"objects,omitempty"`
+	Usage         []string `json:"usage,omitempty"`
+	IsConflict    bool     `json:"is_conflict,omitempty"`
+	ConflictTypes []string `json:"conflict_types,omitempty"`
+	Ea            EA       `json:"extattrs,omitempty"`
+}
+
+func NewIPv6Address(addr IPv6Address) *IPv6Address {
+	res := addr
+	res.objectType = "ipv6address"
+	res.returnFields = []string{"conflict_types", "duid", "extattrs", "ip_address", "is_conflict", "names",
+		"network", "network_view", "objects", "status", "types", "usage"}
+
+	return &res
+}
+
+// Lease represents a DHCP lease of an address, IPv4 or IPv6 as given by
+// Protocol, held by the client Hardware, or Duid, in the state
+// BindingState, e.g. "ACTIVE" or "FREE"
+type Lease struct {
+	IBBase         `json:"-"`
+	Ref            string    `json:"_ref,omitempty"`
+	Address        string    `json:"address,omitempty"`
+	Network        string    `json:"network,omitempty"`
+	NetviewName    string    `json:"network_view,omitempty"`
+	Hardware       string    `json:"hardware,omitempty"`
+	Duid           string    `json:"ipv6_duid,omitempty"`
+	ClientHostname string    `json:"client_hostname,omitempty"`
+	BindingState   string    `json:"binding_state,omitempty"`
+	Protocol       string    `json:"protocol,omitempty"`
+	ServedBy       string    `json:"served_by,omitempty"`
+	Starts         *UnixTime `json:"starts,omitempty"`
+	Ends           *UnixTime `json:"ends,omitempty"`
+}
+
+func NewLease(lease Lease) *Lease {
+	res := lease
+	res.objectType = "lease"
+	res.returnFields = []string{"address", "binding_state", "client_hostname", "ends", "hardware",
+		"ipv6_duid", "network", "network_view", "protocol", "served_by", "starts"}
+
+	return &res
+}
+
 type HostRecordIpv4Addr struct {
-	IBBase   `json:"-"`
-	Ipv4Addr string `json:"ipv4addr,omitempty"`
-	Ref      string `json:"_ref,omitempty"`
-	Mac      string `json:"mac,omitempty"`
-	View     string `json:"view,omitempty"`
-	Cidr     string `json:"network,omitempty"`
+	IBBase         `json:"-"`
+	Ipv4Addr       string          `json:"ipv4addr,omitempty"`
+	Ref            string          `json:"_ref,omitempty"`
+	Host           string          `json:"host,omitempty"`
+	Mac            string          `json:"mac,omitempty"`
+	View           string          `json:"view,omitempty"`
+	Cidr           string          `json:"network,omitempty"`
+	EnableDhcp     *bool           `json:"configure_for_dhcp,omitempty"`
+	DiscoveredData *DiscoveredData `json:"discovered_data,omitempty"`
 }
 
 func NewHostRecordIpv4Addr(hostAddr HostRecordIpv4Addr) *HostRecordIpv4Addr {
@@ -420,15 +921,36 @@ func NewHostRecordIpv4Addr(hostAddr HostRecordIpv4Addr) *HostRecordIpv4Addr {
 	return &res
 }
 
+type HostRecordIpv6Addr struct {
+	IBBase     `json:"-"`
+	Ipv6Addr   string `json:"ipv6addr,omitempty"`
+	Ref        string `json:"_ref,omitempty"`
+	Host       string `json:"host,omitempty"`
+	Duid       string `json:"duid,omitempty"`
+	EnableDhcp *bool  `json:"configure_for_dhcp,omitempty"`
+}
+
+func NewHostRecordIpv6Addr(hostAddr HostRecordIpv6Addr) *HostRecordIpv6Addr {
+	res := hostAddr
+	res.objectType = "record:host_ipv6addr"
+	return &res
+}
+
 type HostRecord struct {
 	IBBase      `json:"-"`
 	Ref         string               `json:"_ref,omitempty"`
 	Ipv4Addr    string               `json:"ipv4addr,omitempty"`
+	Ipv6Addr    string               `json:"ipv6addr,omitempty"`
 	Ipv4Addrs   []HostRecordIpv4Addr `json:"ipv4addrs,omitempty"`
+	Ipv6Addrs   []HostRecordIpv6Addr `json:"ipv6addrs,omitempty"`
+	Aliases     []string             `json:"aliases,omitempty"`
 	Name        string               `json:"name,omitempty"`
 	View        string               `json:"view,omitempty"`
 	Zone        string               `json:"zone,omitempty"`
 	EnableDns   *bool                `json:"configure_for_dns,omitempty"`
+	Ttl         uint32               `json:"ttl,omitempty"`
+	UseTtl      *bool                `json:"use_ttl,omitempty"`
+	Comment     string               `json:"comment,omitempty"`
 	NetworkView string               `json:"network_view,omitempty"`
 	Ea          EA                   `json:"extattrs,omitempty"`
 }
@@ -442,13 +964,17 @@ func NewHostRecord(rh HostRecord) *HostRecord {
 }
 
 type RecordTXT struct {
-	IBBase `json:"-"`
-	Ref    string `json:"_ref,omitempty"`
-	Name   string `json:"name,omitempty"`
-	Text   string `json:"text,omitempty"`
-	View   string `json:"view,omitempty"`
-	Zone   string `json:"zone,omitempty"`
-	Ea     EA     `json:"extattrs,omitempty"`
+	IBBase       `json:"-"`
+	Ref          string    `json:"_ref,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Text         string    `json:"text,omitempty"`
+	View         string    `json:"view,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	Ttl          uint32    `json:"ttl,omitempty"`
+	UseTtl       *bool     `json:"use_ttl,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreationTime *UnixTime `json:"creation_time,omitempty"`
+	Ea           EA        `json:"extattrs,omitempty"`
 }
 
 func NewRecordTXT(rt RecordTXT) *RecordTXT {
@@ -459,12 +985,115 @@ func NewRecordTXT(rt RecordTXT) *RecordTXT {
 	return &res
 }
 
+// RecordMX represents record:mx wapi object
+type RecordMX struct {
+	IBBase        `json:"-"`
+	Ref           string    `json:"_ref,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	MailExchanger string    `json:"mail_exchanger,omitempty"`
+	Preference    *uint32   `json:"preference,omitempty"`
+	View          string    `json:"view,omitempty"`
+	Zone          string    `json:"zone,omitempty"`
+	Ttl           uint32    `json:"ttl,omitempty"`
+	UseTtl        *bool     `json:"use_ttl,omitempty"`
+	Comment       string    `json:"comment,omitempty"`
+	CreationTime  *UnixTime `json:"creation_time,omitempty"`
+	Ea            EA        `json:"extattrs,omitempty"`
+}
+
+func NewRecordMX(rm RecordMX) *RecordMX {
+	res := rm
+	res.objectType = "record:mx"
+	res.returnFields = []string{"extattrs", "mail_exchanger", "name", "preference", "view", "zone"}
+
+	return &res
+}
+
+// RecordSRV represents record:srv wapi object
+type RecordSRV struct {
+	IBBase       `json:"-"`
+	Ref          string    `json:"_ref,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Target       string    `json:"target,omitempty"`
+	Priority     *uint32   `json:"priority,omitempty"`
+	Weight       *uint32   `json:"weight,omitempty"`
+	Port         *uint32   `json:"port,omitempty"`
+	View         string    `json:"view,omitempty"`
+	Zone         string    `json:"zone,omitempty"`
+	Ttl          uint32    `json:"ttl,omitempty"`
+	UseTtl       *bool     `json:"use_ttl,omitempty"`
+	Comment      string    `json:"comment,omitempty"`
+	CreationTime *UnixTime `json:"creation_time,omitempty"`
+	Ea           EA        `json:"extattrs,omitempty"`
+}
+
+func NewRecordSRV(rs RecordSRV) *RecordSRV {
+	res := rs
+	res.objectType = "record:srv"
+	res.returnFields = []string{"extattrs", "name", "port", "priority", "target", "view", "weight", "zone"}
+
+	return &res
+}
+
+// NsAddress is an address of the name server of a NS record
+type NsAddress struct {
+	Address       string `json:"address"`
+	AutoCreatePtr *bool  `json:"auto_create_ptr,omitempty"`
+}
+
+// RecordNS represents record:ns wapi object. NIOS does not support
+// extensible attributes, TTL or comment on NS records.
+type RecordNS struct {
+	IBBase     `json:"-"`
+	Ref        string      `json:"_ref,omitempty"`
+	Name       string      `json:"name,omitempty"`
+	Nameserver string      `json:"nameserver,omitempty"`
+	Addresses  []NsAddress `json:"addresses,omitempty"`
+	View       string      `json:"view,omitempty"`
+	Zone       string      `json:"zone,omitempty"`
+}
+
+func NewRecordNS(rn RecordNS) *RecordNS {
+	res := rn
+	res.objectType = "record:ns"
+	res.returnFields = []string{"addresses", "name", "nameserver", "view", "zone"}
+
+	return &res
+}
+
+// MemberServer is a grid member serving a zone
+type MemberServer struct {
+	Name          string `json:"name"`
+	Stealth       bool   `json:"stealth,omitempty"`
+	GridReplicate bool   `json:"grid_replicate,omitempty"`
+	Lead          bool   `json:"lead,omitempty"`
+}
+
+// NameServer is an external name server
+type NameServer struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+// ForwardingMemberServer is a grid member forwarding a zone
+type ForwardingMemberServer struct {
+	Name                  string       `json:"name"`
+	ForwardersOnly        bool         `json:"forwarders_only,omitempty"`
+	ForwardTo             []NameServer `json:"forward_to,omitempty"`
+	UseOverrideForwarders bool         `json:"use_override_forwarders,omitempty"`
+}
+
 type ZoneAuth struct {
-	IBBase `json:"-"`
-	Ref    string `json:"_ref,omitempty"`
-	Fqdn   string `json:"fqdn,omitempty"`
-	View   string `json:"view,omitempty"`
-	Ea     EA     `json:"extattrs,omitempty"`
+	IBBase          `json:"-"`
+	Ref             string         `json:"_ref,omitempty"`
+	Fqdn            string         `json:"fqdn,omitempty"`
+	View            string         `json:"view,omitempty"`
+	ZoneFormat      string         `json:"zone_format,omitempty"`
+	Comment         string         `json:"comment,omitempty"`
+	NsGroup         string         `json:"ns_group,omitempty"`
+	GridPrimary     []MemberServer `json:"grid_primary,omitempty"`
+	GridSecondaries []MemberServer `json:"grid_secondaries,omitempty"`
+	Ea              EA             `json:"extattrs,omitempty"`
 }
 
 func NewZoneAuth(za ZoneAuth) *ZoneAuth {
@@ -475,11 +1104,613 @@ func NewZoneAuth(za ZoneAuth) *ZoneAuth {
 	return &res
 }
 
+// AllRecords is a record of a zone, of any type, as listed by allrecords.
+// Record is the ref of the record itself.
+type AllRecords struct {
+	IBBase `json:"-"`
+	Ref    string `json:"_ref,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Record string `json:"record,omitempty"`
+	View   string `json:"view,omitempty"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+func NewAllRecords(ar AllRecords) *AllRecords {
+	res := ar
+	res.objectType = "allrecords"
+	res.returnFields = []string{"name", "record", "type", "view", "zone"}
+
+	return &res
+}
+
+type ZoneForward struct {
+	IBBase            `json:"-"`
+	Ref               string                   `json:"_ref,omitempty"`
+	Fqdn              string                   `json:"fqdn,omitempty"`
+	View              string                   `json:"view,omitempty"`
+	ZoneFormat        string                   `json:"zone_format,omitempty"`
+	Comment           string                   `json:"comment,omitempty"`
+	ForwardTo         []NameServer             `json:"forward_to,omitempty"`
+	ForwardersOnly    bool                     `json:"forwarders_only,omitempty"`
+	ForwardingServers []ForwardingMemberServer `json:"forwarding_servers,omitempty"`
+	NsGroup           string                   `json:"ns_group,omitempty"`
+	Ea                EA                       `json:"extattrs,omitempty"`
+}
+
+func NewZoneForward(zf ZoneForward) *ZoneForward {
+	res := zf
+	res.objectType = "zone_forward"
+	res.returnFields = []string{"comment", "extattrs", "forward_to", "forwarders_only",
+		"forwarding_servers", "fqdn", "ns_group", "view", "zone_format"}
+
+	return &res
+}
+
+type ZoneDelegated struct {
+	IBBase     `json:"-"`
+	Ref        string       `json:"_ref,omitempty"`
+	Fqdn       string       `json:"fqdn,omitempty"`
+	View       string       `json:"view,omitempty"`
+	ZoneFormat string       `json:"zone_format,omitempty"`
+	Comment    string       `json:"comment,omitempty"`
+	DelegateTo []NameServer `json:"delegate_to,omitempty"`
+	NsGroup    string       `json:"ns_group,omitempty"`
+	Ea         EA           `json:"extattrs,omitempty"`
+}
+
+func NewZoneDelegated(zd ZoneDelegated) *ZoneDelegated {
+	res := zd
+	res.objectType = "zone_delegated"
+	res.returnFields = []string{"comment", "delegate_to", "extattrs", "fqdn", "ns_group", "view", "zone_format"}
+
+	return &res
+}
+
+// AddressAC is an access control entry for an address or a network,
+// Permission is "ALLOW" or "DENY"
+type AddressAC struct {
+	Address    string `json:"address"`
+	Permission string `json:"permission,omitempty"`
+}
+
+// View represents view wapi object, a DNS view answering the clients
+// matching MatchClients on the addresses matching MatchDestinations
+type View struct {
+	IBBase            `json:"-"`
+	Ref               string      `json:"_ref,omitempty"`
+	Name              string      `json:"name,omitempty"`
+	NetworkView       string      `json:"network_view,omitempty"`
+	MatchClients      []AddressAC `json:"match_clients,omitempty"`
+	MatchDestinations []AddressAC `json:"match_destinations,omitempty"`
+	Recursion         *bool       `json:"recursion,omitempty"`
+	Disable           *bool       `json:"disable,omitempty"`
+	Comment           string      `json:"comment,omitempty"`
+	Ea                EA          `json:"extattrs,omitempty"`
+}
+
+func NewView(v View) *View {
+	res := v
+	res.objectType = "view"
+	res.returnFields = []string{"comment", "disable", "extattrs", "match_clients", "match_destinations",
+		"name", "network_view", "recursion"}
+
+	return &res
+}
+
+// NSGroup represents nsgroup wapi object, the grid members and external
+// servers serving the zones assigned to the group
+type NSGroup struct {
+	IBBase              `json:"-"`
+	Ref                 string         `json:"_ref,omitempty"`
+	Name                string         `json:"name,omitempty"`
+	GridPrimary         []MemberServer `json:"grid_primary,omitempty"`
+	GridSecondaries     []MemberServer `json:"grid_secondaries,omitempty"`
+	ExternalPrimaries   []NameServer   `json:"external_primaries,omitempty"`
+	ExternalSecondaries []NameServer   `json:"external_secondaries,omitempty"`
+	IsGridDefault       *bool          `json:"is_grid_default,omitempty"`
+	Comment             string         `json:"comment,omitempty"`
+	Ea                  EA             `json:"extattrs,omitempty"`
+}
+
+func NewNSGroup(ns NSGroup) *NSGroup {
+	res := ns
+	res.objectType = "nsgroup"
+	res.returnFields = []string{"comment", "extattrs", "external_primaries", "external_secondaries",
+		"grid_primary", "grid_secondaries", "is_grid_default", "name"}
+
+	return &res
+}
+
+// NamedACL represents namedacl wapi object, a named list of addresses
+// allowed or denied which DNS views and zones refer to
+type NamedACL struct {
+	IBBase     `json:"-"`
+	Ref        string      `json:"_ref,omitempty"`
+	Name       string      `json:"name,omitempty"`
+	AccessList []AddressAC `json:"access_list,omitempty"`
+	Comment    string      `json:"comment,omitempty"`
+	Ea         EA          `json:"extattrs,omitempty"`
+}
+
+func NewNamedACL(acl NamedACL) *NamedACL {
+	res := acl
+	res.objectType = "namedacl"
+	res.returnFields = []string{"access_list", "comment", "extattrs", "name"}
+
+	return &res
+}
+
+// NetworkTemplate represents networktemplate wapi object, the options,
+// ranges, fixed addresses and EAs of the networks created from it
+type NetworkTemplate struct {
+	IBBase                `json:"-"`
+	Ref                   string       `json:"_ref,omitempty"`
+	Name                  string       `json:"name,omitempty"`
+	Netmask               uint         `json:"netmask,omitempty"`
+	AllowAnyNetmask       bool         `json:"allow_any_netmask,omitempty"`
+	RangeTemplates        []string     `json:"range_templates,omitempty"`
+	FixedAddressTemplates []string     `json:"fixed_address_templates,omitempty"`
+	Options               []DhcpOption `json:"options,omitempty"`
+	Comment               string       `json:"comment,omitempty"`
+	Ea                    EA           `json:"extattrs,omitempty"`
+}
+
+func NewNetworkTemplate(nt NetworkTemplate) *NetworkTemplate {
+	res := nt
+	res.objectType = "networktemplate"
+	res.returnFields = []string{"allow_any_netmask", "comment", "extattrs", "fixed_address_templates",
+		"name", "netmask", "options", "range_templates"}
+
+	return &res
+}
+
+// RangeTemplate represents rangetemplate wapi object, a range created in
+// the networks of the network templates referring to it. The range starts
+// at Offset from the network address.
+type RangeTemplate struct {
+	IBBase                `json:"-"`
+	Ref                   string       `json:"_ref,omitempty"`
+	Name                  string       `json:"name,omitempty"`
+	NumberOfAddresses     uint         `json:"number_of_addresses,omitempty"`
+	Offset                uint         `json:"offset,omitempty"`
+	ServerAssociationType string       `json:"server_association_type,omitempty"`
+	Member                *DhcpMember  `json:"member,omitempty"`
+	Options               []DhcpOption `json:"options,omitempty"`
+	Comment               string       `json:"comment,omitempty"`
+	Ea                    EA           `json:"extattrs,omitempty"`
+}
+
+func NewRangeTemplate(rt RangeTemplate) *RangeTemplate {
+	res := rt
+	res.objectType = "rangetemplate"
+	res.returnFields = []string{"comment", "extattrs", "member", "name", "number_of_addresses",
+		"offset", "options", "server_association_type"}
+
+	return &res
+}
+
+// ZoneAssociation is an authoritative zone of a DNS view which a shared
+// record group is associated with
+type ZoneAssociation struct {
+	Fqdn string `json:"fqdn,omitempty"`
+	View string `json:"view,omitempty"`
+}
+
+// SharedRecordGroup represents sharedrecordgroup wapi object, the shared
+// records of the group are published in every zone associated with it
+type SharedRecordGroup struct {
+	IBBase           `json:"-"`
+	Ref              string            `json:"_ref,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	ZoneAssociations []ZoneAssociation `json:"zone_associations,omitempty"`
+	Comment          string            `json:"comment,omitempty"`
+	Ea               EA                `json:"extattrs,omitempty"`
+}
+
+func NewSharedRecordGroup(srg SharedRecordGroup) *SharedRecordGroup {
+	res := srg
+	res.objectType = "sharedrecordgroup"
+	res.returnFields = []string{"comment", "extattrs", "name", "zone_associations"}
+
+	return &res
+}
+
+// SharedRecordA represents sharedrecord:a wapi object, an A record of a
+// shared record group. Name is relative to the zones of the group.
+type SharedRecordA struct {
+	IBBase            `json:"-"`
+	Ref               string `json:"_ref,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Ipv4Addr          string `json:"ipv4addr,omitempty"`
+	SharedRecordGroup string `json:"shared_record_group,omitempty"`
+	Ttl               uint32 `json:"ttl,omitempty"`
+	UseTtl            *bool  `json:"use_ttl,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	Ea                EA     `json:"extattrs,omitempty"`
+}
+
+func NewSharedRecordA(sr SharedRecordA) *SharedRecordA {
+	res := sr
+	res.objectType = "sharedrecord:a"
+	res.returnFields = []string{"comment", "extattrs", "ipv4addr", "name", "shared_record_group", "ttl", "use_ttl"}
+
+	return &res
+}
+
+// SharedRecordAAAA represents sharedrecord:aaaa wapi object, an AAAA record
+// of a shared record group
+type SharedRecordAAAA struct {
+	IBBase            `json:"-"`
+	Ref               string `json:"_ref,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Ipv6Addr          string `json:"ipv6addr,omitempty"`
+	SharedRecordGroup string `json:"shared_record_group,omitempty"`
+	Ttl               uint32 `json:"ttl,omitempty"`
+	UseTtl            *bool  `json:"use_ttl,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	Ea                EA     `json:"extattrs,omitempty"`
+}
+
+func NewSharedRecordAAAA(sr SharedRecordAAAA) *SharedRecordAAAA {
+	res := sr
+	res.objectType = "sharedrecord:aaaa"
+	res.returnFields = []string{"comment", "extattrs", "ipv6addr", "name", "shared_record_group", "ttl", "use_ttl"}
+
+	return &res
+}
+
+// SharedRecordCNAME represents sharedrecord:cname wapi object, a CNAME
+// record of a shared record group
+type SharedRecordCNAME struct {
+	IBBase            `json:"-"`
+	Ref               string `json:"_ref,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Canonical         string `json:"canonical,omitempty"`
+	SharedRecordGroup string `json:"shared_record_group,omitempty"`
+	Ttl               uint32 `json:"ttl,omitempty"`
+	UseTtl            *bool  `json:"use_ttl,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+	Ea                EA     `json:"extattrs,omitempty"`
+}
+
+func NewSharedRecordCNAME(sr SharedRecordCNAME) *SharedRecordCNAME {
+	res := sr
+	res.objectType = "sharedrecord:cname"
+	res.returnFields = []string{"canonical", "comment", "extattrs", "name", "shared_record_group", "ttl", "use_ttl"}
+
+	return &res
+}
+
+// DtcServer represents dtc:server wapi object, a server answered by a
+// DTC LBDN
+type DtcServer struct {
+	IBBase  `json:"-"`
+	Ref     string `json:"_ref,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Host    string `json:"host,omitempty"`
+	Comment string `json:"comment,omitempty"`
+	Disable *bool  `json:"disable,omitempty"`
+	Ea      EA     `json:"extattrs,omitempty"`
+}
+
+func NewDtcServer(ds DtcServer) *DtcServer {
+	res := ds
+	res.objectType = "dtc:server"
+	res.returnFields = []string{"comment", "disable", "extattrs", "host", "name"}
+
+	return &res
+}
+
+// DtcServerLink is a server of a DTC pool with its load balancing ratio
+type DtcServerLink struct {
+	Server string `json:"server"`
+	Ratio  uint32 `json:"ratio"`
+}
+
+// DtcPool represents dtc:pool wapi object. LbPreferredMethod is
+// "ROUND_ROBIN", "RATIO", "GLOBAL_AVAILABILITY", "TOPOLOGY" or
+// "ALL_AVAILABLE", Availability is "ALL", "ANY" or "QUORUM" of the
+// monitors.
+type DtcPool struct {
+	IBBase            `json:"-"`
+	Ref               string          `json:"_ref,omitempty"`
+	Name              string          `json:"name,omitempty"`
+	LbPreferredMethod string          `json:"lb_preferred_method,omitempty"`
+	Servers           []DtcServerLink `json:"servers,omitempty"`
+	Monitors          []string        `json:"monitors,omitempty"`
+	Availability      string          `json:"availability,omitempty"`
+	Ttl               uint32          `json:"ttl,omitempty"`
+	UseTtl            *bool           `json:"use_ttl,omitempty"`
+	Comment           string          `json:"comment,omitempty"`
+	Ea                EA              `json:"extattrs,omitempty"`
+}
+
+func NewDtcPool(dp DtcPool) *DtcPool {
+	res := dp
+	res.objectType = "dtc:pool"
+	res.returnFields = []string{"availability", "comment", "extattrs", "lb_preferred_method",
+		"monitors", "name", "servers"}
+
+	return &res
+}
+
+// DtcPoolLink is a pool of a DTC LBDN with its load balancing ratio
+type DtcPoolLink struct {
+	Pool  string `json:"pool"`
+	Ratio uint32 `json:"ratio"`
+}
+
+// DtcLbdn represents dtc:lbdn wapi object, a load balanced domain name
+// answered with the servers of its pools for the names matching Patterns
+// in AuthZones
+type DtcLbdn struct {
+	IBBase    `json:"-"`
+	Ref       string        `json:"_ref,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	LbMethod  string        `json:"lb_method,omitempty"`
+	Pools     []DtcPoolLink `json:"pools,omitempty"`
+	AuthZones []string      `json:"auth_zones,omitempty"`
+	Patterns  []string      `json:"patterns,omitempty"`
+	Types     []RecordType  `json:"types,omitempty"`
+	Ttl       uint32        `json:"ttl,omitempty"`
+	UseTtl    *bool         `json:"use_ttl,omitempty"`
+	Disable   *bool         `json:"disable,omitempty"`
+	Comment   string        `json:"comment,omitempty"`
+	Ea        EA            `json:"extattrs,omitempty"`
+}
+
+func NewDtcLbdn(dl DtcLbdn) *DtcLbdn {
+	res := dl
+	res.objectType = "dtc:lbdn"
+	res.returnFields = []string{"auth_zones", "comment", "disable", "extattrs", "lb_method",
+		"name", "patterns", "pools", "types"}
+
+	return &res
+}
+
+// DtcMonitor represents the dtc:monitor:<type> wapi objects, the health
+// monitors of DTC pools. Type is "http", "icmp", "pdp", "sip", "snmp" or
+// "tcp", the fields Secure, Request, Result and ResultCode only apply to
+// HTTP monitors.
+type DtcMonitor struct {
+	IBBase     `json:"-"`
+	Type       string `json:"-"`
+	Ref        string `json:"_ref,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Interval   uint32 `json:"interval,omitempty"`
+	Timeout    uint32 `json:"timeout,omitempty"`
+	RetryUp    uint32 `json:"retry_up,omitempty"`
+	RetryDown  uint32 `json:"retry_down,omitempty"`
+	Port       uint32 `json:"port,omitempty"`
+	Secure     *bool  `json:"secure,omitempty"`
+	Request    string `json:"request,omitempty"`
+	Result     string `json:"result,omitempty"`
+	ResultCode uint32 `json:"result_code,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Ea         EA     `json:"extattrs,omitempty"`
+}
+
+func NewDtcMonitor(dm DtcMonitor) *DtcMonitor {
+	res := dm
+	res.objectType = "dtc:monitor:" + dm.Type
+	res.returnFields = []string{"comment", "extattrs", "interval", "name", "retry_down", "retry_up", "timeout"}
+	switch dm.Type {
+	case "http":
+		res.returnFields = append(res.returnFields, "port", "request", "result", "result_code", "secure")
+	case "pdp", "sip", "snmp", "tcp":
+		res.returnFields = append(res.returnFields, "port")
+	}
+
+	return &res
+}
+
+// DiscoveryDevice represents discovery:device wapi object, it requires
+// the Network Insight license
+type DiscoveryDevice struct {
+	IBBase      `json:"-"`
+	Ref         string `json:"_ref,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Address     string `json:"address,omitempty"`
+	NetviewName string `json:"network_view,omitempty"`
+	Vendor      string `json:"vendor,omitempty"`
+	Model       string `json:"model,omitempty"`
+	OsVersion   string `json:"os_version,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+func NewDiscoveryDevice(dev DiscoveryDevice) *DiscoveryDevice {
+	res := dev
+	res.objectType = "discovery:device"
+	res.returnFields = []string{"address", "model", "name", "network_view", "os_version", "type", "vendor"}
+
+	return &res
+}
+
+type VlanInfo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type PortConfigAdminStatus struct {
+	AdminStatus string `json:"admin_status,omitempty"`
+}
+
+type PortConfigVlanInfo struct {
+	DataVlanInfo  *VlanInfo `json:"data_vlan_info,omitempty"`
+	VoiceVlanInfo *VlanInfo `json:"voice_vlan_info,omitempty"`
+}
+
+type PortConfigDescription struct {
+	Description string `json:"description,omitempty"`
+}
+
+// DiscoveryDeviceInterface represents discovery:deviceinterface wapi
+// object, the port_config fields are used for port control
+type DiscoveryDeviceInterface struct {
+	IBBase                `json:"-"`
+	Ref                   string                 `json:"_ref,omitempty"`
+	Name                  string                 `json:"name,omitempty"`
+	Device                string                 `json:"device,omitempty"`
+	AdminStatus           string                 `json:"admin_status,omitempty"`
+	OperStatus            string                 `json:"oper_status,omitempty"`
+	Description           string                 `json:"description,omitempty"`
+	VlanInfos             []VlanInfo             `json:"vlan_infos,omitempty"`
+	PortConfigAdminStatus *PortConfigAdminStatus `json:"port_config_admin_status,omitempty"`
+	PortConfigDescription *PortConfigDescription `json:"port_config_description,omitempty"`
+	PortConfigVlanInfo    *PortConfigVlanInfo    `json:"port_config_vlan_info,omitempty"`
+}
+
+func NewDiscoveryDeviceInterface(intf DiscoveryDeviceInterface) *DiscoveryDeviceInterface {
+	res := intf
+	res.objectType = "discovery:deviceinterface"
+	res.returnFields = []string{"admin_status", "description", "device", "name", "oper_status", "vlan_infos"}
+
+	return &res
+}
+
+// DhcpMember is a grid member serving DHCP. Struct is the kind of
+// member, "dhcpmember", required in the members of a network.
+type DhcpMember struct {
+	Struct   string `json:"_struct,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Ipv4Addr string `json:"ipv4addr,omitempty"`
+	Ipv6Addr string `json:"ipv6addr,omitempty"`
+}
+
+type DhcpOption struct {
+	Name        string `json:"name,omitempty"`
+	Num         uint32 `json:"num,omitempty"`
+	Value       string `json:"value"`
+	VendorClass string `json:"vendor_class,omitempty"`
+	UseOption   *bool  `json:"use_option,omitempty"`
+}
+
+type Range struct {
+	IBBase                `json:"-"`
+	Ref                   string       `json:"_ref,omitempty"`
+	NetviewName           string       `json:"network_view,omitempty"`
+	Network               string       `json:"network,omitempty"`
+	StartAddr             string       `json:"start_addr,omitempty"`
+	EndAddr               string       `json:"end_addr,omitempty"`
+	Name                  string       `json:"name,omitempty"`
+	Comment               string       `json:"comment,omitempty"`
+	Disable               bool         `json:"disable,omitempty"`
+	ServerAssociationType string       `json:"server_association_type,omitempty"`
+	Member                *DhcpMember  `json:"member,omitempty"`
+	FailoverAssociation   string       `json:"failover_association,omitempty"`
+	Options               []DhcpOption `json:"options,omitempty"`
+	Ea                    EA           `json:"extattrs,omitempty"`
+}
+
+func NewRange(rng Range) *Range {
+	res := rng
+	res.objectType = "range"
+	res.returnFields = []string{"comment", "end_addr", "extattrs", "failover_association", "member",
+		"name", "network", "network_view", "options", "server_association_type", "start_addr"}
+
+	return &res
+}
+
+// IPv6Range represents ipv6range wapi object. A range of AddressType
+// PREFIX or BOTH delegates the prefixes of Ipv6PrefixBits length from
+// Ipv6StartPrefix to Ipv6EndPrefix to requesting routers.
+type IPv6Range struct {
+	IBBase                `json:"-"`
+	Ref                   string      `json:"_ref,omitempty"`
+	NetviewName           string      `json:"network_view,omitempty"`
+	Network               string      `json:"network,omitempty"`
+	AddressType           AddressType `json:"address_type,omitempty"`
+	StartAddr             string      `json:"start_addr,omitempty"`
+	EndAddr               string      `json:"end_addr,omitempty"`
+	Ipv6PrefixBits        uint32      `json:"ipv6_prefix_bits,omitempty"`
+	Ipv6StartPrefix       string      `json:"ipv6_start_prefix,omitempty"`
+	Ipv6EndPrefix         string      `json:"ipv6_end_prefix,omitempty"`
+	Name                  string      `json:"name,omitempty"`
+	Comment               string      `json:"comment,omitempty"`
+	Disable               bool        `json:"disable,omitempty"`
+	ServerAssociationType string      `json:"server_association_type,omitempty"`
+	Member                *DhcpMember `json:"member,omitempty"`
+	Ea                    EA          `json:"extattrs,omitempty"`
+}
+
+func NewIPv6Range(rng IPv6Range) *IPv6Range {
+	res := rng
+	res.objectType = "ipv6range"
+	res.returnFields = []string{"address_type", "comment", "end_addr", "extattrs", "ipv6_end_prefix",
+		"ipv6_prefix_bits", "ipv6_start_prefix", "member", "name", "network", "network_view",
+		"server_association_type", "start_addr"}
+
+	return &res
+}
+
+// DBObject is an object of the grid database created or modified after a
+// sequence id. Object is the reference of the object.
+type DBObject struct {
+	Ref            string `json:"_ref,omitempty"`
+	LastSequenceID string `json:"last_sequence_id,omitempty"`
+	Object         string `json:"object,omitempty"`
+	ObjectType     string `json:"object_type,omitempty"`
+	UniqueID       string `json:"unique_id,omitempty"`
+}
+
+// SearchObject is an object of any type searched by the fields in Filters.
+// Filter names may carry WAPI search modifiers, e.g. "name~" for a regular
+// expression or "name:" for a case-insensitive match.
+type SearchObject struct {
+	IBBase  `json:"-"`
+	Filters map[string]interface{}
+}
+
+func NewSearchObject(objType string, filters map[string]interface{}, eaFilters EA, returnFields []string) *SearchObject {
+	res := &SearchObject{Filters: filters}
+	res.objectType = objType
+	res.returnFields = returnFields
+	res.returnFieldsPlus = len(returnFields) > 0
+	if len(eaFilters) > 0 {
+		res.eaSearch = EASearch(eaFilters)
+	}
+
+	return res
+}
+
+func (s *SearchObject) MarshalJSON() ([]byte, error) {
+	if s.Filters == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(s.Filters)
+}
+
+// eaJSONValue returns v as sent to WAPI, dates as RFC 3339 timestamps
+func eaJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	case []interface{}:
+		values := make([]interface{}, len(value))
+		for i, e := range value {
+			values[i] = eaJSONValue(e)
+		}
+		return values
+	}
+
+	return v
+}
+
 func (ea EA) MarshalJSON() ([]byte, error) {
 	m := make(map[string]interface{})
 	for k, v := range ea {
 		value := make(map[string]interface{})
-		value["value"] = v
+		if eaValue, ok := v.(EAValue); ok {
+			if eaValue.Value != nil {
+				value["value"] = eaJSONValue(eaValue.Value)
+			}
+			if eaValue.InheritanceOperation != "" {
+				value["inheritance_operation"] = eaValue.InheritanceOperation
+			}
+		} else {
+			value["value"] = eaJSONValue(v)
+		}
 		m[k] = value
 	}
 
@@ -510,6 +1741,20 @@ func (b Bool) MarshalJSON() ([]byte, error) {
 	return json.Marshal("False")
 }
 
+func (t UnixTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Unix())
+}
+
+func (t *UnixTime) UnmarshalJSON(b []byte) error {
+	var sec int64
+	if err := json.Unmarshal(b, &sec); err != nil {
+		return err
+	}
+
+	t.Time = time.Unix(sec, 0).UTC()
+	return nil
+}
+
 func (ea *EA) UnmarshalJSON(b []byte) (err error) {
 	var m map[string]map[string]interface{}
 
@@ -523,14 +1768,21 @@ func (ea *EA) UnmarshalJSON(b []byte) (err error) {
 	*ea = make(EA)
 	for k, v := range m {
 		val := v["value"]
-		if reflect.TypeOf(val).String() == "json.Number" {
+		switch value := val.(type) {
+		case json.Number:
 			var i64 int64
-			i64, err = val.(json.Number).Int64()
+			if i64, err = value.Int64(); err != nil {
+				return
+			}
 			val = int(i64)
-		} else if val.(string) == "True" {
-			val = Bool(true)
-		} else if val.(string) == "False" {
-			val = Bool(false)
+		case string:
+			if value == "True" {
+				val = Bool(true)
+			} else if value == "False" {
+				val = Bool(false)
+			}
+		case nil:
+			return fmt.Errorf("extensible attribute '%s' has no value", k)
 		}
 
 		(*ea)[k] = val
@@ -539,6 +1791,32 @@ func (ea *EA) UnmarshalJSON(b []byte) (err error) {
 	return
 }
 
+func (eas *EAInheritance) UnmarshalJSON(b []byte) (err error) {
+	var ea EA
+	if err = json.Unmarshal(b, &ea); err != nil {
+		return
+	}
+	var sources map[string]struct {
+		Source *struct {
+			Ref string `json:"_ref"`
+		} `json:"inheritance_source"`
+	}
+	if err = json.Unmarshal(b, &sources); err != nil {
+		return
+	}
+
+	*eas = make(EAInheritance)
+	for k, v := range ea {
+		value := EAValue{Value: v}
+		if source := sources[k].Source; source != nil {
+			value.InheritanceSource = source.Ref
+		}
+		(*eas)[k] = value
+	}
+
+	return
+}
+
 func (v *EADefListValue) UnmarshalJSON(b []byte) (err error) {
 	var m map[string]string
 	err = json.Unmarshal(b, &m)
@@ -550,6 +1828,29 @@ func (v *EADefListValue) UnmarshalJSON(b []byte) (err error) {
 	return
 }
 
+// CSVImportTask is the state of a CSV import
+type CSVImportTask struct {
+	IBBase         `json:"-"`
+	Ref            string `json:"_ref,omitempty"`
+	ImportID       int    `json:"import_id,omitempty"`
+	FileName       string `json:"file_name,omitempty"`
+	Operation      string `json:"operation,omitempty"`
+	OnError        string `json:"on_error,omitempty"`
+	Status         string `json:"status,omitempty"`
+	LinesProcessed int    `json:"lines_processed,omitempty"`
+	LinesFailed    int    `json:"lines_failed,omitempty"`
+	LinesWarning   int    `json:"lines_warning,omitempty"`
+}
+
+func NewCSVImportTask(task CSVImportTask) *CSVImportTask {
+	res := task
+	res.objectType = "csvimporttask"
+	res.returnFields = []string{"file_name", "import_id", "lines_failed", "lines_processed", "lines_warning",
+		"on_error", "operation", "status"}
+
+	return &res
+}
+
 type RequestBody struct {
 	Data               map[string]interface{} `json:"data,omitempty"`
 	Args               map[string]string      `json:"args,omitempty"`
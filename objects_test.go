@@ -2,6 +2,7 @@ package ibclient
 
 import (
 	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -479,6 +480,24 @@ var _ = Describe("Objects", func() {
 
 	})
 
+	Context("Unmarshalling timestamps", func() {
+		var ra RecordA
+		err := json.Unmarshal([]byte(`{"name": "vm1.example.com", "creation_time": 1546300800}`), &ra)
+
+		It("should parse creation_time as a UTC time", func() {
+			Expect(err).To(BeNil())
+			Expect(ra.CreationTime.Time).To(Equal(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should not send an unset timestamp", func() {
+			js, _ := json.Marshal(RecordA{Name: "vm1.example.com"})
+			Expect(string(js)).NotTo(ContainSubstring("creation_time"))
+
+			js, _ = json.Marshal(ra.CreationTime)
+			Expect(string(js)).To(Equal("1546300800"))
+		})
+	})
+
 	Context("Unmarshalling malformed JSON", func() {
 		Context("for EA", func() {
 			badJSON := `""`
@@ -490,6 +509,34 @@ var _ = Describe("Objects", func() {
 			})
 		})
 
+		Context("for EA without value", func() {
+			var ea EA
+			err := json.Unmarshal([]byte(`{"Owner": {}}`), &ea)
+
+			It("should return an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		Context("for EA with a fractional number", func() {
+			var ea EA
+			err := json.Unmarshal([]byte(`{"Weight": {"value": 1.5}}`), &ea)
+
+			It("should return an error", func() {
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		Context("for EA with a list of values", func() {
+			var ea EA
+			err := json.Unmarshal([]byte(`{"Site": {"value": ["hq", "dc1"]}}`), &ea)
+
+			It("should keep the values", func() {
+				Expect(err).To(BeNil())
+				Expect(ea).To(Equal(EA{"Site": []interface{}{"hq", "dc1"}}))
+			})
+		})
+
 		Context("for EADefListValue", func() {
 			badJSON := `""`
 			var ead EADefListValue
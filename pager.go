@@ -0,0 +1,97 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+)
+
+// DefaultPageSize is the page size used when a non-positive size is given
+const DefaultPageSize = 1000
+
+// IBPagingConnector is implemented by connectors which can fetch search
+// results exceeding the grid's max results limit page by page
+type IBPagingConnector interface {
+	IBConnector
+	GetObjectPaged(obj IBObject, pageSize int, res interface{}) error
+}
+
+type pagedResult struct {
+	Result     json.RawMessage `json:"result"`
+	NextPageID string          `json:"next_page_id,omitempty"`
+}
+
+// Pager iterates over the pages of a WAPI search
+type Pager struct {
+	conn     *Connector
+	obj      IBObject
+	pageSize int
+	pageID   string
+	done     bool
+}
+
+// NewPager returns a Pager fetching the objects matching obj in pages of
+// pageSize objects
+func (c *Connector) NewPager(obj IBObject, pageSize int) *Pager {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return &Pager{conn: c, obj: obj, pageSize: pageSize}
+}
+
+// Done reports whether all pages have been fetched
+func (p *Pager) Done() bool {
+	return p.done
+}
+
+// Next unmarshals the next page of results into res, which must be a
+// pointer to a slice
+func (p *Pager) Next(res interface{}) error {
+	if p.done {
+		return fmt.Errorf("no more pages for '%s'", p.obj.ObjectType())
+	}
+
+	queryParams := QueryParams{forceProxy: false, paging: true, maxResults: p.pageSize, pageID: p.pageID}
+	resp, err := p.conn.makeRequest(GET, p.obj, "", queryParams)
+	if err != nil {
+		log.Printf("GetObject page request error: '%s'\n", err)
+		return err
+	}
+
+	var page pagedResult
+	if err = json.Unmarshal(resp, &page); err != nil {
+		log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		return err
+	}
+
+	p.pageID = page.NextPageID
+	p.done = page.NextPageID == ""
+
+	if len(page.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(page.Result, res)
+}
+
+// GetObjectPaged fetches all objects matching obj page by page and appends
+// them to res, which must be a pointer to a slice
+func (c *Connector) GetObjectPaged(obj IBObject, pageSize int, res interface{}) error {
+	resVal := reflect.ValueOf(res)
+	if resVal.Kind() != reflect.Ptr || resVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("result of paged request must be a pointer to a slice, got %T", res)
+	}
+	all := resVal.Elem()
+
+	pager := c.NewPager(obj, pageSize)
+	for !pager.Done() {
+		page := reflect.New(all.Type())
+		if err := pager.Next(page.Interface()); err != nil {
+			return err
+		}
+		all.Set(reflect.AppendSlice(all, page.Elem()))
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package ibclient
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type pageRequestor struct {
+	reqs  []*http.Request
+	pages [][]byte
+}
+
+func (hr *pageRequestor) Init(config TransportConfig) {}
+
+func (hr *pageRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	hr.reqs = append(hr.reqs, req)
+	res := hr.pages[0]
+	hr.pages = hr.pages[1:]
+
+	return res, nil
+}
+
+var _ = Describe("Pager", func() {
+	hostConfig := HostConfig{Host: "172.22.18.66", Version: "2.5", Port: "443", Username: "admin", Password: "infoblox"}
+
+	newConnector := func(requestor HttpRequestor) *Connector {
+		builder := &WapiRequestBuilder{}
+		builder.Init(hostConfig)
+		return &Connector{HostConfig: hostConfig, RequestBuilder: builder, Requestor: requestor}
+	}
+
+	It("should aggregate all pages of the search", func() {
+		requestor := &pageRequestor{pages: [][]byte{
+			[]byte(`{"result":[{"network":"10.0.0.0/24","network_view":"default"},{"network":"10.0.1.0/24","network_view":"default"}],"next_page_id":"789c5d8f"}`),
+			[]byte(`{"result":[{"network":"10.0.2.0/24","network_view":"default"}]}`),
+		}}
+		objMgr := NewObjectManager(newConnector(requestor), "Docker", "0123")
+
+		networks, err := objMgr.GetAllNetworks("default", nil, 2)
+		Expect(err).To(BeNil())
+		Expect(networks).To(HaveLen(3))
+		Expect(networks[2].Cidr).To(Equal("10.0.2.0/24"))
+
+		Expect(requestor.reqs).To(HaveLen(2))
+		first := requestor.reqs[0].URL.Query()
+		Expect(first.Get("_paging")).To(Equal("1"))
+		Expect(first.Get("_max_results")).To(Equal("2"))
+		Expect(first.Get("_return_as_object")).To(Equal("1"))
+		Expect(first.Get("_page_id")).To(Equal(""))
+		Expect(requestor.reqs[1].URL.Query().Get("_page_id")).To(Equal("789c5d8f"))
+	})
+
+	It("should iterate over the pages with Next", func() {
+		requestor := &pageRequestor{pages: [][]byte{
+			[]byte(`{"result":[{"name":"host1.test.com"}],"next_page_id":"p2"}`),
+			[]byte(`{"result":[]}`),
+		}}
+		pager := newConnector(requestor).NewPager(NewHostRecord(HostRecord{}), 0)
+
+		var page []HostRecord
+		Expect(pager.Next(&page)).To(BeNil())
+		Expect(page).To(HaveLen(1))
+		Expect(pager.Done()).To(BeFalse())
+		Expect(requestor.reqs[0].URL.Query().Get("_max_results")).To(Equal("1000"))
+
+		page = nil
+		Expect(pager.Next(&page)).To(BeNil())
+		Expect(page).To(BeEmpty())
+		Expect(pager.Done()).To(BeTrue())
+		Expect(pager.Next(&page)).NotTo(BeNil())
+	})
+})
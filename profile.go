@@ -0,0 +1,162 @@
+package ibclient
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Profile is the configuration of the grid of an environment, e.g. dev,
+// stage or prod
+type Profile struct {
+	Host     string `json:"host" yaml:"host"`
+	Version  string `json:"version" yaml:"version"`
+	Port     string `json:"port" yaml:"port"`
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	// PasswordEnv is the environment variable holding the password, when
+	// Password is empty, to keep the password out of the profiles file
+	PasswordEnv string `json:"password_env" yaml:"password_env"`
+	// ExpectedUserType and ExpectedAdminGroup are checked in the profile
+	// of the admin, see HostConfig
+	ExpectedUserType   string `json:"expected_user_type" yaml:"expected_user_type"`
+	ExpectedAdminGroup string `json:"expected_admin_group" yaml:"expected_admin_group"`
+	NegotiateVersion   bool   `json:"negotiate_version" yaml:"negotiate_version"`
+	// Discovery resolves the Grid Master instead of Host, see HostConfig
+	Discovery string `json:"discovery" yaml:"discovery"`
+
+	// SslVerify is "true", "false" or the CA bundle verifying the grid
+	// certificate, as taken by NewTransportConfig. CAFile, CertFile,
+	// KeyFile and ServerName configure the transport with
+	// NewTLSTransportConfig instead when one of them is set.
+	SslVerify           string `json:"ssl_verify" yaml:"ssl_verify"`
+	CAFile              string `json:"ca_file" yaml:"ca_file"`
+	CertFile            string `json:"cert_file" yaml:"cert_file"`
+	KeyFile             string `json:"key_file" yaml:"key_file"`
+	ServerName          string `json:"server_name" yaml:"server_name"`
+	HttpRequestTimeout  int    `json:"http_request_timeout" yaml:"http_request_timeout"`
+	HttpPoolConnections int    `json:"http_pool_connections" yaml:"http_pool_connections"`
+
+	// CmpType and TenantID are the cloud attributes of the object managers
+	CmpType  string `json:"cmp_type" yaml:"cmp_type"`
+	TenantID string `json:"tenant_id" yaml:"tenant_id"`
+}
+
+// Profiles maps environment names to their profile
+type Profiles map[string]Profile
+
+const (
+	defaultProfileRequestTimeout  = 20
+	defaultProfilePoolConnections = 10
+)
+
+// ParseProfiles parses profiles in YAML, or in JSON which is parsed as YAML
+func ParseProfiles(data []byte) (Profiles, error) {
+	var profiles Profiles
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("cannot parse profiles: %s", err)
+	}
+
+	return profiles, nil
+}
+
+// LoadProfiles reads the profiles of the YAML or JSON file path
+func LoadProfiles(path string) (Profiles, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseProfiles(data)
+}
+
+func (p Profiles) profile(name string) (Profile, error) {
+	profile, ok := p[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile '%s' not found", name)
+	}
+	return profile, nil
+}
+
+// HostConfig returns the host configuration of the profile name
+func (p Profiles) HostConfig(name string) (HostConfig, error) {
+	profile, err := p.profile(name)
+	if err != nil {
+		return HostConfig{}, err
+	}
+
+	password := profile.Password
+	if password == "" && profile.PasswordEnv != "" {
+		password = os.Getenv(profile.PasswordEnv)
+		if password == "" {
+			return HostConfig{}, fmt.Errorf("password of profile '%s' not set in '%s'", name, profile.PasswordEnv)
+		}
+	}
+
+	return HostConfig{
+		Host:               profile.Host,
+		Version:            profile.Version,
+		Port:               profile.Port,
+		Username:           profile.Username,
+		Password:           password,
+		ExpectedUserType:   profile.ExpectedUserType,
+		ExpectedAdminGroup: profile.ExpectedAdminGroup,
+		NegotiateVersion:   profile.NegotiateVersion,
+		Discovery:          profile.Discovery}, nil
+}
+
+// TransportConfig returns the transport configuration of the profile name.
+// The timeout and the pool size default to 20 seconds and 10 connections.
+func (p Profiles) TransportConfig(name string) (TransportConfig, error) {
+	profile, err := p.profile(name)
+	if err != nil {
+		return TransportConfig{}, err
+	}
+
+	timeout := profile.HttpRequestTimeout
+	if timeout <= 0 {
+		timeout = defaultProfileRequestTimeout
+	}
+	poolConnections := profile.HttpPoolConnections
+	if poolConnections <= 0 {
+		poolConnections = defaultProfilePoolConnections
+	}
+
+	if profile.CAFile != "" || profile.CertFile != "" || profile.KeyFile != "" || profile.ServerName != "" {
+		return NewTLSTransportConfig(profile.CAFile, profile.CertFile, profile.KeyFile, profile.ServerName,
+			timeout, poolConnections)
+	}
+
+	sslVerify := profile.SslVerify
+	if sslVerify == "" {
+		sslVerify = "true"
+	}
+	return NewTransportConfig(sslVerify, timeout, poolConnections), nil
+}
+
+// Connector returns a connector to the grid of the profile name
+func (p Profiles) Connector(name string) (*Connector, error) {
+	hostConfig, err := p.HostConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	transportConfig, err := p.TransportConfig(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConnector(hostConfig, transportConfig, &WapiRequestBuilder{}, &WapiHttpRequestor{})
+}
+
+// ObjectManager returns an object manager of the grid of the profile name,
+// with the cloud attributes of the profile
+func (p Profiles) ObjectManager(name string) (*ObjectManager, error) {
+	conn, err := p.Connector(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewObjectManager(conn, p[name].CmpType, p[name].TenantID), nil
+}
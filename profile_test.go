@@ -0,0 +1,105 @@
+package ibclient
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiles", func() {
+	profilesYAML := `
+dev:
+  host: gm.dev.example.com
+  version: "2.11"
+  port: "443"
+  username: admin
+  password: infoblox
+  ssl_verify: "false"
+prod:
+  host: gm.prod.example.com
+  version: "2.11"
+  port: "443"
+  username: automation
+  password_env: IBCLIENT_TEST_PROD_PASSWORD
+  http_request_timeout: 60
+  cmp_type: Terraform
+  tenant_id: acme
+`
+
+	It("should parse YAML and JSON profiles", func() {
+		profiles, err := ParseProfiles([]byte(profilesYAML))
+		Expect(err).To(BeNil())
+		Expect(profiles).To(HaveLen(2))
+		Expect(profiles["prod"].TenantID).To(Equal("acme"))
+
+		profiles, err = ParseProfiles([]byte(`{"dev": {"host": "gm.dev.example.com", "negotiate_version": true}}`))
+		Expect(err).To(BeNil())
+		Expect(profiles["dev"]).To(Equal(Profile{Host: "gm.dev.example.com", NegotiateVersion: true}))
+	})
+
+	It("should load the profiles of a file", func() {
+		dir, err := ioutil.TempDir("", "profiles")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "profiles.yaml")
+		Expect(ioutil.WriteFile(path, []byte(profilesYAML), 0600)).To(BeNil())
+
+		profiles, err := LoadProfiles(path)
+		Expect(err).To(BeNil())
+		Expect(profiles["dev"].Host).To(Equal("gm.dev.example.com"))
+	})
+
+	It("should build the configurations of a profile", func() {
+		profiles, _ := ParseProfiles([]byte(profilesYAML))
+
+		hostConfig, err := profiles.HostConfig("dev")
+		Expect(err).To(BeNil())
+		Expect(hostConfig).To(Equal(HostConfig{Host: "gm.dev.example.com", Version: "2.11", Port: "443",
+			Username: "admin", Password: "infoblox"}))
+
+		transportConfig, err := profiles.TransportConfig("dev")
+		Expect(err).To(BeNil())
+		Expect(transportConfig.SslVerify).To(BeFalse())
+		Expect(transportConfig.HttpRequestTimeout).To(BeEquivalentTo(20))
+
+		transportConfig, err = profiles.TransportConfig("prod")
+		Expect(err).To(BeNil())
+		Expect(transportConfig.SslVerify).To(BeTrue())
+		Expect(transportConfig.HttpRequestTimeout).To(BeEquivalentTo(60))
+
+		_, err = profiles.HostConfig("stage")
+		Expect(err).To(MatchError("profile 'stage' not found"))
+	})
+
+	It("should read the password from the environment", func() {
+		profiles, _ := ParseProfiles([]byte(profilesYAML))
+
+		os.Unsetenv("IBCLIENT_TEST_PROD_PASSWORD")
+		_, err := profiles.HostConfig("prod")
+		Expect(err).NotTo(BeNil())
+
+		os.Setenv("IBCLIENT_TEST_PROD_PASSWORD", "secret")
+		defer os.Unsetenv("IBCLIENT_TEST_PROD_PASSWORD")
+		hostConfig, err := profiles.HostConfig("prod")
+		Expect(err).To(BeNil())
+		Expect(hostConfig.Password).To(Equal("secret"))
+	})
+
+	It("should return object managers with the cloud attributes of the profile", func() {
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+		os.Setenv("IBCLIENT_TEST_PROD_PASSWORD", "secret")
+		defer os.Unsetenv("IBCLIENT_TEST_PROD_PASSWORD")
+		profiles, _ := ParseProfiles([]byte(profilesYAML))
+
+		objMgr, err := profiles.ObjectManager("prod")
+		Expect(err).To(BeNil())
+		Expect(objMgr.cmpType).To(Equal("Terraform"))
+		Expect(objMgr.tenantID).To(Equal("acme"))
+		Expect(objMgr.connector.(*Connector).HostConfig.Host).To(Equal("gm.prod.example.com"))
+	})
+})
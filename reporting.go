@@ -0,0 +1,205 @@
+package ibclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ReportingClient talks to the search REST API exposed by the NIOS
+// Reporting server (Splunk) so that saved searches can be run and their
+// results fetched alongside regular WAPI provisioning.
+type ReportingClient struct {
+	HostConfig HostConfig
+	Requestor  HttpRequestor
+	// Namespace user and app used for saved searches, defaults to
+	// "nobody" and "search".
+	Owner string
+	App   string
+}
+
+// ReportingJob represents a dispatched search job
+type ReportingJob struct {
+	Sid           string  `json:"sid"`
+	DispatchState string  `json:"dispatchState,omitempty"`
+	IsDone        bool    `json:"isDone,omitempty"`
+	IsFailed      bool    `json:"isFailed,omitempty"`
+	DoneProgress  float64 `json:"doneProgress,omitempty"`
+	ResultCount   int     `json:"resultCount,omitempty"`
+}
+
+type reportingJobStatus struct {
+	Entry []struct {
+		Content ReportingJob `json:"content"`
+	} `json:"entry"`
+}
+
+type reportingResults struct {
+	Results []map[string]interface{} `json:"results"`
+}
+
+func NewReportingClient(hostConfig HostConfig, transportConfig TransportConfig, requestor HttpRequestor) *ReportingClient {
+	rc := &ReportingClient{
+		HostConfig: hostConfig,
+		Requestor:  requestor,
+		Owner:      "nobody",
+		App:        "search",
+	}
+	rc.Requestor.Init(transportConfig)
+
+	return rc
+}
+
+func (rc *ReportingClient) buildUrl(path []string, vals url.Values) string {
+	if vals == nil {
+		vals = url.Values{}
+	}
+	vals.Set("output_mode", "json")
+
+	escaped := make([]string, len(path))
+	for i, p := range path {
+		escaped[i] = url.PathEscape(p)
+	}
+	u := url.URL{
+		Scheme:   "https",
+		Host:     rc.HostConfig.Host + ":" + rc.HostConfig.Port,
+		Path:     "/" + strings.Join(path, "/"),
+		RawPath:  "/" + strings.Join(escaped, "/"),
+		RawQuery: vals.Encode(),
+	}
+
+	return u.String()
+}
+
+func (rc *ReportingClient) makeRequest(t RequestType, path []string, vals url.Values, form url.Values) ([]byte, error) {
+	var body []byte
+	if form != nil {
+		body = []byte(form.Encode())
+	}
+
+	req, err := http.NewRequest(t.toMethod(), rc.buildUrl(path, vals), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.SetBasicAuth(rc.HostConfig.Username, rc.HostConfig.Password)
+
+	return rc.Requestor.SendRequest(req)
+}
+
+func (rc *ReportingClient) parseSid(resp []byte) (*ReportingJob, error) {
+	job := &ReportingJob{}
+	if err := json.Unmarshal(resp, job); err != nil {
+		log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		return nil, err
+	}
+	if job.Sid == "" {
+		return nil, fmt.Errorf("reporting server did not return a search id")
+	}
+
+	return job, nil
+}
+
+// RunSavedSearch dispatches the saved search with the given name.
+// args are passed as dispatch arguments, e.g. "dispatch.earliest_time".
+func (rc *ReportingClient) RunSavedSearch(name string, args map[string]string) (*ReportingJob, error) {
+	form := url.Values{}
+	for k, v := range args {
+		form.Set(k, v)
+	}
+
+	path := []string{"servicesNS", rc.Owner, rc.App, "saved", "searches", name, "dispatch"}
+	resp, err := rc.makeRequest(CREATE, path, nil, form)
+	if err != nil {
+		log.Printf("RunSavedSearch request error: '%s'\n", err)
+		return nil, err
+	}
+
+	return rc.parseSid(resp)
+}
+
+// Search starts an ad-hoc search job, the query must start with a
+// search command, e.g. "search index=ib_dns".
+func (rc *ReportingClient) Search(query string) (*ReportingJob, error) {
+	form := url.Values{}
+	form.Set("search", query)
+
+	resp, err := rc.makeRequest(CREATE, []string{"services", "search", "jobs"}, nil, form)
+	if err != nil {
+		log.Printf("Search request error: '%s'\n", err)
+		return nil, err
+	}
+
+	return rc.parseSid(resp)
+}
+
+// GetJob returns the current state of the search job
+func (rc *ReportingClient) GetJob(sid string) (*ReportingJob, error) {
+	resp, err := rc.makeRequest(GET, []string{"services", "search", "jobs", sid}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var status reportingJobStatus
+	if err = json.Unmarshal(resp, &status); err != nil {
+		log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		return nil, err
+	}
+	if len(status.Entry) == 0 {
+		return nil, fmt.Errorf("search job '%s' not found", sid)
+	}
+
+	job := status.Entry[0].Content
+	job.Sid = sid
+	return &job, nil
+}
+
+// WaitForJob polls the job every interval until it is done or the
+// timeout expires.
+func (rc *ReportingClient) WaitForJob(sid string, interval time.Duration, timeout time.Duration) (*ReportingJob, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := rc.GetJob(sid)
+		if err != nil {
+			return nil, err
+		}
+		if job.IsFailed {
+			return job, fmt.Errorf("search job '%s' failed", sid)
+		}
+		if job.IsDone {
+			return job, nil
+		}
+		if time.Now().After(deadline) {
+			return job, fmt.Errorf("timed out waiting for search job '%s'", sid)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// GetResults fetches the results of a finished job, count of 0 returns
+// all results.
+func (rc *ReportingClient) GetResults(sid string, offset int, count int) ([]map[string]interface{}, error) {
+	vals := url.Values{}
+	vals.Set("offset", fmt.Sprintf("%d", offset))
+	vals.Set("count", fmt.Sprintf("%d", count))
+
+	resp, err := rc.makeRequest(GET, []string{"services", "search", "jobs", sid, "results"}, vals, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res reportingResults
+	if err = json.Unmarshal(resp, &res); err != nil {
+		log.Printf("Cannot unmarshall '%s', err: '%s'\n", string(resp), err)
+		return nil, err
+	}
+
+	return res.Results, nil
+}
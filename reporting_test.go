@@ -0,0 +1,80 @@
+package ibclient
+
+import (
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeReportingRequestor struct {
+	reqs []*http.Request
+	body []string
+	res  [][]byte
+}
+
+func (hr *fakeReportingRequestor) Init(config TransportConfig) {}
+
+func (hr *fakeReportingRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	b, _ := ioutil.ReadAll(req.Body)
+	hr.reqs = append(hr.reqs, req)
+	hr.body = append(hr.body, string(b))
+
+	res := hr.res[0]
+	hr.res = hr.res[1:]
+	return res, nil
+}
+
+var _ = Describe("Reporting Client", func() {
+	hostConfig := HostConfig{
+		Host:     "172.22.18.70",
+		Port:     "8089",
+		Username: "admin",
+		Password: "infoblox",
+	}
+	transportConfig := NewTransportConfig("false", 20, 10)
+
+	Describe("RunSavedSearch", func() {
+		requestor := &fakeReportingRequestor{
+			res: [][]byte{[]byte(`{"sid":"admin__search__RMD5a1b2"}`)},
+		}
+		rc := NewReportingClient(hostConfig, transportConfig, requestor)
+
+		It("should dispatch the saved search and return its sid", func() {
+			job, err := rc.RunSavedSearch("DNS Top Clients", map[string]string{"dispatch.earliest_time": "-1h"})
+			Expect(err).To(BeNil())
+			Expect(job.Sid).To(Equal("admin__search__RMD5a1b2"))
+
+			req := requestor.reqs[0]
+			Expect(req.Method).To(Equal("POST"))
+			Expect(req.URL.String()).To(Equal(
+				"https://172.22.18.70:8089/servicesNS/nobody/search/saved/searches/DNS%20Top%20Clients/dispatch?output_mode=json"))
+			Expect(requestor.body[0]).To(Equal("dispatch.earliest_time=-1h"))
+		})
+	})
+
+	Describe("WaitForJob and GetResults", func() {
+		requestor := &fakeReportingRequestor{
+			res: [][]byte{
+				[]byte(`{"entry":[{"content":{"dispatchState":"RUNNING","isDone":false}}]}`),
+				[]byte(`{"entry":[{"content":{"dispatchState":"DONE","isDone":true,"resultCount":1}}]}`),
+				[]byte(`{"results":[{"client":"10.0.0.1","count":"42"}]}`),
+			},
+		}
+		rc := NewReportingClient(hostConfig, transportConfig, requestor)
+
+		It("should poll the job until done and fetch its results", func() {
+			job, err := rc.WaitForJob("sid1", time.Millisecond, time.Second)
+			Expect(err).To(BeNil())
+			Expect(job.DispatchState).To(Equal("DONE"))
+			Expect(job.ResultCount).To(Equal(1))
+
+			res, err := rc.GetResults("sid1", 0, 0)
+			Expect(err).To(BeNil())
+			Expect(res).To(Equal([]map[string]interface{}{{"client": "10.0.0.1", "count": "42"}}))
+			Expect(requestor.reqs[2].URL.RawQuery).To(Equal("count=0&offset=0&output_mode=json"))
+		})
+	})
+})
@@ -0,0 +1,218 @@
+package ibclient
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// RetryPolicy configures the retries of the requests of a Connector.
+// Requests are sent once when MaxAttempts is lower than 2.
+//
+// Creations are only retried with RetryCreates. The creations allocating
+// the next available address or network are never sent again after an
+// error: the grid may have applied them, and sending them again would
+// allocate a second address or network.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent
+	MaxAttempts int
+	// RetryCreates retries the creations too. A creation applied by the
+	// grid before the error is reported may then fail with a
+	// ConflictError, or create a second object of a type allowing
+	// duplicates.
+	RetryCreates bool
+	// InitialBackoff is the delay before the first retry,
+	// DefaultInitialBackoff when zero
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries, DefaultMaxBackoff when zero
+	MaxBackoff time.Duration
+	// Multiplier grows the delay between retries, 2 when lower than 1
+	Multiplier float64
+	// Jitter shortens each delay by a random fraction of up to Jitter,
+	// capped to 1, so that clients failing together do not retry together
+	Jitter float64
+	// RetryOn tells whether a failed request is retried, IsRetryable when nil
+	RetryOn func(err error) bool
+}
+
+// backoff returns the delay before the retry following attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	if d <= 0 {
+		d = DefaultInitialBackoff
+	}
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = DefaultMaxBackoff
+	}
+	mult := p.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+
+	for i := 1; i < attempt && d < max; i++ {
+		d = time.Duration(float64(d) * mult)
+	}
+	if d > max {
+		d = max
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+
+	return d
+}
+
+// retries tells whether the request of type t with body obj may be sent
+// again after an error
+func (p RetryPolicy) retries(t RequestType, obj IBObject, queryParams QueryParams) bool {
+	return t != CREATE || p.RetryCreates && !allocates(t, obj, queryParams)
+}
+
+// allocates tells whether the request allocates the next available
+// address or network
+func allocates(t RequestType, obj IBObject, queryParams QueryParams) bool {
+	if t != CREATE {
+		return false
+	}
+	return strings.HasPrefix(queryParams.function, "next_available") || obj != nil && allocatesNext(obj)
+}
+
+// mayBeApplied tells whether the request which failed with err may have
+// been applied by the grid, which rejects with a 4xx error the requests it
+// does not apply
+func mayBeApplied(err error) bool {
+	var wapiErr *WapiError
+	return !errors.As(err, &wapiErr) || wapiErr.StatusCode >= 500
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.RetryOn != nil {
+		return p.RetryOn(err)
+	}
+	return IsRetryable(err)
+}
+
+// permanentErrors are the typed WAPI errors which are never retried,
+// whatever their status
+var permanentErrors = []error{ErrNotFound, ErrConflict, ErrAuth, ErrAccountLocked,
+	ErrRequestTooLarge, ErrAddressSpaceExhausted, ErrNoAvailableNetwork}
+
+// IsRetryable tells whether err, or an error it wraps, is transient: a 429
+// or 5xx response of WAPI, a timeout or a connection reset by the grid
+func IsRetryable(err error) bool {
+	var wapiErr *WapiError
+	if errors.As(err, &wapiErr) {
+		for _, permanent := range permanentErrors {
+			if errors.Is(err, permanent) {
+				return false
+			}
+		}
+		return wapiErr.StatusCode == http.StatusTooManyRequests || wapiErr.StatusCode >= 500
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "connection reset by peer")
+}
+
+// RateLimiter is a token bucket limiting the rate of the requests of the
+// Connectors sharing it
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests
+// on average, and bursts of up to burst requests
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait before it is available
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 || l.rate <= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// sleep waits for d, or until the context of the Connector is done
+func (c *Connector) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	if c.ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+// send sends req once the rate limiter of the Connector allows it
+func (c *Connector) send(req *http.Request) ([]byte, error) {
+	if c.RateLimiter != nil {
+		if err := c.sleep(c.RateLimiter.reserve()); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.sendHooked(req)
+}
@@ -0,0 +1,152 @@
+package ibclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type flakyRequestor struct {
+	errs  []error
+	sends int
+}
+
+func (hr *flakyRequestor) Init(config TransportConfig) {}
+
+func (hr *flakyRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	hr.sends++
+	if len(hr.errs) > 0 {
+		err := hr.errs[0]
+		hr.errs = hr.errs[1:]
+		return nil, err
+	}
+
+	return []byte(`"network/ZG5zLm5ldHdvcms:10.0.0.0/24/default"`), nil
+}
+
+var _ = Describe("Retries", func() {
+	unavailable := newWapiError(http.StatusServiceUnavailable, "503 Service Unavailable", nil)
+	throttled := newWapiError(http.StatusTooManyRequests, "429 Too Many Requests", nil)
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryCreates: true}
+
+	It("should retry transient errors with backoff", func() {
+		// each attempt falls back to the Grid Master once
+		requestor := &flakyRequestor{errs: []error{unavailable, unavailable, throttled, throttled}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor, RetryPolicy: policy}
+
+		ref, err := conn.CreateObject(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"}))
+		Expect(err).To(BeNil())
+		Expect(ref).To(Equal("network/ZG5zLm5ldHdvcms:10.0.0.0/24/default"))
+		Expect(requestor.sends).To(Equal(5))
+	})
+
+	It("should give up after MaxAttempts", func() {
+		requestor := &flakyRequestor{errs: []error{unavailable, unavailable, unavailable, unavailable, unavailable, unavailable}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor, RetryPolicy: policy}
+
+		_, err := conn.CreateObject(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"}))
+		Expect(err).To(Equal(unavailable))
+		Expect(requestor.sends).To(Equal(6))
+	})
+
+	It("should not retry other errors", func() {
+		notFound := newWapiError(http.StatusNotFound, "404 Not Found", nil)
+		requestor := &flakyRequestor{errs: []error{notFound, notFound}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor, RetryPolicy: policy}
+
+		_, err := conn.CreateObject(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"}))
+		Expect(err).To(Equal(notFound))
+		Expect(requestor.sends).To(Equal(2))
+	})
+
+	It("should not retry creations by default", func() {
+		requestor := &flakyRequestor{errs: []error{unavailable, unavailable, unavailable, unavailable}}
+		noCreates := policy
+		noCreates.RetryCreates = false
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor, RetryPolicy: noCreates}
+
+		_, err := conn.CreateObject(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"}))
+		Expect(err).To(Equal(unavailable))
+		Expect(requestor.sends).To(Equal(2))
+
+		requestor = &flakyRequestor{errs: []error{unavailable, unavailable}}
+		conn.Requestor = requestor
+		_, err = conn.DeleteObject("network/ZG5zLm5ldHdvcms:10.0.0.0/24/default")
+		Expect(err).To(BeNil())
+		Expect(requestor.sends).To(Equal(3))
+	})
+
+	It("should send a failed allocation once", func() {
+		requestor := &flakyRequestor{errs: []error{unavailable, unavailable, unavailable}}
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor, RetryPolicy: policy}
+
+		_, err := conn.CreateObject(NewNetwork(Network{NetviewName: "default",
+			Cidr: "func:nextavailablenetwork:10.0.0.0/8,default,24"}))
+		Expect(err).To(Equal(unavailable))
+		Expect(requestor.sends).To(Equal(1))
+
+		requestor = &flakyRequestor{errs: []error{unavailable, unavailable, unavailable}}
+		conn.Requestor = requestor
+		err = conn.callFunction("network/ZG5zLm5ldHdvcms:10.0.0.0/24/default", "next_available_ip",
+			&nextAvailableIPs{Num: 2}, nil)
+		Expect(err).To(Equal(unavailable))
+		Expect(requestor.sends).To(Equal(1))
+	})
+
+	It("should not retry nor fall back on a locked account", func() {
+		locked := newWapiError(http.StatusUnauthorized, "401 Authorization Required", []byte("Account is locked"))
+		requestor := &flakyRequestor{errs: []error{locked, locked}}
+		retryAll := policy
+		retryAll.RetryOn = func(err error) bool { return true }
+		conn := &Connector{RequestBuilder: &WapiRequestBuilder{}, Requestor: requestor, RetryPolicy: retryAll}
+
+		_, err := conn.CreateObject(NewNetwork(Network{NetviewName: "default", Cidr: "10.0.0.0/24"}))
+		Expect(errors.Is(err, ErrAccountLocked)).To(BeTrue())
+		Expect(requestor.sends).To(Equal(1))
+	})
+
+	It("should grow the backoff up to MaxBackoff", func() {
+		p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+		Expect(p.backoff(1)).To(Equal(time.Second))
+		Expect(p.backoff(2)).To(Equal(2 * time.Second))
+		Expect(p.backoff(3)).To(Equal(4 * time.Second))
+		Expect(p.backoff(4)).To(Equal(5 * time.Second))
+		Expect(RetryPolicy{}.backoff(1)).To(Equal(DefaultInitialBackoff))
+	})
+
+	It("should shorten the backoff by up to Jitter", func() {
+		p := RetryPolicy{InitialBackoff: time.Second, Jitter: 0.5}
+		for i := 0; i < 10; i++ {
+			d := p.backoff(1)
+			Expect(d).To(BeNumerically(">=", 500*time.Millisecond))
+			Expect(d).To(BeNumerically("<=", time.Second))
+		}
+	})
+
+	It("should classify transient errors", func() {
+		Expect(IsRetryable(unavailable)).To(BeTrue())
+		Expect(IsRetryable(throttled)).To(BeTrue())
+		Expect(IsRetryable(errors.New("read tcp 10.0.0.1:443: connection reset by peer"))).To(BeTrue())
+		Expect(IsRetryable(newWapiError(http.StatusBadRequest, "400 Bad Request", nil))).To(BeFalse())
+	})
+
+	It("should classify the wrapped and typed WAPI errors", func() {
+		Expect(IsRetryable(fmt.Errorf("cannot create the network: %w", unavailable))).To(BeTrue())
+		Expect(IsRetryable(&ConflictError{WapiError{StatusCode: http.StatusServiceUnavailable}})).To(BeFalse())
+		Expect(IsRetryable(&NotFoundError{WapiError{StatusCode: http.StatusNotFound}})).To(BeFalse())
+		Expect(IsRetryable(fmt.Errorf("read failed: %w", io.ErrUnexpectedEOF))).To(BeTrue())
+	})
+
+	It("should limit the rate of requests", func() {
+		limiter := NewRateLimiter(10, 2)
+		Expect(limiter.reserve()).To(BeZero())
+		Expect(limiter.reserve()).To(BeZero())
+		Expect(limiter.reserve()).To(BeNumerically("~", 100*time.Millisecond, 10*time.Millisecond))
+		Expect(limiter.reserve()).To(BeNumerically("~", 200*time.Millisecond, 10*time.Millisecond))
+	})
+})
@@ -0,0 +1,160 @@
+package ibclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// schemaQueryVersion is the WAPI version used to query the schema of the
+// grid when the Connector has no version yet
+const schemaQueryVersion = "2.0"
+
+// WapiSchema is the schema of the grid: the WAPI versions it supports and
+// the object types of the requested version
+type WapiSchema struct {
+	RequestedVersion  string   `json:"requested_version"`
+	SupportedObjects  []string `json:"supported_objects"`
+	SupportedVersions []string `json:"supported_versions"`
+}
+
+// SupportsObject reports whether the object type, e.g. "dtc:pool", is
+// available in the requested version
+func (s *WapiSchema) SupportsObject(objType string) bool {
+	for _, o := range s.SupportedObjects {
+		if o == objType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldSchema describes a field of an object type. Supports holds the
+// operations allowed on the field: r(ead), w(rite), u(pdate), s(earch)
+// and d(elete).
+type FieldSchema struct {
+	Name         string   `json:"name"`
+	Type         []string `json:"type"`
+	Supports     string   `json:"supports"`
+	IsArray      bool     `json:"is_array"`
+	SearchableBy string   `json:"searchable_by,omitempty"`
+	Standard     bool     `json:"standard_field"`
+}
+
+// ObjectSchema is the schema of an object type in the requested version
+type ObjectSchema struct {
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Fields  []FieldSchema `json:"fields"`
+}
+
+// Field returns the schema of the field name, nil if the object type has
+// no such field in the requested version
+func (s *ObjectSchema) Field(name string) *FieldSchema {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// schemaObject requests the schema of objectType, of the grid if empty
+type schemaObject struct {
+	IBBase `json:"-"`
+}
+
+func (c *Connector) getSchema(objType string, res interface{}) error {
+	obj := &schemaObject{IBBase{objectType: objType}}
+	resp, err := c.makeRequest(GET, obj, "", QueryParams{forceProxy: false, schema: true})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(resp, res)
+}
+
+// GetSchema returns the schema of the grid for the WAPI version of the
+// Connector
+func (c *Connector) GetSchema() (*WapiSchema, error) {
+	schema := &WapiSchema{}
+	if err := c.getSchema("", schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// GetObjectSchema returns the schema of objType, e.g. "record:host", for
+// the WAPI version of the Connector, to check which fields the grid
+// supports before sending them
+func (c *Connector) GetObjectSchema(objType string) (*ObjectSchema, error) {
+	schema := &ObjectSchema{}
+	if err := c.getSchema(objType, schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// compareWapiVersions compares the WAPI versions a and b, e.g. "2.9" and
+// "2.10", component by component
+func compareWapiVersions(a string, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// NegotiateVersion selects the highest WAPI version supported by the grid
+// up to maxVersion, or the highest one if maxVersion is empty, and uses it
+// for the following requests of the Connector and of the copies made by
+// WithContext
+func (c *Connector) NegotiateVersion(maxVersion string) (string, error) {
+	unlock := c.lockGrid()
+	if c.wapiVersion() == "" {
+		c.setWapiVersion(schemaQueryVersion)
+	}
+	unlock()
+
+	schema, err := c.GetSchema()
+	if err != nil {
+		return "", err
+	}
+
+	version := ""
+	for _, v := range schema.SupportedVersions {
+		if maxVersion != "" && compareWapiVersions(v, maxVersion) > 0 {
+			continue
+		}
+		if version == "" || compareWapiVersions(v, version) > 0 {
+			version = v
+		}
+	}
+	if version == "" {
+		return "", fmt.Errorf("the grid supports no WAPI version up to %s", maxVersion)
+	}
+
+	defer c.lockGrid()()
+
+	c.setWapiVersion(version)
+
+	return version, nil
+}
@@ -0,0 +1,133 @@
+package ibclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// schemaRequestor returns the schema of the grid to the requests sent
+// concurrently and records their URLs
+type schemaRequestor struct {
+	mu     sync.Mutex
+	schema []byte
+	urls   []string
+}
+
+func (r *schemaRequestor) Init(cfg TransportConfig) {}
+
+func (r *schemaRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.urls = append(r.urls, req.URL.String())
+	return r.schema, nil
+}
+
+func (r *schemaRequestor) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.urls[len(r.urls)-1]
+}
+
+var _ = Describe("WAPI Schema", func() {
+	hostConfig := HostConfig{Host: "172.22.18.66", Port: "443"}
+	gridSchema := []byte(`{"requested_version": "2.0",
+		"supported_objects": ["network", "record:host", "dtc:pool"],
+		"supported_versions": ["1.0", "2.0", "2.9", "2.10", "2.11", "2.12"]}`)
+
+	It("should negotiate the highest version up to the given one", func() {
+		requestor := &fakeReportingRequestor{res: [][]byte{gridSchema}}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+
+		version, err := conn.NegotiateVersion("2.11")
+		Expect(err).To(BeNil())
+		Expect(version).To(Equal("2.11"))
+		Expect(requestor.reqs[0].URL.String()).To(Equal("https://172.22.18.66:443/wapi/v2.0/?_schema=1"))
+		Expect(wrb.HostConfig.Version).To(Equal("2.11"))
+		Expect(conn.wapiVersion()).To(Equal("2.11"))
+	})
+
+	It("should negotiate the highest version of the grid without a limit", func() {
+		requestor := &fakeReportingRequestor{res: [][]byte{gridSchema, gridSchema}}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(hostConfig)
+		conn := &Connector{HostConfig: hostConfig, RequestBuilder: wrb, Requestor: requestor}
+
+		version, err := conn.NegotiateVersion("")
+		Expect(err).To(BeNil())
+		Expect(version).To(Equal("2.12"))
+
+		_, err = conn.NegotiateVersion("0.9")
+		Expect(err).To(MatchError("the grid supports no WAPI version up to 0.9"))
+	})
+
+	It("should send the requests of the connector copies with the negotiated version", func() {
+		OrigValidateConnector := ValidateConnector
+		ValidateConnector = MockValidateConnector
+		defer func() { ValidateConnector = OrigValidateConnector }()
+
+		cfg := hostConfig
+		cfg.Version = "2.11"
+		cfg.NegotiateVersion = true
+		requestor := &schemaRequestor{schema: gridSchema}
+		conn, err := NewConnector(cfg, TransportConfig{}, &WapiRequestBuilder{}, requestor)
+		Expect(err).To(BeNil())
+		copied := conn.WithContext(context.Background())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				copied.DeleteObject("network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default")
+			}()
+		}
+		version, err := conn.NegotiateVersion("")
+		wg.Wait()
+		Expect(err).To(BeNil())
+		Expect(version).To(Equal("2.12"))
+
+		copied.DeleteObject("network/ZG5zLm5ldHdvcmskMTAuMC4wLjAvMjQvMA:10.0.0.0/24/default")
+		Expect(requestor.last()).To(HavePrefix("https://172.22.18.66:443/wapi/v2.12/network/"))
+	})
+
+	It("should return the schema of the grid and of object types", func() {
+		cfg := hostConfig
+		cfg.Version = "2.11"
+		requestor := &fakeReportingRequestor{res: [][]byte{
+			gridSchema,
+			[]byte(`{"type": "record:host", "version": "2.11", "fields": [
+				{"name": "name", "type": ["string"], "supports": "rwus", "is_array": false, "searchable_by": "=~:", "standard_field": true},
+				{"name": "use_ttl", "type": ["bool"], "supports": "rwu", "is_array": false, "standard_field": false}]}`),
+		}}
+		wrb := &WapiRequestBuilder{}
+		wrb.Init(cfg)
+		conn := &Connector{HostConfig: cfg, RequestBuilder: wrb, Requestor: requestor}
+
+		schema, err := conn.GetSchema()
+		Expect(err).To(BeNil())
+		Expect(schema.SupportsObject("dtc:pool")).To(BeTrue())
+		Expect(schema.SupportsObject("dtc:lbdn")).To(BeFalse())
+
+		objSchema, err := conn.GetObjectSchema("record:host")
+		Expect(err).To(BeNil())
+		Expect(requestor.reqs[1].URL.String()).To(Equal("https://172.22.18.66:443/wapi/v2.11/record:host?_schema=1"))
+		Expect(objSchema.Field("name").SearchableBy).To(Equal("=~:"))
+		Expect(objSchema.Field("use_ttl").Supports).To(Equal("rwu"))
+		Expect(objSchema.Field("use_options")).To(BeNil())
+	})
+
+	It("should compare versions numerically", func() {
+		Expect(compareWapiVersions("2.10", "2.9")).To(Equal(1))
+		Expect(compareWapiVersions("2.9", "2.10")).To(Equal(-1))
+		Expect(compareWapiVersions("2.7.1", "2.7")).To(Equal(1))
+		Expect(compareWapiVersions("2.11", "2.11")).To(Equal(0))
+	})
+})